@@ -0,0 +1,169 @@
+package builtins
+
+// AsyncToGeneratorSource runs generator functions the async functions are lowered to,
+// yielded values are awaited and sent back to the generator, the returned promise
+// is settled with the value the generator returns or the exception it throws
+const AsyncToGeneratorSource = `function asyncToGenerator(fn) {
+  return function () {
+    var self = this, args = arguments;
+    return new Promise(function (resolve, reject) {
+      var gen = fn.apply(self, args);
+      function step(key, arg) {
+        try {
+          var info = gen[key](arg);
+          var value = info.value;
+        } catch (error) {
+          reject(error);
+          return;
+        }
+        if (info.done) {
+          resolve(value);
+        } else {
+          Promise.resolve(value).then(next, _throw);
+        }
+      }
+      function next(value) { step("next", value); }
+      function _throw(err) { step("throw", err); }
+      next();
+    });
+  };
+}`
+
+// AwaitAsyncGeneratorSource marks awaits of async generators lowered to generators,
+// those yield { __await: value } for @wrapAsyncGenerator to resume them with the result
+const AwaitAsyncGeneratorSource = `function awaitAsyncGenerator(value) {
+  return { __await: value };
+}`
+
+// WrapAsyncGeneratorSource runs generator functions the async generators are lowered to.
+// Requests are queued and run one by one, awaits marked by @awaitAsyncGenerator resume
+// the generator while other yields settle the request. Awaits of @asyncGeneratorDelegate
+// are resumed by the request they are made for, so yield* returns when the delegate does
+const WrapAsyncGeneratorSource = `function wrapAsyncGenerator(fn) {
+  return function () {
+    var gen = fn.apply(this, arguments);
+    var queue = [];
+    function send(key, arg) {
+      return new Promise(function (resolve, reject) {
+        queue.push({ key: key, arg: arg, resolve: resolve, reject: reject });
+        if (queue.length === 1) resume(key, arg);
+      });
+    }
+    function resume(key, arg) {
+      try {
+        var result = gen[key](arg);
+        var value = result.value;
+        var awaited = !result.done && value !== null && typeof value === "object" &&
+          Object.prototype.hasOwnProperty.call(value, "__await");
+        Promise.resolve(awaited ? value.__await : value).then(function (arg) {
+          if (awaited) {
+            resume(key === "return" && value.__delegate ? "return" : "next", arg);
+            return;
+          }
+          settle(result.done ? "return" : "normal", arg);
+        }, function (err) {
+          resume("throw", err);
+        });
+      } catch (err) {
+        settle("throw", err);
+      }
+    }
+    function settle(type, value) {
+      var request = queue.shift();
+      if (type === "throw") {
+        request.reject(value);
+      } else {
+        request.resolve({ value: value, done: type === "return" });
+      }
+      if (queue.length) resume(queue[0].key, queue[0].arg);
+    }
+    var generator = {
+      next: function (arg) { return send("next", arg); },
+      "throw": function (arg) { return send("throw", arg); },
+      "return": function (arg) { return send("return", arg); }
+    };
+    if (typeof Symbol === "function" && Symbol.asyncIterator) {
+      generator[Symbol.asyncIterator] = function () { return this; };
+    }
+    return generator;
+  };
+}`
+
+// AsyncIteratorSource gets the iterator for await loops and yield* of async generators,
+// sync iterables are iterated with the values awaited
+const AsyncIteratorSource = `function asyncIterator(iterable) {
+  var method;
+  var hasSymbol = typeof Symbol === "function";
+  if (iterable != null) {
+    method = hasSymbol && Symbol.asyncIterator ? iterable[Symbol.asyncIterator] : iterable["@@asyncIterator"];
+    if (method != null) return method.call(iterable);
+    method = hasSymbol && Symbol.iterator ? iterable[Symbol.iterator] : iterable["@@iterator"];
+    if (method != null) return fromSync(method.call(iterable));
+    if (typeof iterable.length === "number") {
+      var i = 0;
+      return fromSync({
+        next: function () {
+          return i < iterable.length ? { value: iterable[i++], done: false } : { value: undefined, done: true };
+        }
+      });
+    }
+  }
+  throw new TypeError("Object is not async iterable");
+
+  function fromSync(iterator) {
+    function step(result) {
+      return Promise.resolve(result.value).then(function (value) {
+        return { value: value, done: result.done };
+      });
+    }
+    return {
+      next: function (arg) { return step(iterator.next(arg)); },
+      "return": function (arg) {
+        var ret = iterator["return"];
+        return ret == null ? Promise.resolve({ value: arg, done: true }) : step(ret.call(iterator, arg));
+      },
+      "throw": function (arg) {
+        var thr = iterator["throw"];
+        return thr == null ? Promise.reject(arg) : step(thr.call(iterator, arg));
+      }
+    };
+  }
+}`
+
+// AsyncGeneratorDelegateSource is the iterator yield* of async generators delegates to,
+// requests to the inner iterator are yielded as awaits and their results are passed back
+const AsyncGeneratorDelegateSource = `function asyncGeneratorDelegate(inner) {
+  var iterator = {}, waiting = false;
+  function pump(key, value) {
+    waiting = true;
+    value = new Promise(function (resolve) { resolve(inner[key](value)); });
+    return { done: false, value: { __await: value, __delegate: true } };
+  }
+  iterator[typeof Symbol === "function" && Symbol.iterator || "@@iterator"] = function () { return this; };
+  iterator.next = function (value) {
+    if (waiting) {
+      waiting = false;
+      return value;
+    }
+    return pump("next", value);
+  };
+  if (typeof inner["throw"] === "function") {
+    iterator["throw"] = function (value) {
+      if (waiting) {
+        waiting = false;
+        throw value;
+      }
+      return pump("throw", value);
+    };
+  }
+  if (typeof inner["return"] === "function") {
+    iterator["return"] = function (value) {
+      if (waiting) {
+        waiting = false;
+        return value;
+      }
+      return pump("return", value);
+    };
+  }
+  return iterator;
+}`
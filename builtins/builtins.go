@@ -1,13 +1,14 @@
 package builtins
 
 import (
+	"strings"
 	"yawp/parser/ast"
 )
 
 const emptyString = ""
 
 var Arguments = &ast.Identifier{
-	LegacyRef:  &ast.SymbolRef{
+	LegacyRef: &ast.SymbolRef{
 		Name:    "arguments",
 		Type:    ast.SRBuiltin,
 		Mangled: true,
@@ -15,18 +16,88 @@ var Arguments = &ast.Identifier{
 	Name: "arguments",
 }
 
-var SlicedArrayRest = &ast.Identifier{
-	LegacyRef: &ast.SymbolRef{
-		Name: "@slicedArrayRest",
-		Type: ast.SRBuiltin,
-	},
-	Name: "@slicedArrayRest",
+// Helper is the runtime function called by the lowered code, Name is the one
+// the Source declares and the runtime module exports
+type Helper struct {
+	Name   string
+	Source string
 }
 
-var ObjectRest = &ast.Identifier{
-	LegacyRef: &ast.SymbolRef{
-		Name: "@objectRest",
-		Type: ast.SRBuiltin,
-	},
-	Name: "@objectRest",
+var SlicedArrayRest = &Helper{Name: "slicedArrayRest", Source: SlicedArrayRestSource}
+var ObjectRest = &Helper{Name: "objectRest", Source: ObjectRestSource}
+var ApplyDecorators = &Helper{Name: "applyDecs", Source: ApplyDecoratorsSource}
+var ApplyDecoratedDescriptor = &Helper{Name: "applyDecoratedDescriptor", Source: ApplyDecoratedDescriptorSource}
+var InitializerDefineProperty = &Helper{Name: "initializerDefineProperty", Source: InitializerDefinePropertySource}
+var ClassCallCheck = &Helper{Name: "classCallCheck", Source: ClassCallCheckSource}
+var Inherits = &Helper{Name: "inherits", Source: InheritsSource}
+var SuperGet = &Helper{Name: "superGet", Source: SuperGetSource}
+var SuperSet = &Helper{Name: "superSet", Source: SuperSetSource}
+var ClassPrivateFieldGet = &Helper{Name: "classPrivateFieldGet", Source: ClassPrivateFieldGetSource}
+var ClassPrivateFieldSet = &Helper{Name: "classPrivateFieldSet", Source: ClassPrivateFieldSetSource}
+var ClassPrivateFieldDestructureSet = &Helper{Name: "classPrivateFieldDestructureSet", Source: ClassPrivateFieldDestructureSetSource}
+var ClassPrivateMethodGet = &Helper{Name: "classPrivateMethodGet", Source: ClassPrivateMethodGetSource}
+var ClassStaticPrivateFieldGet = &Helper{Name: "classStaticPrivateFieldGet", Source: ClassStaticPrivateFieldGetSource}
+var ClassStaticPrivateFieldSet = &Helper{Name: "classStaticPrivateFieldSet", Source: ClassStaticPrivateFieldSetSource}
+var ClassStaticPrivateFieldDestructureSet = &Helper{Name: "classStaticPrivateFieldDestructureSet", Source: ClassStaticPrivateFieldDestructureSetSource}
+var ClassStaticPrivateMethodGet = &Helper{Name: "classStaticPrivateMethodGet", Source: ClassStaticPrivateMethodGetSource}
+var WrapGenerator = &Helper{Name: "wrapGenerator", Source: WrapGeneratorSource}
+var AsyncToGenerator = &Helper{Name: "asyncToGenerator", Source: AsyncToGeneratorSource}
+var WrapAsyncGenerator = &Helper{Name: "wrapAsyncGenerator", Source: WrapAsyncGeneratorSource}
+var AwaitAsyncGenerator = &Helper{Name: "awaitAsyncGenerator", Source: AwaitAsyncGeneratorSource}
+var AsyncIterator = &Helper{Name: "asyncIterator", Source: AsyncIteratorSource}
+var AsyncGeneratorDelegate = &Helper{Name: "asyncGeneratorDelegate", Source: AsyncGeneratorDelegateSource}
+var DefineProperty = &Helper{Name: "defineProperty", Source: DefinePropertySource}
+var ObjectSpread = &Helper{Name: "objectSpread", Source: ObjectSpreadSource}
+var TaggedTemplateLiteral = &Helper{Name: "taggedTemplateLiteral", Source: TaggedTemplateLiteralSource}
+var GetIterator = &Helper{Name: "getIterator", Source: GetIteratorSource}
+var ToConsumableArray = &Helper{Name: "toConsumableArray", Source: ToConsumableArraySource}
+var SlicedToArray = &Helper{Name: "slicedToArray", Source: SlicedToArraySource}
+var TDZ = &Helper{Name: "tdz", Source: TDZSource}
+var FlowTypeError = &Helper{Name: "flowTypeError", Source: FlowTypeErrorSource}
+
+// Helpers are all the runtime helpers, see Runtime
+var Helpers = []*Helper{
+	SlicedArrayRest,
+	ObjectRest,
+	ApplyDecorators,
+	ApplyDecoratedDescriptor,
+	InitializerDefineProperty,
+	ClassCallCheck,
+	Inherits,
+	SuperGet,
+	SuperSet,
+	ClassPrivateFieldGet,
+	ClassPrivateFieldSet,
+	ClassPrivateFieldDestructureSet,
+	ClassPrivateMethodGet,
+	ClassStaticPrivateFieldGet,
+	ClassStaticPrivateFieldSet,
+	ClassStaticPrivateFieldDestructureSet,
+	ClassStaticPrivateMethodGet,
+	WrapGenerator,
+	AsyncToGenerator,
+	WrapAsyncGenerator,
+	AwaitAsyncGenerator,
+	AsyncIterator,
+	AsyncGeneratorDelegate,
+	DefineProperty,
+	ObjectSpread,
+	TaggedTemplateLiteral,
+	GetIterator,
+	ToConsumableArray,
+	SlicedToArray,
+	TDZ,
+	FlowTypeError,
+}
+
+// Runtime is the source of the module exporting all the helpers,
+// the transpiled modules import those from it when options.Runtime is set
+func Runtime() string {
+	sources := make([]string, 0, len(Helpers))
+
+	for _, helper := range Helpers {
+		sources = append(sources, "export "+helper.Source)
+	}
+
+	return strings.Join(sources, "\n\n") + "\n"
 }
@@ -0,0 +1,104 @@
+package builtins
+
+// ClassCallCheckSource prevents calling lowered classes as functions
+const ClassCallCheckSource = `function classCallCheck(instance, Constructor) {
+  if (!(instance instanceof Constructor)) throw new TypeError("Cannot call a class as a function");
+}`
+
+// InheritsSource links prototypes of lowered classes, statics are inherited through the constructors chain
+const InheritsSource = `function inherits(subClass, superClass) {
+  if (typeof superClass !== "function" && superClass !== null) {
+    throw new TypeError("Super expression must either be null or a function");
+  }
+  subClass.prototype = Object.create(superClass && superClass.prototype, {
+    constructor: { value: subClass, writable: true, configurable: true }
+  });
+  if (superClass) {
+    if (Object.setPrototypeOf) Object.setPrototypeOf(subClass, superClass);
+    else subClass.__proto__ = superClass;
+  }
+}`
+
+// SuperGetSource reads super.x of lowered classes and objects, getters run with this as the receiver
+const SuperGetSource = `function superGet(object, property, receiver) {
+  while (object !== null && !Object.prototype.hasOwnProperty.call(object, property)) object = Object.getPrototypeOf(object);
+  if (object === null) return undefined;
+  var descriptor = Object.getOwnPropertyDescriptor(object, property);
+  return descriptor.get ? descriptor.get.call(receiver) : descriptor.value;
+}`
+
+// SuperSetSource calls the setter found by super.x = v, other values are defined on the receiver
+const SuperSetSource = `function superSet(object, property, value, receiver) {
+  while (object !== null && !Object.prototype.hasOwnProperty.call(object, property)) object = Object.getPrototypeOf(object);
+  var descriptor = object === null ? undefined : Object.getOwnPropertyDescriptor(object, property);
+  if (descriptor && descriptor.set) descriptor.set.call(receiver, value);
+  else if (descriptor && (descriptor.get || !descriptor.writable)) throw new TypeError("Cannot assign to read only property " + String(property));
+  else if (Object.prototype.hasOwnProperty.call(receiver, property)) Object.defineProperty(receiver, property, { value: value });
+  else Object.defineProperty(receiver, property, { value: value, writable: true, enumerable: true, configurable: true });
+  return value;
+}`
+
+// Private instance members are kept in WeakMaps by their instances as descriptors,
+// fields as { writable, value } and accessors as { get, set }, private methods are branded by WeakSets
+const ClassPrivateFieldGetSource = `function classPrivateFieldGet(receiver, privateMap) {
+  if (!privateMap.has(receiver)) throw new TypeError("attempted to get private field on non-instance");
+  var descriptor = privateMap.get(receiver);
+  return descriptor.get ? descriptor.get.call(receiver) : descriptor.value;
+}`
+
+const ClassPrivateFieldSetSource = `function classPrivateFieldSet(receiver, privateMap, value) {
+  if (!privateMap.has(receiver)) throw new TypeError("attempted to set private field on non-instance");
+  var descriptor = privateMap.get(receiver);
+  if (descriptor.set) descriptor.set.call(receiver, value);
+  else if (!descriptor.writable) throw new TypeError("attempted to set read only private field");
+  else descriptor.value = value;
+  return value;
+}`
+
+// ClassPrivateFieldDestructureSetSource is the target of destructuring assignments, [a.#x] = v sets its value
+const ClassPrivateFieldDestructureSetSource = `function classPrivateFieldDestructureSet(receiver, privateMap) {
+  if (!privateMap.has(receiver)) throw new TypeError("attempted to set private field on non-instance");
+  var descriptor = privateMap.get(receiver);
+  return {
+    set value(value) {
+      if (descriptor.set) descriptor.set.call(receiver, value);
+      else if (!descriptor.writable) throw new TypeError("attempted to set read only private field");
+      else descriptor.value = value;
+    }
+  };
+}`
+
+const ClassPrivateMethodGetSource = `function classPrivateMethodGet(receiver, privateSet, fn) {
+  if (!privateSet.has(receiver)) throw new TypeError("attempted to get private method on non-instance");
+  return fn;
+}`
+
+// Private static members are descriptors and functions checked against the class they are defined by
+const ClassStaticPrivateFieldGetSource = `function classStaticPrivateFieldGet(receiver, classConstructor, descriptor) {
+  if (receiver !== classConstructor) throw new TypeError("Private static access of wrong provenance");
+  return descriptor.get ? descriptor.get.call(receiver) : descriptor.value;
+}`
+
+const ClassStaticPrivateFieldSetSource = `function classStaticPrivateFieldSet(receiver, classConstructor, descriptor, value) {
+  if (receiver !== classConstructor) throw new TypeError("Private static access of wrong provenance");
+  if (descriptor.set) descriptor.set.call(receiver, value);
+  else if (!descriptor.writable) throw new TypeError("attempted to set read only private field");
+  else descriptor.value = value;
+  return value;
+}`
+
+const ClassStaticPrivateFieldDestructureSetSource = `function classStaticPrivateFieldDestructureSet(receiver, classConstructor, descriptor) {
+  if (receiver !== classConstructor) throw new TypeError("Private static access of wrong provenance");
+  return {
+    set value(value) {
+      if (descriptor.set) descriptor.set.call(receiver, value);
+      else if (!descriptor.writable) throw new TypeError("attempted to set read only private field");
+      else descriptor.value = value;
+    }
+  };
+}`
+
+const ClassStaticPrivateMethodGetSource = `function classStaticPrivateMethodGet(receiver, classConstructor, method) {
+  if (receiver !== classConstructor) throw new TypeError("Private static access of wrong provenance");
+  return method;
+}`
@@ -0,0 +1,191 @@
+package builtins
+
+// ApplyDecoratorsSource implements 2022-03 decorators semantics,
+// applyDecs(Class, memberDecs, classDecs) decorates members of the defined class
+// and returns field initializers, private members replacements,
+// instance initializers runner and the decorated class with its initializers runner.
+//
+// Every member is described as [decorators, kind, name, ...private access],
+// kind is 0 field, 1 accessor, 2 method, 3 getter, 4 setter and static ones have 5 added
+const ApplyDecoratorsSource = `function applyDecs(Class, memberDecs, classDecs) {
+  var kinds = ["field", "accessor", "method", "getter", "setter"];
+  var result = [], protoInitializers = [], staticInitializers = [], hasProto = false;
+
+  function assertCallable(fn, hint) {
+    if (typeof fn !== "function") throw new TypeError(hint + " must be a function");
+  }
+
+  function decorate(decs, value, context, initializers) {
+    var inits = [];
+
+    for (var i = decs.length - 1; i >= 0; i--) {
+      var done = false, ctx = {};
+
+      for (var key in context) ctx[key] = context[key];
+
+      if (initializers) {
+        ctx.addInitializer = function (initializer) {
+          if (done) throw new TypeError("addInitializer can not be called after decoration has finished");
+          assertCallable(initializer, "An initializer");
+          initializers.push(initializer);
+        };
+      }
+
+      var newValue;
+
+      try {
+        newValue = decs[i].call(void 0, value, ctx);
+      } finally {
+        done = true;
+      }
+
+      if (newValue === void 0) continue;
+
+      if (context.kind === "field") {
+        assertCallable(newValue, "Field decorator result");
+        inits.push(newValue);
+      } else if (context.kind === "accessor") {
+        if (typeof newValue !== "object" || newValue === null) throw new TypeError("Accessor decorator must return an object");
+        if (newValue.get !== void 0) assertCallable(newValue.get, "accessor.get");
+        if (newValue.set !== void 0) assertCallable(newValue.set, "accessor.set");
+        if (newValue.init !== void 0) assertCallable(newValue.init, "accessor.init");
+        value = { get: newValue.get || value.get, set: newValue.set || value.set };
+        if (newValue.init) inits.push(newValue.init);
+      } else {
+        assertCallable(newValue, "Decorator result");
+        value = newValue;
+      }
+    }
+
+    return { value: value, inits: inits };
+  }
+
+  function applyMember(info) {
+    var kind = info[1], name = info[2], isPrivate = info.length > 3, isStatic = kind >= 5;
+    var base = Class.prototype, desc, value, access;
+
+    if (isStatic) {
+      kind -= 5;
+      base = Class;
+    } else if (kind) {
+      hasProto = true;
+    }
+
+    if (isPrivate) {
+      desc = kind < 2 ? { get: info[3], set: info[4] } : kind === 2 ? { value: info[3] } : kind === 3 ? { get: info[3] } : { set: info[3] };
+      access = kind === 2 ? { get: function () { return desc.value; } } : { get: desc.get, set: desc.set };
+    } else {
+      if (kind) desc = Object.getOwnPropertyDescriptor(base, name);
+      access = {
+        get: function () { return this[name]; },
+        set: function (v) { this[name] = v; }
+      };
+    }
+
+    if (kind === 2 || kind === 3) delete access.set;
+    if (kind === 4) delete access.get;
+
+    value = kind === 1 ? { get: desc.get, set: desc.set } : kind === 2 ? desc.value : kind === 3 ? desc.get : kind === 4 ? desc.set : void 0;
+
+    var context = { kind: kinds[kind], name: name, static: isStatic, private: isPrivate, access: access };
+    var decorated = decorate(info[0], value, context, kind ? (isStatic ? staticInitializers : protoInitializers) : null);
+    var inits = decorated.inits;
+
+    value = decorated.value;
+
+    if (kind < 2) {
+      result.push(function (instance, v) {
+        for (var i = 0; i < inits.length; i++) v = inits[i].call(instance, v);
+        return v;
+      });
+    }
+
+    if (!kind) return;
+
+    if (isPrivate) {
+      if (kind === 1) {
+        result.push(function (instance) { return value.get.call(instance); }, function (instance, v) { value.set.call(instance, v); });
+      } else if (kind === 2) {
+        result.push(value);
+      } else {
+        result.push(function (instance, v) { return value.call(instance, v); });
+      }
+
+      return;
+    }
+
+    if (kind === 1) {
+      desc.get = value.get;
+      desc.set = value.set;
+    } else if (kind === 2) {
+      desc.value = value;
+    } else if (kind === 3) {
+      desc.get = value;
+    } else {
+      desc.set = value;
+    }
+
+    Object.defineProperty(base, name, desc);
+  }
+
+  function runner(initializers) {
+    return function (instance) {
+      for (var i = 0; i < initializers.length; i++) initializers[i].call(instance);
+    };
+  }
+
+  for (var i = 0; i < memberDecs.length; i++) applyMember(memberDecs[i]);
+
+  if (hasProto) result.push(runner(protoInitializers));
+
+  runner(staticInitializers)(Class);
+
+  if (classDecs) {
+    var classInitializers = [];
+    var decorated = decorate(classDecs, Class, { kind: "class", name: Class.name }, classInitializers);
+
+    result.push(decorated.value, function () { runner(classInitializers)(decorated.value); });
+  }
+
+  return result;
+}`
+
+// ApplyDecoratedDescriptorSource implements legacy decorators semantics,
+// decorators receive the target, property name and descriptor and may return a replacement descriptor.
+// Descriptors with initializer are returned to be defined on instances by initializerDefineProperty,
+// the rest are defined on the target right away
+const ApplyDecoratedDescriptorSource = `function applyDecoratedDescriptor(target, property, decorators, descriptor, context) {
+  var desc = {};
+  Object.keys(descriptor).forEach(function (key) {
+    desc[key] = descriptor[key];
+  });
+  desc.enumerable = !!desc.enumerable;
+  desc.configurable = !!desc.configurable;
+  if ("value" in desc || desc.initializer) desc.writable = true;
+
+  desc = decorators.slice().reverse().reduce(function (desc, decorator) {
+    return decorator(target, property, desc) || desc;
+  }, desc);
+
+  if (context && desc.initializer !== void 0) {
+    desc.value = desc.initializer ? desc.initializer.call(context) : void 0;
+    desc.initializer = undefined;
+  }
+
+  if (desc.initializer === void 0) {
+    Object.defineProperty(target, property, desc);
+    desc = null;
+  }
+
+  return desc;
+}`
+
+const InitializerDefinePropertySource = `function initializerDefineProperty(target, property, descriptor, context) {
+  if (!descriptor) return;
+  Object.defineProperty(target, property, {
+    enumerable: descriptor.enumerable,
+    configurable: descriptor.configurable,
+    writable: descriptor.writable,
+    value: descriptor.initializer ? descriptor.initializer.call(context) : void 0
+  });
+}`
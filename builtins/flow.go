@@ -0,0 +1,8 @@
+package builtins
+
+// FlowTypeErrorSource throws for the value violating the Flow annotation checked at runtime,
+// expected is the annotation as written and location is the one of the annotation
+const FlowTypeErrorSource = `function flowTypeError(name, expected, value, location) {
+  var actual = value === null ? "null" : Array.isArray(value) ? "array" : typeof value;
+  throw new TypeError(name + " must be " + expected + ", got " + actual + " at " + location);
+}`
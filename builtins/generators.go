@@ -0,0 +1,266 @@
+package builtins
+
+// WrapGeneratorSource runs generator functions lowered to state machines, after regenerator.
+// wrapGenerator(innerFn, self, tryLocsList) returns the generator object, innerFn is called
+// with the context on every step and switches on context.next to the code to run next.
+// It returns the yielded value or the continue sentinel once the context is updated
+// by abrupt completions, those are returns and jumps leaving try statements through finally.
+//
+// Try statements are described as [tryLoc, catchLoc, finallyLoc, afterLoc] with null
+// for missing parts, exceptions are dispatched by the location of the last step in context.prev
+const WrapGeneratorSource = `function wrapGenerator(innerFn, self, tryLocsList) {
+  var ContinueSentinel = {};
+  var iteratorSymbol = typeof Symbol === "function" && Symbol.iterator || "@@iterator";
+
+  function tryCatch(fn, obj, arg) {
+    try {
+      return { type: "normal", arg: fn.call(obj, arg) };
+    } catch (err) {
+      return { type: "throw", arg: err };
+    }
+  }
+
+  function values(iterable) {
+    if (iterable != null) {
+      var iteratorMethod = iterable[iteratorSymbol];
+      if (iteratorMethod) return iteratorMethod.call(iterable);
+      if (typeof iterable.next === "function") return iterable;
+      if (!isNaN(iterable.length)) {
+        var i = -1;
+        return {
+          next: function () {
+            return ++i < iterable.length ? { value: iterable[i], done: false } : { value: undefined, done: true };
+          }
+        };
+      }
+    }
+    throw new TypeError(typeof iterable + " is not iterable");
+  }
+
+  function resetTryEntry(entry) {
+    entry.completion = { type: "normal" };
+  }
+
+  var rootEntry = { tryLoc: "root" };
+  resetTryEntry(rootEntry);
+
+  var context = {
+    prev: 0,
+    next: 0,
+    sent: undefined,
+    done: false,
+    delegate: null,
+    method: "next",
+    arg: undefined,
+    rval: undefined,
+    tryEntries: [rootEntry],
+
+    stop: function () {
+      this.done = true;
+      var rootRecord = this.tryEntries[0].completion;
+      if (rootRecord.type === "throw") throw rootRecord.arg;
+      return this.rval;
+    },
+
+    dispatchException: function (exception) {
+      if (this.done) throw exception;
+      var context = this;
+      function handle(entry, loc, caught) {
+        entry.completion = { type: "throw", arg: exception };
+        context.next = loc;
+        if (caught) {
+          context.method = "next";
+          context.arg = undefined;
+        }
+      }
+      for (var i = this.tryEntries.length - 1; i >= 0; --i) {
+        var entry = this.tryEntries[i];
+        if (entry.tryLoc === "root") return handle(entry, "end");
+        if (entry.tryLoc <= this.prev) {
+          if (entry.catchLoc != null && this.prev < entry.catchLoc) return handle(entry, entry.catchLoc, true);
+          if (entry.finallyLoc != null && this.prev < entry.finallyLoc) return handle(entry, entry.finallyLoc);
+        }
+      }
+    },
+
+    abrupt: function (type, arg) {
+      var finallyEntry = null;
+      for (var i = this.tryEntries.length - 1; i >= 0; --i) {
+        var entry = this.tryEntries[i];
+        if (entry.tryLoc <= this.prev && entry.finallyLoc != null && this.prev < entry.finallyLoc) {
+          finallyEntry = entry;
+          break;
+        }
+      }
+      if (finallyEntry && (type === "break" || type === "continue") &&
+        finallyEntry.tryLoc <= arg && arg <= finallyEntry.finallyLoc) {
+        // the jump stays inside of the try statement
+        finallyEntry = null;
+      }
+      var record = { type: type, arg: arg };
+      if (finallyEntry) {
+        finallyEntry.completion = record;
+        this.method = "next";
+        this.next = finallyEntry.finallyLoc;
+        return ContinueSentinel;
+      }
+      return this.complete(record);
+    },
+
+    complete: function (record, afterLoc) {
+      if (record.type === "throw") throw record.arg;
+      if (record.type === "break" || record.type === "continue") {
+        this.next = record.arg;
+      } else if (record.type === "return") {
+        this.rval = this.arg = record.arg;
+        this.method = "return";
+        this.next = "end";
+      } else if (afterLoc != null) {
+        this.next = afterLoc;
+      }
+      return ContinueSentinel;
+    },
+
+    finish: function (finallyLoc) {
+      for (var i = this.tryEntries.length - 1; i >= 0; --i) {
+        var entry = this.tryEntries[i];
+        if (entry.finallyLoc === finallyLoc) {
+          var record = entry.completion;
+          resetTryEntry(entry);
+          return this.complete(record, entry.afterLoc);
+        }
+      }
+    },
+
+    "catch": function (tryLoc) {
+      for (var i = this.tryEntries.length - 1; i >= 0; --i) {
+        var entry = this.tryEntries[i];
+        if (entry.tryLoc === tryLoc) {
+          var record = entry.completion;
+          if (record.type === "throw") {
+            resetTryEntry(entry);
+            return record.arg;
+          }
+        }
+      }
+      throw new Error("illegal catch attempt");
+    },
+
+    delegateYield: function (iterable, nextLoc) {
+      this.delegate = { iterator: values(iterable), nextLoc: nextLoc };
+      if (this.method === "next") this.arg = undefined;
+      return ContinueSentinel;
+    },
+
+    keys: function (object) {
+      var keys = [];
+      for (var key in object) keys.push(key);
+      keys.reverse();
+      return function next() {
+        while (keys.length) {
+          var key = keys.pop();
+          if (key in object) return { value: key, done: false };
+        }
+        return { done: true };
+      };
+    },
+
+    values: values
+  };
+
+  for (var i = 0; i < (tryLocsList || []).length; i++) {
+    var locs = tryLocsList[i];
+    var entry = { tryLoc: locs[0], catchLoc: locs[1], finallyLoc: locs[2], afterLoc: locs[3] };
+    resetTryEntry(entry);
+    context.tryEntries.push(entry);
+  }
+
+  function maybeInvokeDelegate(delegate) {
+    var method = delegate.iterator[context.method];
+    if (method === undefined) {
+      context.delegate = null;
+      if (context.method === "throw") {
+        if (delegate.iterator["return"]) {
+          context.method = "return";
+          context.arg = undefined;
+          maybeInvokeDelegate(delegate);
+          if (context.method === "throw") return ContinueSentinel;
+        }
+        context.method = "throw";
+        context.arg = new TypeError("The iterator does not provide a 'throw' method");
+      }
+      return ContinueSentinel;
+    }
+    var record = tryCatch(method, delegate.iterator, context.arg);
+    if (record.type === "throw") {
+      context.method = "throw";
+      context.arg = record.arg;
+      context.delegate = null;
+      return ContinueSentinel;
+    }
+    var info = record.arg;
+    if (Object(info) !== info) {
+      context.method = "throw";
+      context.arg = new TypeError("iterator result is not an object");
+      context.delegate = null;
+      return ContinueSentinel;
+    }
+    if (!info.done) return info;
+    context.result = info.value;
+    context.next = delegate.nextLoc;
+    if (context.method !== "return") {
+      context.method = "next";
+      context.arg = undefined;
+    }
+    context.delegate = null;
+    return ContinueSentinel;
+  }
+
+  var state = "suspendedStart";
+
+  function invoke(method, arg) {
+    if (state === "executing") throw new Error("Generator is already running");
+    if (state === "completed") {
+      if (method === "throw") throw arg;
+      return { value: method === "return" ? arg : undefined, done: true };
+    }
+    context.method = method;
+    context.arg = arg;
+    while (true) {
+      if (context.delegate) {
+        var delegated = maybeInvokeDelegate(context.delegate);
+        if (delegated === ContinueSentinel) continue;
+        if (delegated) return delegated;
+      }
+      if (context.method === "next") {
+        context.sent = context.arg;
+      } else if (context.method === "throw") {
+        if (state === "suspendedStart") {
+          state = "completed";
+          throw context.arg;
+        }
+        context.dispatchException(context.arg);
+      } else if (context.method === "return") {
+        context.abrupt("return", context.arg);
+      }
+      state = "executing";
+      var record = tryCatch(innerFn, self, context);
+      if (record.type === "normal") {
+        state = context.done ? "completed" : "suspendedYield";
+        if (record.arg === ContinueSentinel) continue;
+        return { value: record.arg, done: context.done };
+      }
+      state = "completed";
+      context.method = "throw";
+      context.arg = record.arg;
+    }
+  }
+
+  var generator = {
+    next: function (arg) { return invoke("next", arg); },
+    "throw": function (arg) { return invoke("throw", arg); },
+    "return": function (arg) { return invoke("return", arg); }
+  };
+  generator[iteratorSymbol] = function () { return this; };
+  return generator;
+}`
@@ -0,0 +1,63 @@
+package builtins
+
+// Iterables are iterated by Symbol.iterator method, or @@iterator where there are no symbols,
+// array-likes without the method are iterated by index, those are strings and arguments of ES5
+
+// GetIteratorSource gets the iterator of lowered for of loops
+const GetIteratorSource = `function getIterator(iterable) {
+  var method = iterable == null ? undefined : typeof Symbol === "function" && Symbol.iterator ? iterable[Symbol.iterator] : iterable["@@iterator"];
+  if (method != null) return method.call(iterable);
+  if (iterable != null && typeof iterable.length === "number") {
+    var i = 0;
+    return {
+      next: function () {
+        return i < iterable.length ? { value: iterable[i++], done: false } : { value: undefined, done: true };
+      }
+    };
+  }
+  throw new TypeError("Invalid attempt to iterate non-iterable instance");
+}`
+
+// ToConsumableArraySource copies items of spread iterables to the new array, holes of arrays are undefined
+const ToConsumableArraySource = `function toConsumableArray(iterable) {
+  var method = iterable == null || Array.isArray(iterable) ? undefined : typeof Symbol === "function" && Symbol.iterator ? iterable[Symbol.iterator] : iterable["@@iterator"];
+  var array = [];
+  if (method != null) {
+    for (var iterator = method.call(iterable), step; !(step = iterator.next()).done;) array.push(step.value);
+    return array;
+  }
+  if (iterable == null || typeof iterable.length !== "number") {
+    throw new TypeError("Invalid attempt to spread non-iterable instance");
+  }
+  for (var i = 0; i < iterable.length; i++) array[i] = iterable[i];
+  return array;
+}`
+
+// SlicedToArraySource takes the first n items of destructured iterables closing iterators
+// that are not done, arrays and array-likes are destructured by index
+const SlicedToArraySource = `function slicedToArray(iterable, n) {
+  var method = iterable == null || Array.isArray(iterable) ? undefined : typeof Symbol === "function" && Symbol.iterator ? iterable[Symbol.iterator] : iterable["@@iterator"];
+  if (method == null) {
+    if (iterable == null || typeof iterable.length !== "number" && typeof iterable !== "string") {
+      throw new TypeError("Invalid attempt to destructure non-iterable instance");
+    }
+    return iterable;
+  }
+  var array = [], done = false;
+  var iterator = method.call(iterable);
+  while (array.length < n) {
+    var step = iterator.next();
+    if (step.done) {
+      done = true;
+      break;
+    }
+    array.push(step.value);
+  }
+  if (!done && iterator["return"] != null) iterator["return"]();
+  return array;
+}`
+
+// SlicedArrayRestSource is the rest of destructured arrays, see ToConsumableArraySource for iterables
+const SlicedArrayRestSource = `function slicedArrayRest(array, index) {
+  return Array.prototype.slice.call(array, index);
+}`
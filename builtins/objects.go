@@ -0,0 +1,50 @@
+package builtins
+
+// DefinePropertySource defines properties of lowered object literals after computed keys and lowered class fields,
+// own data properties are defined even when the prototype has setters of the same name
+const DefinePropertySource = `function defineProperty(obj, key, value) {
+  if (key in obj) {
+    Object.defineProperty(obj, key, { value: value, enumerable: true, configurable: true, writable: true });
+  } else {
+    obj[key] = value;
+  }
+  return obj;
+}`
+
+// ObjectSpreadSource copies own enumerable properties of the sources to the target for lowered
+// object spread, properties are defined as Object.assign would skip setters of the target
+const ObjectSpreadSource = `function objectSpread(target) {
+  for (var i = 1; i < arguments.length; i++) {
+    var source = arguments[i];
+    if (source == null) continue;
+    source = Object(source);
+    var keys = Object.keys(source);
+    if (typeof Object.getOwnPropertySymbols === "function") {
+      keys = keys.concat(Object.getOwnPropertySymbols(source).filter(function (symbol) {
+        return Object.getOwnPropertyDescriptor(source, symbol).enumerable;
+      }));
+    }
+    for (var j = 0; j < keys.length; j++) {
+      Object.defineProperty(target, keys[j], { value: source[keys[j]], enumerable: true, configurable: true, writable: true });
+    }
+  }
+  return target;
+}`
+
+// ObjectRestSource copies own enumerable properties of destructured objects
+// except the ones taken by the pattern before the rest
+const ObjectRestSource = `function objectRest(source, excluded) {
+  if (source == null) throw new TypeError("Cannot destructure '" + source + "' as it is " + source + ".");
+  source = Object(source);
+  var target = {};
+  var keys = Object.keys(source);
+  if (typeof Object.getOwnPropertySymbols === "function") {
+    keys = keys.concat(Object.getOwnPropertySymbols(source).filter(function (symbol) {
+      return Object.getOwnPropertyDescriptor(source, symbol).enumerable;
+    }));
+  }
+  for (var i = 0; i < keys.length; i++) {
+    if (excluded.indexOf(keys[i]) < 0) target[keys[i]] = source[keys[i]];
+  }
+  return target;
+}`
@@ -0,0 +1,6 @@
+package builtins
+
+// TDZSource throws for let and const lowered to var when used before the declaration
+const TDZSource = `function tdz(name) {
+  throw new ReferenceError("Cannot access '" + name + "' before initialization");
+}`
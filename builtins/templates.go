@@ -0,0 +1,8 @@
+package builtins
+
+// TaggedTemplateLiteralSource builds the strings array passed to tags of lowered templates,
+// it is created once for every template as the array of the native templates
+const TaggedTemplateLiteralSource = `function taggedTemplateLiteral(strings, raw) {
+  if (!raw) raw = strings.slice(0);
+  return Object.freeze(Object.defineProperties(strings, { raw: { value: Object.freeze(raw) } }));
+}`
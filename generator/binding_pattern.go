@@ -5,14 +5,96 @@ import (
 )
 
 func (g *Generator) ObjectBinding(ob *ast.ObjectBinding) *ast.ObjectBinding {
-	if !g.options.Minify {
-		g.src(ob.GetLoc())
+	g.rune('{')
+
+	for index, binder := range ob.List {
+		if index > 0 {
+			g.rune(',')
+		}
 
-		return ob
+		g.PatternBinder(binder)
 	}
 
-	g.rune('{')
-	defer g.rune('}')
+	g.rune('}')
+
+	return ob
+}
+
+func (g *Generator) ArrayBinding(ab *ast.ArrayBinding) *ast.ArrayBinding {
+	g.rune('[')
+
+	// every item is preceded by as many commas as its index
+	commas := 0
+
+	for _, binder := range ab.List {
+		position := commas
+
+		switch b := binder.(type) {
+		case *ast.ArrayItemBinder:
+			position = b.Index
+		case *ast.ArrayRestBinder:
+			position = b.FromIndex
+		}
+
+		for ; commas < position; commas++ {
+			g.rune(',')
+		}
+
+		g.PatternBinder(binder)
+	}
+
+	g.rune(']')
+
+	return ab
+}
+
+func (g *Generator) IdentifierBinder(b *ast.IdentifierBinder) *ast.IdentifierBinder {
+	g.Identifier(b.Id)
+
+	return b
+}
+
+func (g *Generator) ExpressionBinder(b *ast.ExpressionBinder) *ast.ExpressionBinder {
+	g.expression(b.Expression, pPostfix)
+
+	return b
+}
+
+func (g *Generator) ObjectPropertyBinder(b *ast.ObjectPropertyBinder) *ast.ObjectPropertyBinder {
+	g.ObjectPropertyName(b.PropertyName)
+	g.rune(':')
+	g.PatternBinder(b.Binder)
+	g.defaultValue(b.DefaultValue)
+
+	return b
+}
+
+func (g *Generator) ArrayItemBinder(b *ast.ArrayItemBinder) *ast.ArrayItemBinder {
+	g.PatternBinder(b.Binder)
+	g.defaultValue(b.DefaultValue)
+
+	return b
+}
+
+func (g *Generator) ObjectRestBinder(b *ast.ObjectRestBinder) *ast.ObjectRestBinder {
+	g.operator("...")
+	g.PatternBinder(b.Binder)
+
+	return b
+}
+
+func (g *Generator) ArrayRestBinder(b *ast.ArrayRestBinder) *ast.ArrayRestBinder {
+	g.operator("...")
+	g.PatternBinder(b.Binder)
+
+	return b
+}
+
+func (g *Generator) defaultValue(exp ast.IExpr) {
+	if exp == nil {
+		return
+	}
 
-	return g.Walker.ObjectBinding(ob)
+	g.rune('=')
+	g.expression(exp, pSpread)
 }
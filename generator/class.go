@@ -1,7 +1,6 @@
 package generator
 
 import (
-	"yawp/options"
 	"yawp/parser/ast"
 )
 
@@ -12,26 +11,103 @@ func (g *Generator) ClassStatement(c *ast.ClassStatement) ast.IStmt {
 }
 
 func (g *Generator) ClassExpression(c *ast.ClassExpression) *ast.ClassExpression {
-	if g.options.Target == options.ES5 {
-		return g.classES5(c)
-	}
+	g.decorators(c.Decorators)
+	g.word("class")
 
-	g.str("class ")
-	g.str(c.Name.Name)
+	if c.Name != nil {
+		g.Identifier(c.Name)
+	}
 
 	if c.SuperClass != nil {
-		g.str(" extends ")
-		g.Expression(c.SuperClass)
+		g.word("extends")
+		g.expression(c.SuperClass, pCall)
 	}
 
-	g.rune('{')
-	g.rune('}')
+	g.Statement(c.Body)
 
 	return c
 }
 
-func (g *Generator) classES5(c *ast.ClassExpression) *ast.ClassExpression {
-	g.str("'es5 class expression'")
+func (g *Generator) classMemberName(name ast.ObjectPropertyName, static bool, private bool) {
+	if static {
+		g.word("static")
+	}
 
-	return c
+	if private {
+		g.rune('#')
+	}
+
+	g.ObjectPropertyName(name)
+}
+
+// decorators are printed as is, @(expression) keeps parens
+// for anything but the member and call chains
+func (g *Generator) decorators(decorators []ast.IExpr) {
+	for _, decorator := range decorators {
+		g.rune('@')
+
+		switch decorator.(type) {
+		case *ast.Identifier, *ast.MemberExpression, *ast.CallExpression:
+			g.expression(decorator, pCall)
+		default:
+			g.rune('(')
+			g.expression(decorator, pLowest)
+			g.rune(')')
+		}
+	}
+}
+
+func (g *Generator) ClassFieldStatement(f *ast.ClassFieldStatement) ast.IStmt {
+	g.decorators(f.Decorators)
+
+	if f.Accessor {
+		if f.Static {
+			g.word("static")
+		}
+
+		g.word("accessor")
+		g.classMemberName(f.Name, false, f.Private)
+	} else {
+		g.classMemberName(f.Name, f.Static, f.Private)
+	}
+
+	g.defaultValue(f.Initializer)
+	g.semicolon()
+
+	return f
+}
+
+func (g *Generator) ClassAccessorStatement(a *ast.ClassAccessorStatement) ast.IStmt {
+	g.decorators(a.Decorators)
+
+	if a.Static {
+		g.word("static")
+	}
+
+	g.word(a.Kind)
+	g.classMemberName(a.Field, false, a.Private)
+	g.functionTail(a.Body.Parameters, a.Body.Body)
+
+	return a
+}
+
+func (g *Generator) ClassMethodStatement(m *ast.ClassMethodStatement) ast.IStmt {
+	g.decorators(m.Decorators)
+
+	if m.Static {
+		g.word("static")
+	}
+
+	if m.Async {
+		g.word("async")
+	}
+
+	if m.Generator {
+		g.rune('*')
+	}
+
+	g.classMemberName(m.Name, false, m.Private)
+	g.functionTail(m.Parameters, m.Body)
+
+	return m
 }
@@ -1,17 +1,59 @@
 package generator
 
-import "yawp/parser/ast"
+import (
+	"strings"
+	"yawp/parser/ast"
+	"yawp/parser/token"
+)
+
+var binaryPrecedence = map[token.Token]precedence{
+	token.LOGICAL_OR:           pLogicalOr,
+	token.LOGICAL_AND:          pLogicalAnd,
+	token.OR:                   pBitwiseOr,
+	token.EXCLUSIVE_OR:         pBitwiseXor,
+	token.AND:                  pBitwiseAnd,
+	token.EQUAL:                pEquals,
+	token.NOT_EQUAL:            pEquals,
+	token.STRICT_EQUAL:         pEquals,
+	token.STRICT_NOT_EQUAL:     pEquals,
+	token.LESS:                 pCompare,
+	token.LESS_OR_EQUAL:        pCompare,
+	token.GREATER:              pCompare,
+	token.GREATER_OR_EQUAL:     pCompare,
+	token.INSTANCEOF:           pCompare,
+	token.IN:                   pCompare,
+	token.SHIFT_LEFT:           pShift,
+	token.SHIFT_RIGHT:          pShift,
+	token.UNSIGNED_SHIFT_RIGHT: pShift,
+	token.PLUS:                 pAdd,
+	token.MINUS:                pAdd,
+	token.MULTIPLY:             pMultiply,
+	token.SLASH:                pMultiply,
+	token.REMAINDER:            pMultiply,
+	token.EXPONENTIATION:       pExponentiation,
+}
+
+var rawStringEscaper = strings.NewReplacer(
+	"\\", "\\\\",
+	"'", "\\'",
+	"\n", "\\n",
+	"\r", "\\r",
+	"\u2028", "\\u2028",
+	"\u2029", "\\u2029",
+)
 
 func (g *Generator) BooleanLiteral(b *ast.BooleanLiteral) *ast.BooleanLiteral {
 	if !g.options.Minify {
-		g.str(b.Literal)
+		g.word(b.Literal)
 		return b
 	}
 
+	defer g.wrap(pPrefix)()
+
 	if b.Literal == ast.LBooleanFalse {
-		g.str("!1")
+		g.operator("!1")
 	} else {
-		g.str("!0")
+		g.operator("!0")
 	}
 
 	return b
@@ -20,7 +62,10 @@ func (g *Generator) BooleanLiteral(b *ast.BooleanLiteral) *ast.BooleanLiteral {
 func (g *Generator) StringLiteral(s *ast.StringLiteral) *ast.StringLiteral {
 	if s.Raw {
 		g.rune('\'')
-		defer g.rune('\'')
+		g.str(rawStringEscaper.Replace(s.Literal))
+		g.rune('\'')
+
+		return s
 	}
 
 	g.str(s.Literal)
@@ -29,116 +74,574 @@ func (g *Generator) StringLiteral(s *ast.StringLiteral) *ast.StringLiteral {
 }
 
 func (g *Generator) NumberLiteral(s *ast.NumberLiteral) *ast.NumberLiteral {
-	g.str(s.Literal)
+	g.word(s.Literal)
 
 	return s
 }
 
+func (g *Generator) NullLiteral(n *ast.NullLiteral) *ast.NullLiteral {
+	g.word("null")
+
+	return n
+}
+
+func (g *Generator) RegExpLiteral(r *ast.RegExpLiteral) *ast.RegExpLiteral {
+	// a / /b/ is not a comment
+	if g.lastByte() == '/' {
+		g.rune(' ')
+	}
+
+	g.word(r.Literal)
+
+	return r
+}
+
+func (g *Generator) TemplateExpression(t *ast.TemplateExpression) *ast.TemplateExpression {
+	g.rune('`')
+
+	for index, str := range t.Strings {
+		// parts are kept as they are in the source
+		g.str(str)
+
+		if index < len(t.Substitutions) {
+			g.str("${")
+			g.expression(t.Substitutions[index], pLowest)
+			g.rune('}')
+		}
+	}
+
+	g.rune('`')
+
+	return t
+}
+
+func (g *Generator) TaggedTemplateExpression(t *ast.TaggedTemplateExpression) *ast.TaggedTemplateExpression {
+	defer g.wrap(pCall)()
+
+	g.expression(t.Tag, pCall)
+	g.TemplateExpression(t.Template)
+
+	return t
+}
+
 func (g *Generator) ArrayLiteral(al *ast.ArrayLiteral) *ast.ArrayLiteral {
 	g.rune('[')
-	defer g.rune(']')
 
 	for index, item := range al.List {
-		al.List[index] = g.Expression(item)
+		if index > 0 {
+			g.rune(',')
+		}
+
+		if item != nil {
+			g.expression(item, pSpread)
+		}
 	}
 
+	// trailing hole needs its own comma
+	if len(al.List) > 0 && al.List[len(al.List)-1] == nil {
+		g.rune(',')
+	}
+
+	g.rune(']')
+
 	return al
 }
 
+func (g *Generator) ArraySpread(as *ast.ArraySpread) *ast.ArraySpread {
+	g.operator("...")
+	g.expression(as.Expression, pAssign)
+
+	return as
+}
+
 func (g *Generator) ObjectLiteral(o *ast.ObjectLiteral) *ast.ObjectLiteral {
 	g.rune('{')
-	defer g.rune('}')
-
-	for _, prop := range o.Properties {
-		switch p := prop.(type) {
-		case *ast.ObjectPropertyValue:
-			g.ObjectPropertyName(p.PropertyName)
-			g.rune(':')
-			g.Expression(p.Value)
+
+	for index, prop := range o.Properties {
+		if index > 0 {
+			g.rune(',')
 		}
+
+		g.ObjectProperty(prop)
 	}
 
+	g.rune('}')
+
 	return o
 }
 
+// ObjectPropertyValue prints methods as such unless those are named, e.g. to lower new.target
+func (g *Generator) ObjectPropertyValue(p *ast.ObjectPropertyValue) *ast.ObjectPropertyValue {
+	if fl, ok := p.Value.(*ast.FunctionLiteral); ok && p.Method && fl.Id == nil {
+		if fl.Async {
+			g.word("async")
+		}
+
+		if fl.Generator {
+			g.rune('*')
+		}
+
+		g.ObjectPropertyName(p.PropertyName)
+		g.functionTail(fl.Parameters, fl.Body)
+
+		return p
+	}
+
+	g.ObjectPropertyName(p.PropertyName)
+	g.rune(':')
+	g.expression(p.Value, pSpread)
+
+	return p
+}
+
+func (g *Generator) ObjectPropertyGetter(p *ast.ObjectPropertyGetter) *ast.ObjectPropertyGetter {
+	g.word("get")
+	g.ObjectPropertyName(p.PropertyName)
+	g.functionTail(p.Getter.Parameters, p.Getter.Body)
+
+	return p
+}
+
+func (g *Generator) ObjectPropertySetter(p *ast.ObjectPropertySetter) *ast.ObjectPropertySetter {
+	g.word("set")
+	g.ObjectPropertyName(p.PropertyName)
+	g.functionTail(p.Setter.Parameters, p.Setter.Body)
+
+	return p
+}
+
+func (g *Generator) ObjectSpread(os *ast.ObjectSpread) *ast.ObjectSpread {
+	g.operator("...")
+	g.expression(os.Expression, pAssign)
+
+	return os
+}
+
+// property names are never renamed
 func (g *Generator) ObjectPropertyName(opn ast.ObjectPropertyName) ast.ObjectPropertyName {
 	switch o := opn.(type) {
 	case *ast.Identifier:
-		return g.Identifier(o)
+		g.word(o.Name)
 	case *ast.ComputedName:
-		return g.ComputedName(o)
+		g.ComputedName(o)
+	case *ast.StringLiteral:
+		g.StringLiteral(o)
+	case *ast.NumberLiteral:
+		g.NumberLiteral(o)
 
 	default:
 		panic("Unknown object property name type")
 	}
+
+	return opn
 }
 
 func (g *Generator) ComputedName(cn *ast.ComputedName) *ast.ComputedName {
 	g.rune('[')
-	g.Expression(cn.Expression)
+	g.expression(cn.Expression, pSpread)
 	g.rune(']')
 
 	return cn
 }
 
 func (g *Generator) BinaryExpression(b *ast.BinaryExpression) *ast.BinaryExpression {
-	if g.wrapExpression {
+	level := binaryPrecedence[b.Operator]
+
+	// `in` is forbidden inside for initializer
+	if b.Operator == token.IN && g.forbidIn {
 		g.rune('(')
 		defer g.rune(')')
+
+		g.forbidIn = false
+		defer func() { g.forbidIn = true }()
+	}
+
+	defer g.wrap(level)()
+
+	leftLevel, rightLevel := level, level+1
+
+	if b.Operator == token.EXPONENTIATION {
+		// right associative and `-a ** b` is a syntax error
+		leftLevel, rightLevel = pPostfix, level
 	}
 
-	g.Expression(b.Left)
-	g.str(b.Operator.String())
-	g.Expression(b.Right)
+	g.expression(b.Left, leftLevel)
+
+	switch b.Operator {
+	case token.IN, token.INSTANCEOF:
+		g.word(b.Operator.String())
+	default:
+		g.operator(b.Operator.String())
+	}
+
+	g.expression(b.Right, rightLevel)
 
 	return b
 }
 
+func (g *Generator) CoalesceExpression(c *ast.CoalesceExpression) *ast.CoalesceExpression {
+	defer g.wrap(pNullishCoalescing)()
+
+	// ?? can not be mixed with || and && without parens
+	g.expression(c.Head, pBitwiseOr)
+	g.str("??")
+
+	if _, ok := c.Consequent.(*ast.CoalesceExpression); ok {
+		g.expression(c.Consequent, pNullishCoalescing)
+	} else {
+		g.expression(c.Consequent, pBitwiseOr)
+	}
+
+	return c
+}
+
+func (g *Generator) ConditionalExpression(c *ast.ConditionalExpression) *ast.ConditionalExpression {
+	defer g.wrap(pConditional)()
+
+	g.expression(c.Test, pNullishCoalescing)
+	g.rune('?')
+	g.expression(c.Consequent, pYield)
+	g.rune(':')
+	g.expression(c.Alternate, pYield)
+
+	return c
+}
+
 func (g *Generator) AssignExpression(a *ast.AssignmentExpression) *ast.AssignmentExpression {
-	g.Expression(a.Left)
-	g.str(a.Operator.String())
-	g.Expression(a.Right)
+	defer g.wrap(pAssign)()
+
+	g.expression(a.Left, pPostfix)
+
+	switch a.Operator {
+	case token.ASSIGN, token.EXPONENTIATION_ASSIGN:
+		g.str(a.Operator.String())
+	default:
+		g.str(a.Operator.String())
+		g.rune('=')
+	}
+
+	g.expression(a.Right, pYield)
 
 	return a
 }
 
-func (g *Generator) CallExpression(c *ast.CallExpression) *ast.CallExpression {
-	g.Expression(c.Callee)
+func (g *Generator) SequenceExpression(s *ast.SequenceExpression) *ast.SequenceExpression {
+	defer g.wrap(pComma)()
+
+	for index, exp := range s.Sequence {
+		if index > 0 {
+			g.rune(',')
+		}
+
+		g.expression(exp, pSpread)
+	}
+
+	return s
+}
+
+func (g *Generator) UnaryExpression(u *ast.UnaryExpression) *ast.UnaryExpression {
+	if u.Postfix {
+		defer g.wrap(pPostfix)()
+
+		g.expression(u.Operand, pPostfix)
+		g.str(u.Operator.String())
+
+		return u
+	}
+
+	defer g.wrap(pPrefix)()
+
+	switch u.Operator {
+	case token.TYPEOF, token.VOID, token.DELETE:
+		g.word(u.Operator.String())
+	default:
+		g.operator(u.Operator.String())
+	}
+
+	g.expression(u.Operand, pPrefix)
+
+	return u
+}
+
+func (g *Generator) AwaitExpression(a *ast.AwaitExpression) *ast.AwaitExpression {
+	defer g.wrap(pPrefix)()
+
+	g.word("await")
+	g.expression(a.Expression, pPrefix)
+
+	return a
+}
+
+func (g *Generator) YieldExpression(y *ast.YieldExpression) *ast.YieldExpression {
+	defer g.wrap(pYield)()
+
+	g.word("yield")
+
+	if y.Delegate {
+		g.rune('*')
+	}
+
+	if y.Argument != nil {
+		g.expression(y.Argument, pYield)
+	}
+
+	return y
+}
+
+func (g *Generator) arguments(args []ast.IExpr) {
 	g.rune('(')
 
-	for index, arg := range c.ArgumentList {
+	for index, arg := range args {
 		if index > 0 {
 			g.rune(',')
 		}
 
-		g.Expression(arg)
+		g.expression(arg, pSpread)
 	}
 
 	g.rune(')')
+}
+
+func (g *Generator) CallExpression(c *ast.CallExpression) *ast.CallExpression {
+	defer g.wrap(pCall)()
+
+	g.expression(c.Callee, pCall)
+	g.arguments(c.ArgumentList)
 
 	return c
 }
 
+func (g *Generator) SpreadExpression(s *ast.SpreadExpression) *ast.SpreadExpression {
+	g.operator("...")
+	g.expression(s.Value, pAssign)
+
+	return s
+}
+
+func (g *Generator) NewExpression(n *ast.NewExpression) *ast.NewExpression {
+	defer g.wrap(pNew)()
+
+	g.word("new")
+
+	// new (a())() and new a()() are different things, new a?.b() is a syntax error
+	if isCalleeAmbiguous(n.Callee) {
+		g.rune('(')
+		g.expression(n.Callee, pLowest)
+		g.rune(')')
+	} else {
+		g.expression(n.Callee, pNew)
+	}
+
+	g.arguments(n.ArgumentList)
+
+	return n
+}
+
+// isCalleeAmbiguous tells whether the callee of new has calls or optional links,
+// those are parenthesized
+func isCalleeAmbiguous(exp ast.IExpr) bool {
+	switch e := exp.(type) {
+	case *ast.CallExpression, *ast.OptionalCallExpression,
+		*ast.OptionalObjectMemberAccessExpression, *ast.OptionalArrayMemberAccessExpression:
+		return true
+	case *ast.MemberExpression:
+		return isCalleeAmbiguous(e.Left)
+	case *ast.TaggedTemplateExpression:
+		return isCalleeAmbiguous(e.Tag)
+	}
+
+	return false
+}
+
 func (g *Generator) MemberExpression(me *ast.MemberExpression) ast.IExpr {
-	wrapExpression := g.wrapExpression
-	g.wrapExpression = true
-	g.Expression(me.Left)
-	g.wrapExpression = wrapExpression
+	defer g.wrap(pMember)()
+
+	// 1.toString() is a syntax error
+	if number, ok := me.Left.(*ast.NumberLiteral); ok && me.Kind == ast.MKObject {
+		g.rune('(')
+		g.NumberLiteral(number)
+		g.rune(')')
+	} else {
+		g.expression(me.Left, pCall)
+	}
 
 	if me.Kind == ast.MKArray {
 		g.rune('[')
-		g.Expression(me.Right)
+		g.expression(me.Right, pLowest)
 		g.rune(']')
-	} else {
-		g.rune('.')
-		g.Expression(me.Right)
+
+		return me
 	}
 
+	g.rune('.')
+
+	if me.Kind == ast.MKPrivate {
+		g.rune('#')
+	}
+
+	g.propertyName(me.Right)
+
 	return me
 }
 
+func (g *Generator) propertyName(exp ast.IExpr) {
+	if id, ok := exp.(*ast.Identifier); ok {
+		g.word(id.Name)
+
+		return
+	}
+
+	g.expression(exp, pLowest)
+}
+
+func (g *Generator) OptionalObjectMemberAccessExpression(o *ast.OptionalObjectMemberAccessExpression) *ast.OptionalObjectMemberAccessExpression {
+	defer g.wrap(pMember)()
+
+	g.expression(o.Left, pCall)
+	g.str("?.")
+	g.word(o.Identifier.Name)
+
+	return o
+}
+
+func (g *Generator) OptionalArrayMemberAccessExpression(o *ast.OptionalArrayMemberAccessExpression) *ast.OptionalArrayMemberAccessExpression {
+	defer g.wrap(pMember)()
+
+	g.expression(o.Left, pCall)
+	g.str("?.[")
+	g.expression(o.Index, pLowest)
+	g.rune(']')
+
+	return o
+}
+
+func (g *Generator) OptionalCallExpression(o *ast.OptionalCallExpression) *ast.OptionalCallExpression {
+	defer g.wrap(pCall)()
+
+	g.expression(o.Left, pCall)
+	g.str("?.")
+	g.arguments(o.Arguments)
+
+	return o
+}
+
 func (g *Generator) ThisExpression(te *ast.ThisExpression) *ast.ThisExpression {
-	g.str("this")
+	g.word("this")
 
 	return te
 }
+
+func (g *Generator) SuperExpression(se *ast.SuperExpression) ast.IExpr {
+	g.word("super")
+
+	return se
+}
+
+func (g *Generator) NewTargetExpression(nt *ast.NewTargetExpression) *ast.NewTargetExpression {
+	g.word("new.target")
+
+	return nt
+}
+
+func (g *Generator) ImportCall(ic *ast.ImportCall) *ast.ImportCall {
+	defer g.wrap(pCall)()
+
+	g.word("import")
+	g.rune('(')
+	g.expression(ic.Expression, pSpread)
+	g.rune(')')
+
+	return ic
+}
+
+func (g *Generator) FlowTypeAssertion(f *ast.FlowTypeAssertionExpression) *ast.FlowTypeAssertionExpression {
+	g.Expression(f.Left)
+
+	return f
+}
+
+func (g *Generator) TSTypeAssertion(t *ast.TSTypeAssertionExpression) *ast.TSTypeAssertionExpression {
+	g.Expression(t.Expression)
+
+	return t
+}
+
+// JSX is kept as is until it's transformed
+func (g *Generator) JsxElement(j *ast.JSXElement) *ast.JSXElement {
+	g.src(j.GetLoc())
+
+	return j
+}
+
+func (g *Generator) JsxFragment(j *ast.JSXFragment) *ast.JSXFragment {
+	g.src(j.GetLoc())
+
+	return j
+}
+
+// startsWith reports whether printed exp would start with one of the given nodes,
+// those have to be wrapped in statement or arrow body position
+func startsWith(exp ast.IExpr, test func(ast.IExpr) bool) bool {
+	for exp != nil {
+		if test(exp) {
+			return true
+		}
+
+		switch e := exp.(type) {
+		case *ast.CallExpression:
+			exp = e.Callee
+		case *ast.MemberExpression:
+			exp = e.Left
+		case *ast.BinaryExpression:
+			exp = e.Left
+		case *ast.AssignmentExpression:
+			exp = e.Left
+		case *ast.ConditionalExpression:
+			exp = e.Test
+		case *ast.CoalesceExpression:
+			exp = e.Head
+		case *ast.SequenceExpression:
+			exp = e.Sequence[0]
+		case *ast.TaggedTemplateExpression:
+			exp = e.Tag
+		case *ast.OptionalCallExpression:
+			exp = e.Left
+		case *ast.OptionalObjectMemberAccessExpression:
+			exp = e.Left
+		case *ast.OptionalArrayMemberAccessExpression:
+			exp = e.Left
+		case *ast.TSTypeAssertionExpression:
+			exp = e.Expression
+		case *ast.FlowTypeAssertionExpression:
+			exp = e.Left
+		case *ast.UnaryExpression:
+			if !e.Postfix {
+				return false
+			}
+
+			exp = e.Operand
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+func isObjectStart(exp ast.IExpr) bool {
+	switch exp.(type) {
+	case *ast.ObjectLiteral, *ast.ObjectBinding:
+		return true
+	}
+
+	return false
+}
+
+func isStatementAmbiguous(exp ast.IExpr) bool {
+	switch exp.(type) {
+	case *ast.ObjectLiteral, *ast.ObjectBinding, *ast.FunctionLiteral, *ast.ClassExpression:
+		return true
+	}
+
+	return false
+}
@@ -3,22 +3,109 @@ package generator
 import "yawp/parser/ast"
 
 func (g *Generator) FunctionLiteral(fl *ast.FunctionLiteral) *ast.FunctionLiteral {
-	g.str("function")
+	if fl.Async {
+		g.word("async")
+	}
+
+	g.word("function")
+
+	if fl.Generator {
+		g.rune('*')
+	}
 
 	if fl.Id != nil {
-		g.rune(' ')
 		g.Identifier(fl.Id)
 	}
 
+	g.functionTail(fl.Parameters, fl.Body)
+
+	return fl
+}
+
+// functionTail prints (parameters){body} shared by functions, methods and accessors
+func (g *Generator) functionTail(params *ast.FunctionParameters, body *ast.FunctionBody) {
 	g.rune('(')
+	g.FunctionParameters(params)
+	g.rune(')')
+	g.FunctionBody(body)
+}
 
-	g.FunctionParameters(fl.Parameters)
+func (g *Generator) ArrowFunctionExpression(af *ast.ArrowFunctionExpression) *ast.ArrowFunctionExpression {
+	defer g.wrap(pAssign)()
 
-	g.str("){")
+	if af.Async {
+		g.word("async")
+	}
 
-	g.FunctionBody(fl.Body)
+	g.rune('(')
+	g.FunctionParameters(&ast.FunctionParameters{List: af.Parameters})
+	g.str(")=>")
+
+	// concise body
+	if len(af.Body.List) == 1 {
+		if ret, ok := af.Body.List[0].(*ast.ReturnStatement); ok && ret.Argument != nil {
+			if startsWith(ret.Argument, isObjectStart) {
+				g.rune('(')
+				g.expression(ret.Argument, pLowest)
+				g.rune(')')
+			} else {
+				g.expression(ret.Argument, pSpread)
+			}
+
+			return af
+		}
+	}
+
+	g.FunctionBody(af.Body)
+
+	return af
+}
+
+func (g *Generator) FunctionParameters(fp *ast.FunctionParameters) *ast.FunctionParameters {
+	if fp == nil {
+		return fp
+	}
+
+	for index, param := range fp.List {
+		if index > 0 {
+			g.rune(',')
+		}
+
+		g.FunctionParameter(param)
+	}
+
+	return fp
+}
+
+func (g *Generator) IdentifierParameter(ip *ast.IdentifierParameter) ast.FunctionParameter {
+	g.Identifier(ip.Id)
+	g.defaultValue(ip.DefaultValue)
+
+	return ip
+}
+
+func (g *Generator) PatternParameter(pp *ast.PatternParameter) ast.FunctionParameter {
+	g.PatternBinder(pp.Binder)
+	g.defaultValue(pp.DefaultValue)
+
+	return pp
+}
+
+func (g *Generator) RestParameter(rp *ast.RestParameter) ast.FunctionParameter {
+	g.operator("...")
+	g.PatternBinder(rp.Binder)
+
+	return rp
+}
+
+func (g *Generator) FunctionBody(fb *ast.FunctionBody) *ast.FunctionBody {
+	g.rune('{')
+
+	if fb != nil {
+		g.statements(fb.List)
+	}
 
 	g.rune('}')
 
-	return fl
+	return fb
 }
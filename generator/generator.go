@@ -10,6 +10,36 @@ import (
 
 var ident = "                                                                                                          "
 
+// precedence of the expression being printed,
+// operands with lower precedence than their parent are wrapped in parens
+type precedence int
+
+const (
+	pLowest precedence = iota
+	pComma
+	pSpread
+	pYield
+	pAssign
+	pConditional
+	pNullishCoalescing
+	pLogicalOr
+	pLogicalAnd
+	pBitwiseOr
+	pBitwiseXor
+	pBitwiseAnd
+	pEquals
+	pCompare
+	pShift
+	pAdd
+	pMultiply
+	pExponentiation
+	pPrefix
+	pPostfix
+	pNew
+	pCall
+	pMember
+)
+
 type Generator struct {
 	*ast.Walker
 
@@ -22,7 +52,8 @@ type Generator struct {
 	identLevel int
 
 	// punctuation flags
-	wrapExpression bool
+	level    precedence
+	forbidIn bool
 }
 
 func (g *Generator) str(s string) *Generator {
@@ -43,6 +74,42 @@ func (g *Generator) semicolon() *Generator {
 	return g
 }
 
+func (g *Generator) lastByte() byte {
+	out := g.output.String()
+
+	if len(out) == 0 {
+		return 0
+	}
+
+	return out[len(out)-1]
+}
+
+// word prints keywords, identifiers and numbers
+// separating them from the previous word if needed
+func (g *Generator) word(s string) *Generator {
+	if isWordByte(g.lastByte()) && len(s) > 0 && isWordByte(s[0]) {
+		g.rune(' ')
+	}
+
+	return g.str(s)
+}
+
+// operator prints punctuators avoiding `a+ +b` turning into `a++b`
+func (g *Generator) operator(s string) *Generator {
+	last := g.lastByte()
+
+	if (last == '+' || last == '-') && s[0] == last {
+		g.rune(' ')
+	}
+
+	return g.str(s)
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b == '$' || b == '\\' || b >= 0x80 ||
+		(b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
 func (g *Generator) nl() *Generator {
 	g.output.WriteRune('\n')
 	g.ident()
@@ -82,6 +149,34 @@ func (g *Generator) indentDec() *Generator {
 	return g
 }
 
+// expression prints exp as an operand of an expression with the given precedence
+func (g *Generator) expression(exp ast.IExpr, level precedence) {
+	wasLevel, wasForbidIn := g.level, g.forbidIn
+	g.level = level
+
+	g.Expression(exp)
+
+	g.level, g.forbidIn = wasLevel, wasForbidIn
+}
+
+// wrap opens a paren when expression of own precedence
+// is printed in a context with higher one and returns closing callback
+func (g *Generator) wrap(own precedence) func() {
+	if g.level <= own {
+		return func() {}
+	}
+
+	g.rune('(')
+
+	wasForbidIn := g.forbidIn
+	g.forbidIn = false
+
+	return func() {
+		g.forbidIn = wasForbidIn
+		g.rune(')')
+	}
+}
+
 func Generate(options *options.Options, program *ast.Module) string {
 	generator := &Generator{
 		Walker: &ast.Walker{},
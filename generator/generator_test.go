@@ -40,3 +40,614 @@ function foo() {}
 	fmt.Println(str)
 	return
 }
+
+// filename decides on the syntax extensions, e.g. TypeScript for .ts
+func makeAssert(t *testing.T, filename string) func(string, string) {
+	return makeOptionsAssert(t, filename, &options.Options{
+		Target:  options.ES2020,
+		Runtime: "yawp/runtime",
+	})
+}
+
+func makeOptionsAssert(t *testing.T, filename string, opt *options.Options) func(string, string) {
+	return func(src string, expected string) {
+		prog, err := parser.ParseModule(filename, src)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// expected is the error for the code that can not be transpiled
+		if _, err := transpiler.Transpile(prog, opt); err != nil {
+			if err.Error() != expected {
+				t.Errorf("\nExpected error: %s\nActual error: %s", expected, err)
+			}
+
+			return
+		}
+
+		if actual := Generate(opt, prog); actual != expected {
+			t.Errorf("\nExpected output: %s\nActual output: %s", expected, actual)
+		}
+	}
+}
+
+func TestTypeScript(t *testing.T) {
+	assert := makeAssert(t, "test.ts")
+
+	assert(
+		`import {A, b} from 'x'; import 'y'; let x: A = b as any; let y = <A>x!; interface I {} type T = I`,
+		`import{b}from'x';import'y';let x=b;let y=x;`,
+	)
+	assert(
+		`enum E { A, B = 4, C, D = 'd', F = A | B }`,
+		`var E;(function(E){E[E['A']=0]='A';E[E['B']=4]='B';E[E['C']=5]='C';E['D']='d';E[E['F']=E.A|E.B]='F';}(E||(E={})));`,
+	)
+	assert(
+		"enum E { A = 'a', B = A, C = B + 'c', D = `d${A}`, F = E.A, G = E['B'], H = f() }",
+		"var E;(function(E){E['A']='a';E['B']=E.A;E['C']=E.B+'c';E['D']=`d${E.A}`;E['F']=E.A;E['G']=E['B'];E[E['H']=f()]='H';}(E||(E={})));",
+	)
+	assert(
+		`export namespace N { export const a = 1; export function f() {} export type T = 1; const b = 2 }`,
+		`export var N;(function(N){N.a=1;function f(){}N.f=f;const b=2;}(N||(N={})));`,
+	)
+	assert(
+		`namespace N { export let x = 1, {y} = o; x++; function f(x) { return x + y } } namespace N { x = 2 }`,
+		`var N;(function(N){N.x=1;({y:N.y}=o);N.x++;function f(x){return x+N.y;}}(N||(N={})));(function(N){N.x=2;}(N||(N={})));`,
+	)
+	assert(
+		`export enum E { A } export namespace E { export const b = 1 }`,
+		`export var E;(function(E){E[E['A']=0]='A';}(E||(E={})));(function(E){E.b=1;}(E||(E={})));`,
+	)
+	assert(
+		`type U = 1; const v = 1; export { type U, v }; export { type U as W }`,
+		`const v=1;export{v};`,
+	)
+	assert(
+		`abstract class A extends B { x: number; y = 1; constructor(private a: string, c) { super(); c() } abstract m(): void }`,
+		`import{defineProperty as _defineProperty}from'yawp/runtime';class A extends B{constructor(a,c){super();this.a=a;_defineProperty(this,'y',1);c();}}`,
+	)
+	makeOptionsAssert(t, "test.ts", &options.Options{Target: options.ES2022})(
+		`class A extends B { y = this.a; constructor(public a: number) { super() } }`,
+		`function _defineProperty(obj,key,value){if(key in obj){Object.defineProperty(obj,key,{value:value,enumerable:true,configurable:true,writable:true});}else{obj[key]=value;}return obj;}class A extends B{constructor(a){super();this.a=a;_defineProperty(this,'y',this.a);}}`,
+	)
+	assert(
+		`declare const d: string; import fs = require('fs'); export = fs`,
+		`var fs=require('fs');module.exports=fs;`,
+	)
+}
+
+func TestRelationalExpressions(t *testing.T) {
+	assert := makeAssert(t, "")
+
+	assert(`a<b<c; a<(b<c); a in b instanceof c; a instanceof (b in c); for (var x = (a in b) < c;;);`, `a<b<c;a<(b<c);a in b instanceof c;a instanceof(b in c);for(var x=(a in b)<c;;);`)
+	makeAssert(t, "test.ts")(`a < b as any; x as any < y`, `a<b;x<y;`)
+}
+
+func TestPrecedence(t *testing.T) {
+	assert := makeAssert(t, "")
+
+	assert(`(a + b) * c; a + b * c; a - (b - c); (a - b) - c; a ** (b ** c); (a ** b) ** c; (-a) ** b; (a, b) ? c : d; a ? b : (c, d)`, `(a+b)*c;a+b*c;a-(b-c);a-b-c;a**b**c;(a**b)**c;(-a)**b;(a,b)?c:d;a?b:(c,d);`)
+	assert(`f((a, b)); x = (a, b); x = y = z; (x = y) || z; a || (b && c); (a || b) && c; (a ?? b) || c; a ?? (b || c)`, `f((a,b));x=(a,b);x=y=z;(x=y)||z;a||b&&c;(a||b)&&c;(a??b)||c;a??(b||c);`)
+	assert(`new (f())(); new (a.b().c); new a.b.c(); (new A).x; new (A?.b)(); new (a?.[b].c)`, `new(f())();new(a.b().c)();new a.b.c();(new A()).x;new(A?.b)();new(a?.[b].c)();`)
+	assert(`async function f() { (await a) ** 2; await (a || b); (await x)?.y }`, `async function f(){(await a)**2;await(a||b);(await x)?.y;}`)
+	assert(`function* g() { yield a, yield b; x = yield; f(yield a); (yield a) + 1 }`, `function*g(){yield a,yield b;x=yield;f(yield a);(yield a)+1;}`)
+	assert(`typeof typeof a; - -a; + +a; -(-a); a - -b; a + +b; a++ + +b; a - --b; !(a && b); (() => {}).call(); (async () => {})()`, `typeof typeof a;- -a;+ +a;- -a;a- -b;a+ +b;a++ + +b;a- --b;!(a&&b);(()=>{}).call();(async()=>{})();`)
+}
+
+func TestStatementStart(t *testing.T) {
+	assert := makeAssert(t, "")
+
+	assert(`({}).x; ({a} = b); (function () {})(); (function f() {}).call(); (class {}).name; let x = () => ({})`, `({}.x);({a:a}=b);(function(){}());(function f(){}.call());(class{}.name);let x=()=>({});`)
+	assert(`if (a) b; else if (c) d; else { e } for (;;) break; do x(); while (a); label: for (x in y) continue label`, `if(a)b;else if(c)d;else{e;}for(;;)break;do x();while(a);label:for(x in y)continue label;`)
+	assert(`function f() { return -1; return typeof a; return {}; throw new E } x = a in b; for (var i = (a in b) ? 1 : 2;;);`, `function f(){return-1;return typeof a;return{};throw new E();}x=a in b;for(var i=(a in b)?1:2;;);`)
+}
+
+func TestModules(t *testing.T) {
+	assert := makeAssert(t, "")
+
+	assert(`import a, {b as c, d} from 'x'; import * as n from 'y'; export {a, c as e}; export * from 'z'; export * as f from 'w'`, `import a,{b as c,d}from'x';import*as n from'y';export{a,c as e};export*from'z';export*as f from'w';`)
+	assert(`export default function () {} export const g = 1; export class A {}`, `export default function(){}export const g=1;export class A{}`)
+	assert(`export default (a, b); export {h as i} from 'v'`, `export default(a,b);export{h as i}from'v';`)
+}
+
+func TestMinify(t *testing.T) {
+	assert := makeOptionsAssert(t, "", &options.Options{
+		Target: options.ES2020,
+		Minify: true,
+	})
+
+	assert(`const p = function () { return p; }`, `const _=function(){return _;};`)
+	assert(`const o = { f() { return o; } }`, `const _={f(){return _;}};`)
+	assert(`function g() { function f() { return h() + x; } function h() {} const x = 1; }`, `function _(){function $(){return a()+b;}function a(){}const b=1;}`)
+}
+
+func TestFlowDeclare(t *testing.T) {
+	assert := makeAssert(t, "")
+
+	assert(
+		`declare var __DEV__: boolean; if (__DEV__) { log() }
+declare function log(): void
+declare class A<T> extends B<T> { static x: number; m(): void }
+new A()`,
+		`if(__DEV__){log();}new A();`,
+	)
+	assert(
+		`declare module 'x' { declare export function f(): void; declare module.exports: { f: () => void } }
+declare module.exports: number
+declare export default string
+declare opaque type T: string
+export opaque type U = string`,
+		``,
+	)
+}
+
+func TestFlow(t *testing.T) {
+	assert := makeAssert(t, "")
+
+	assert(
+		`function f(a /*: string */) /*: void */ {} /*:: type A = string */ let x /*: A */ = 1 /* comment */ * 2`,
+		`function f(a){}let x=1*2;`,
+	)
+	assert(`class A { /*:: x: number; static s: string; */ y = 1 }`, `import{defineProperty as _defineProperty}from'yawp/runtime';class A{constructor(){_defineProperty(this,'y',1);}}`)
+	assert(
+		`enum A {B, C} export enum D of number {E = 1} enum F of symbol {G}`,
+		`const A=Object.freeze({B:'B',C:'C'});export const D=Object.freeze({E:1});const F=Object.freeze({G:Symbol('G')});`,
+	)
+	assert(
+		`function f(x: mixed): boolean %checks { return !!x }`,
+		`function f(x){return!!x;}`,
+	)
+	assert(
+		`import typeof A from 'a'; import {typeof b, type C, d} from 'b'; import {type E} from 'e'; import {} from 'f'`,
+		`import{d}from'b';import'f';`,
+	)
+}
+
+func TestDecorators(t *testing.T) {
+	assert := makeOptionsAssert(t, "", &options.Options{
+		Target:  options.ES2022,
+		Runtime: "yawp/runtime",
+	})
+
+	assert(`class A { @f x = 1 }`, `import{applyDecs as _applyDecs}from'yawp/runtime';var _init_x,_decs;class A{static#_=(_decs=_applyDecs(this,[[[f],0,'x']]),_init_x=_decs[0]);x=_init_x(this,1);}`)
+	assert(`@c class A { @m.n() static s() {} accessor y = 2 }`, `import{applyDecs as _applyDecs}from'yawp/runtime';var _dec,_A,_initClass,_decs;_dec=m.n();class A{static#_=(_decs=_applyDecs(this,[[[_dec],7,'s']],[c]),_A=_decs[0],_initClass=_decs[1]);static s(){}#_y=2;get y(){return this.#_y;}set y(v){this.#_y=v;}}A=_A,_initClass();`)
+	assert(`const B = @(d) class extends C { @e #p() {} }`, `import{applyDecs as _applyDecs}from'yawp/runtime';var _call_p,_initProto,_class,_initClass,_decs;const B=(class extends C{static#_=(_decs=_applyDecs(this,[[[e],2,'#p',function(){}]],[d]),_call_p=_decs[0],_initProto=_decs[1],_class=_decs[2],_initClass=_decs[3]);constructor(){super(...arguments);_initProto(this);}get#p(){return _call_p;}},_initClass(),_class);`)
+	assert(`export default @c class A {}`, `import{applyDecs as _applyDecs}from'yawp/runtime';var _A,_initClass,_decs;class A{static#_=(_decs=_applyDecs(this,[],[c]),_A=_decs[0],_initClass=_decs[1]);}A=_A,_initClass();export{A as default};`)
+
+	assert = makeOptionsAssert(t, "", &options.Options{
+		Target:  options.ES2020,
+		Runtime: "yawp/runtime",
+	})
+
+	assert(`class A { @f x = 1 }`, `import{applyDecs as _applyDecs,defineProperty as _defineProperty}from'yawp/runtime';var _init_x,_decs;class A{constructor(){_defineProperty(this,'x',_init_x(this,1));}}_decs=_applyDecs(A,[[[f],0,'x']]),_init_x=_decs[0];`)
+	assert(`const B = @(d) class extends C { @e #p() {} }`, `import{applyDecs as _applyDecs}from'yawp/runtime';var _call_p,_initProto,_class,_initClass,_decs,_class2,_p,_get_p;const B=((_p=new WeakMap(),_get_p=function(){return _call_p;},_class2=class extends C{constructor(){super(...arguments);_p.set(this,{get:_get_p});_initProto(this);}},(_decs=_applyDecs(_class2,[[[e],2,'#p',function(){}]],[d]),_call_p=_decs[0],_initProto=_decs[1],_class=_decs[2],_initClass=_decs[3]),_class2),_initClass(),_class);`)
+}
+
+func TestLegacyDecorators(t *testing.T) {
+	assert := makeOptionsAssert(t, "", &options.Options{
+		Target:           options.ES2020,
+		LegacyDecorators: true,
+		Runtime:          "yawp/runtime",
+	})
+
+	assert(`@a @b class A { @c x = 1; @d static m() {} }`, `import{applyDecoratedDescriptor as _applyDecoratedDescriptor,initializerDefineProperty as _initializerDefineProperty}from'yawp/runtime';var _descriptor;class A{constructor(){_initializerDefineProperty(this,'x',_descriptor,this);}static m(){}}_descriptor=_applyDecoratedDescriptor(A.prototype,'x',[c],{configurable:true,enumerable:true,writable:true,initializer:function(){return 1;}});_applyDecoratedDescriptor(A,'m',[d],Object.getOwnPropertyDescriptor(A,'m'),A);A=a(A=b(A)||A)||A;`)
+	assert(`class A extends B { @c get [k]() {} @d static y }`, `import{applyDecoratedDescriptor as _applyDecoratedDescriptor}from'yawp/runtime';var _key;class A extends B{get[_key=k](){}}_applyDecoratedDescriptor(A.prototype,_key,[c],Object.getOwnPropertyDescriptor(A.prototype,_key),A.prototype);_applyDecoratedDescriptor(A,'y',[d],{configurable:true,enumerable:true,writable:true,initializer:null},A);`)
+	assert(`export default @a class { @b m() {} }`, `import{applyDecoratedDescriptor as _applyDecoratedDescriptor}from'yawp/runtime';var _class;export default(_class=class{m(){}},_applyDecoratedDescriptor(_class.prototype,'m',[b],Object.getOwnPropertyDescriptor(_class.prototype,'m'),_class.prototype),a(_class)||_class);`)
+	assert(`class A { x; @b #m() {} }`, `1:17 Private method can not be decorated in legacy mode`)
+}
+
+func TestClassesES5(t *testing.T) {
+	assert := makeOptionsAssert(t, "", &options.Options{
+		Target:  options.ES5,
+		Runtime: "yawp/runtime",
+	})
+
+	assert(`class A { x = 1; constructor(y) { this.y = y } m() {} get g() {} set g(v) {} static s = this }`, `import{defineProperty as _defineProperty,classCallCheck as _classCallCheck}from'yawp/runtime';var A=function(){function A(y){_classCallCheck(this,A);_defineProperty(this,'x',1);this.y=y;}Object.defineProperty(A.prototype,'m',{value:function(){},writable:true,configurable:true});Object.defineProperty(A.prototype,'g',{get:function(){},set:function(v){},configurable:true});return A;}();_defineProperty(A,'s',A);`)
+	assert(`class B extends A { constructor() { super(1); this.z = () => this } m() { return super.m() } }`, `import{inherits as _inherits,superGet as _superGet,classCallCheck as _classCallCheck}from'yawp/runtime';var B=function(_A){_inherits(B,_A);function B(){var _this;_classCallCheck(this,B);_this=_A.call(this,1)||this;_this.z=function(){return _this;};return _this;}Object.defineProperty(B.prototype,'m',{value:function(){return _superGet(_A.prototype,'m',this).call(this);},writable:true,configurable:true});return B;}(A);`)
+	assert(`class B extends A { m() { super.x = 1; super[k] += 2; return super.v++ } }`, `import{inherits as _inherits,superSet as _superSet,superGet as _superGet,classCallCheck as _classCallCheck}from'yawp/runtime';var _key,_tmp;var B=function(_A){_inherits(B,_A);function B(){_classCallCheck(this,B);return _A.apply(this,arguments)||this;}Object.defineProperty(B.prototype,'m',{value:function(){_superSet(_A.prototype,'x',1,this);_superSet(_A.prototype,_key=k,_superGet(_A.prototype,_key,this)+2,this);return _superSet(_A.prototype,'v',(_tmp=+_superGet(_A.prototype,'v',this))+1,this),_tmp;},writable:true,configurable:true});return B;}(A);`)
+	assert(`let A = 1; { class A {} new A } f(A)`, `import{classCallCheck as _classCallCheck}from'yawp/runtime';var A=1;{var _A=function(){function A(){_classCallCheck(this,A);}return A;}();new _A();}f(A);`)
+	assert(`class A extends B { constructor(...args) { super(...args); super.m(...args); } }`, `import{inherits as _inherits,classCallCheck as _classCallCheck,toConsumableArray as _toConsumableArray,superGet as _superGet,slicedArrayRest as _slicedArrayRest}from'yawp/runtime';var A=function(_B){_inherits(A,_B);function A(){var args=_slicedArrayRest(arguments,0);var _this;_classCallCheck(this,A);_this=_B.apply(this,_toConsumableArray(args))||this;_superGet(_B.prototype,'m',_this).apply(_this,_toConsumableArray(args));return _this;}return A;}(B);`)
+	assert(`export default class extends A {}`, `import{inherits as _inherits,classCallCheck as _classCallCheck}from'yawp/runtime';export default(function(_A){_inherits(_class,_A);function _class(){_classCallCheck(this,_class);return _A.apply(this,arguments)||this;}return _class;}(A));`)
+	assert(`const Anon = class {}; o = {Prop: class {}, default: class {}}; x = class { m() { return x } }`, `import{classCallCheck as _classCallCheck}from'yawp/runtime';var Anon=function(){function Anon(){_classCallCheck(this,Anon);}return Anon;}();o={Prop:function(){function Prop(){_classCallCheck(this,Prop);}return Prop;}(),default:function(){function _class(){_classCallCheck(this,_class);}return _class;}()};x=function(){function _class2(){_classCallCheck(this,_class2);}Object.defineProperty(_class2.prototype,'m',{value:function(){return x;},writable:true,configurable:true});return _class2;}();`)
+	assert(`var y; y = class extends A {}`, `import{inherits as _inherits,classCallCheck as _classCallCheck}from'yawp/runtime';var y;y=function(_A){_inherits(y,_A);function y(){_classCallCheck(this,y);return _A.apply(this,arguments)||this;}return y;}(A);`)
+}
+
+func TestClassFields(t *testing.T) {
+	assert := makeAssert(t, "")
+
+	assert(`class A extends B { x = 1; [k] = this; static s = this.x; constructor() { f(); super() } }`, `import{defineProperty as _defineProperty}from'yawp/runtime';var _key;_key=k;class A extends B{constructor(){f();super();_defineProperty(this,'x',1);_defineProperty(this,_key,this);}}_defineProperty(A,'s',A.x);`)
+	assert(`class A { #x = 1; #m() {} static #s = 2; n(o) { this.#m(); o.#x++; return A.#s } }`, `import{classPrivateMethodGet as _classPrivateMethodGet,classPrivateFieldGet as _classPrivateFieldGet,classPrivateFieldSet as _classPrivateFieldSet,classStaticPrivateFieldGet as _classStaticPrivateFieldGet}from'yawp/runtime';var _x,_m,_m2,_s,_tmp;_x=new WeakMap(),_m=new WeakSet(),_m2=function(){};class A{constructor(){_m.add(this);_x.set(this,{writable:true,value:1});}n(o){_classPrivateMethodGet(this,_m,_m2).call(this);_classPrivateFieldSet(o,_x,(_tmp=+_classPrivateFieldGet(o,_x))+1),_tmp;return _classStaticPrivateFieldGet(A,A,_s);}}_s={writable:true,value:2};`)
+	assert(`const C = class { get #g() {} static y = super.z }`, `import{superGet as _superGet,defineProperty as _defineProperty}from'yawp/runtime';var _class,_g,_get_g;const C=(_g=new WeakMap(),_get_g=function(){},_class=class{constructor(){_g.set(this,{get:_get_g});}},_defineProperty(_class,'y',_superGet(Object.getPrototypeOf(_class),'z',_class)),_class);`)
+	assert(`class A { #x; static #s; m(a) { [this.#x, {y: A.#s}] = a; for (this.#x of a); } }`, `import{classPrivateFieldDestructureSet as _classPrivateFieldDestructureSet,classStaticPrivateFieldDestructureSet as _classStaticPrivateFieldDestructureSet}from'yawp/runtime';var _x,_s;_x=new WeakMap();class A{constructor(){_x.set(this,{writable:true,value:void 0});}m(a){[_classPrivateFieldDestructureSet(this,_x).value,{y:_classStaticPrivateFieldDestructureSet(A,A,_s).value}]=a;for(_classPrivateFieldDestructureSet(this,_x).value of a);}}_s={writable:true,value:void 0};`)
+	assert(`class A { [a()] = 1; [b()]() {} static get [d()]() {} [c()] = 2 }`, `import{defineProperty as _defineProperty}from'yawp/runtime';var _key,_key2,_key3,_key4;_key=a(),_key2=b(),_key3=d(),_key4=c();class A{constructor(){_defineProperty(this,_key,1);_defineProperty(this,_key4,2);}[_key2](){}static get[_key3](){}}`)
+	assert(`class A { static name = 'foo'; x = 1 }`, `import{defineProperty as _defineProperty}from'yawp/runtime';class A{constructor(){_defineProperty(this,'x',1);}}_defineProperty(A,'name','foo');`)
+
+	assert = makeOptionsAssert(t, "", &options.Options{
+		Target:               options.ES2020,
+		SetPublicClassFields: true,
+		Runtime:              "yawp/runtime",
+	})
+
+	assert(`class A { static s = 1; x = 1 }`, `class A{constructor(){this.x=1;}}A.s=1;`)
+}
+
+func TestArrowFunctionsES5(t *testing.T) {
+	assert := makeOptionsAssert(t, "", &options.Options{
+		Target:  options.ES5,
+		Runtime: "yawp/runtime",
+	})
+
+	assert(`const f = () => this`, `var _this=void 0;var f=function(){return _this;};`)
+	assert(`function f() { return (a) => () => [this, arguments, new.target, a] }`, `function f(){var _this=this,_arguments=arguments,_newTarget=this instanceof f?this.constructor:void 0;return function(a){return function(){return[_this,_arguments,_newTarget,a];};};}`)
+	assert(`const o = { m() { return [1].map(x => ({ x, arguments: this })) } }`, `var o={m:function(){var _this=this;return[1].map(function(x){return{x:x,arguments:_this};});}};`)
+	assert(`x = function () { return new.target }; class A { constructor() { this.t = new.target } }`, `import{classCallCheck as _classCallCheck}from'yawp/runtime';x=function _target(){return this instanceof _target?this.constructor:void 0;};var A=function(){function A(){_classCallCheck(this,A);this.t=this instanceof A?this.constructor:void 0;}return A;}();`)
+}
+
+func TestGeneratorsES5(t *testing.T) {
+	assert := makeOptionsAssert(t, "", &options.Options{
+		Target:  options.ES5,
+		Runtime: "yawp/runtime",
+	})
+
+	assert(`function* g(a) { var x = yield a; try { yield* x; } catch (e) { return e; } }`, `import{wrapGenerator as _wrapGenerator}from'yawp/runtime';function g(a){var x,_e;return _wrapGenerator(function(_context){while(1)switch(_context.prev=_context.next){case 0:_context.next=1;return a;case 1:x=_context.sent;case 2:_context.prev=2;return _context.delegateYield(x,3);case 3:_context.result;_context.next=5;break;case 4:_context.prev=4;_e=_context.catch(2);return _context.abrupt('return',_e);case 5:case 6:case'end':return _context.stop();}},this,[[2,4,null,null]]);}`)
+	assert(`function* g(a) { try { yield 1; throw a; } catch (e) { yield e; } finally { yield 3; } }`, `import{wrapGenerator as _wrapGenerator}from'yawp/runtime';function g(a){var _e;return _wrapGenerator(function(_context){while(1)switch(_context.prev=_context.next){case 0:case 1:_context.prev=1;_context.next=2;return 1;case 2:_context.sent;throw a;_context.next=5;break;case 3:_context.prev=3;_e=_context.catch(1);_context.next=4;return _e;case 4:_context.sent;case 5:_context.prev=5;_context.next=6;return 3;case 6:_context.sent;return _context.finish(5);case 7:case 8:case'end':return _context.stop();}},this,[[1,3,5,7]]);}`)
+	assert(`function* g(a) { yield* a; return yield* [4, 5]; }`, `import{wrapGenerator as _wrapGenerator}from'yawp/runtime';function g(a){return _wrapGenerator(function(_context){while(1)switch(_context.prev=_context.next){case 0:return _context.delegateYield(a,1);case 1:_context.result;return _context.delegateYield([4,5],2);case 2:return _context.abrupt('return',_context.result);case 3:case'end':return _context.stop();}},this,[]);}`)
+	assert(`function* g() { try { yield 1; return 2; } finally { return 3; } }`, `import{wrapGenerator as _wrapGenerator}from'yawp/runtime';function g(){return _wrapGenerator(function(_context){while(1)switch(_context.prev=_context.next){case 0:case 1:_context.prev=1;_context.next=2;return 1;case 2:_context.sent;return _context.abrupt('return',2);case 3:_context.prev=3;return _context.abrupt('return',3);return _context.finish(3);case 4:case 5:case'end':return _context.stop();}},this,[[1,null,3,4]]);}`)
+	assert(`function* g(a) { outer: for (var i = 0; i < 3; i++) { for (var j = 0; j < 3; j++) { if (j == a) continue outer; if (i == 2) break outer; yield i * 10 + j; } } }`, `import{wrapGenerator as _wrapGenerator}from'yawp/runtime';function g(a){var i,j;return _wrapGenerator(function(_context){while(1)switch(_context.prev=_context.next){case 0:i=0;case 1:if(!(i<3)){_context.next=9;break;}j=0;case 2:if(!(j<3)){_context.next=7;break;}if(!(j==a)){_context.next=3;break;}return _context.abrupt('continue',8);case 3:if(!(i==2)){_context.next=4;break;}return _context.abrupt('break',9);case 4:_context.next=5;return i*10+j;case 5:_context.sent;case 6:j++;_context.next=2;break;case 7:case 8:i++;_context.next=1;break;case 9:case 10:case'end':return _context.stop();}},this,[]);}`)
+	assert(`function* g(a) { var x = (yield 1) + (yield 2); f(yield x, a ? yield 3 : 4); return [yield 5, {k: yield 6}]; }`, `import{wrapGenerator as _wrapGenerator}from'yawp/runtime';function g(a){var x;return _wrapGenerator(function(_context){while(1)switch(_context.prev=_context.next){case 0:_context.next=1;return 1;case 1:_context.t0=_context.sent;_context.next=2;return 2;case 2:_context.t1=_context.sent;x=_context.t0+_context.t1;_context.t2=f;_context.next=3;return x;case 3:_context.t3=_context.sent;if(!a){_context.next=5;break;}_context.next=4;return 3;case 4:_context.t4=_context.sent;_context.next=6;break;case 5:_context.t4=4;case 6:_context.t5=_context.t4;(0,_context.t2)(_context.t3,_context.t5);_context.next=7;return 5;case 7:_context.t6=_context.sent;_context.next=8;return 6;case 8:_context.t7=_context.sent;_context.t8={k:_context.t7};return _context.abrupt('return',[_context.t6,_context.t8]);case 9:case'end':return _context.stop();}},this,[]);}`)
+}
+
+func TestObjectLiterals(t *testing.T) {
+	assert := makeOptionsAssert(t, "", &options.Options{
+		Target:  options.ES5,
+		Runtime: "yawp/runtime",
+	})
+
+	assert(`var o = { a, m() {}, [k]: 1, __proto__: p, get g() {} }`, `import{defineProperty as _defineProperty}from'yawp/runtime';var _obj;var o=(_obj={a:a,m:function(){}},_defineProperty(_obj,k,1),_obj.__proto__=p,Object.defineProperty(_obj,'g',{get:function(){},enumerable:true,configurable:true}),_obj);`)
+	assert(`var o = { a: 1, ...b, c }`, `import{objectSpread as _objectSpread}from'yawp/runtime';var o=_objectSpread({a:1},b,{c:c});`)
+	assert(`var o = { m() { return super.m(); }, get g() { return () => super.g; } }`, `import{superGet as _superGet}from'yawp/runtime';var _obj;var o=(_obj={m:function(){return _superGet(Object.getPrototypeOf(_obj),'m',this).call(this);},get g(){var _this=this;return function(){return _superGet(Object.getPrototypeOf(_obj),'g',_this);};}},_obj);`)
+
+	assert = makeAssert(t, "")
+
+	assert(`o = { m() { return super.m(); }, async [k]() {}, *g() {}, n: function () {} }`, `o={m(){return super.m();},async[k](){},*g(){},n:function(){}};`)
+}
+
+func TestTemplates(t *testing.T) {
+	assert := makeAssert(t, "")
+
+	assert("`a\\n${b}\\`\\${}`", "`a\\n${b}\\`\\${}`;")
+
+	assert = makeOptionsAssert(t, "", &options.Options{
+		Target:  options.ES5,
+		Runtime: "yawp/runtime",
+	})
+
+	assert("`a\\n${b}\\u{1F600}${c}`", `"a\n".concat(b,"\ud83d\ude00").concat(c);`)
+	assert("tag`a${b}\\x`", `import{taggedTemplateLiteral as _taggedTemplateLiteral}from'yawp/runtime';var _templateObject;tag(_templateObject||(_templateObject=_taggedTemplateLiteral(["a",void 0],["a","\\x"])),b);`)
+}
+
+func TestAsyncFunctions(t *testing.T) {
+	assert := makeOptionsAssert(t, "", &options.Options{
+		Target:  options.ES2015,
+		Runtime: "yawp/runtime",
+	})
+
+	assert(`async function f(a) { return await a }`, `import{asyncToGenerator as _asyncToGenerator}from'yawp/runtime';function f(a){return _asyncToGenerator(function*(){return yield a;}).apply(this,arguments);}`)
+	assert(`const f = async () => { for await (const x of this) {} }`, `import{asyncIterator as _asyncIterator,asyncToGenerator as _asyncToGenerator}from'yawp/runtime';const f=()=>_asyncToGenerator(function*(){var _iteratorAbruptCompletion,_didIteratorError,_iteratorError,_iterator,_step;_iteratorAbruptCompletion=false,_didIteratorError=false;try{for(_iterator=_asyncIterator(this);_iteratorAbruptCompletion=!(_step=yield _iterator.next()).done;_iteratorAbruptCompletion=false){const x=_step.value;{}}}catch(_err){_didIteratorError=true,_iteratorError=_err;}finally{try{if(_iteratorAbruptCompletion&&_iterator.return!=null)yield _iterator.return();}finally{if(_didIteratorError)throw _iteratorError;}}}).call(this);`)
+	assert(`class A extends B { async m() { return super.m() } }`, `import{asyncToGenerator as _asyncToGenerator}from'yawp/runtime';class A extends B{m(){var _superprop_get=(_prop)=>super[_prop];return _asyncToGenerator(function*(){return _superprop_get('m').call(this);}).apply(this,arguments);}}`)
+	assert(`async function f(a, b = a, ...c) {}`, `import{asyncToGenerator as _asyncToGenerator}from'yawp/runtime';function f(_x){return _asyncToGenerator(function*(a,b=a,...c){}).apply(this,arguments);}`)
+	assert(`async function f({a}, [b]) { return a + b }`, `import{asyncToGenerator as _asyncToGenerator}from'yawp/runtime';function f(_x,_x2){return _asyncToGenerator(function*({a:a},[b]){return a+b;}).apply(this,arguments);}`)
+	assert(`function g() { return async (a = h()) => { await this.x(a, arguments) } }`, `import{asyncToGenerator as _asyncToGenerator}from'yawp/runtime';function g(){var _this=this,_arguments=arguments;return function(){return _asyncToGenerator(function*(a=h()){yield _this.x(a,_arguments);}).apply(this,arguments);};}`)
+	assert(`const f = async (a, b) => a + b`, `import{asyncToGenerator as _asyncToGenerator}from'yawp/runtime';const f=(a,b)=>_asyncToGenerator(function*(){return a+b;}).call(this);`)
+	assert(`class A extends B { async m(a, b = super.x) { return super.m(a, b) } }`, `import{asyncToGenerator as _asyncToGenerator}from'yawp/runtime';class A extends B{m(_x){var _superprop_get=(_prop)=>super[_prop];return _asyncToGenerator(function*(a,b=_superprop_get('x')){return _superprop_get('m').call(this,a,b);}).apply(this,arguments);}}`)
+}
+
+func TestIterationES5(t *testing.T) {
+	assert := makeOptionsAssert(t, "", &options.Options{
+		Target:  options.ES5,
+		Runtime: "yawp/runtime",
+	})
+
+	assert(`for (const [x, y] of z) f(x, ...y)`, `import{getIterator as _getIterator,slicedToArray as _slicedToArray,toConsumableArray as _toConsumableArray}from'yawp/runtime';var _iteratorAbruptCompletion,_didIteratorError,_iteratorError,_iterator,_step;_iteratorAbruptCompletion=false,_didIteratorError=false;try{for(_iterator=_getIterator(z);_iteratorAbruptCompletion=!(_step=_iterator.next()).done;_iteratorAbruptCompletion=false){var _ref=_step.value,_ref2=_slicedToArray(_ref,2),x=_ref2[0],y=_ref2[1];f.apply(void 0,[x].concat(_toConsumableArray(y)));}}catch(_err){_didIteratorError=true,_iteratorError=_err;}finally{try{if(_iteratorAbruptCompletion&&_iterator.return!=null)_iterator.return();}finally{if(_didIteratorError)throw _iteratorError;}}`)
+
+	assert = makeOptionsAssert(t, "", &options.Options{
+		Target:       options.ES5,
+		AssumeArrays: true,
+		Runtime:      "yawp/runtime",
+	})
+
+	assert(`for (const a of b) o.m(...a); new F(...[1, ...a])`, `var _i,_arr;for(_i=0,_arr=b;_i<_arr.length;_i++){var a=_arr[_i];o.m.apply(o,a);}new(Function.prototype.bind.apply(F,[null].concat([1].concat(a))))();`)
+}
+
+func TestOptionalChaining(t *testing.T) {
+	assert := makeAssert(t, "")
+
+	assert(`a?.b.c?.[d]?.(e) ?? f`, `a?.b.c?.[d]?.(e)??f;`)
+
+	assert = makeOptionsAssert(t, "", &options.Options{
+		Target:  options.ES2015,
+		Runtime: "yawp/runtime",
+	})
+
+	assert(`a?.b.c()`, `a==null?void 0:a.b.c();`)
+	assert(`f().b?.(c)`, `var _obj,_ref;(_ref=(_obj=f()).b)==null?void 0:_ref.call(_obj,c);`)
+	assert(`a.b ?? c`, `var _ref;(_ref=a.b)!=null?_ref:c;`)
+	assert(`delete a?.b`, `a==null?true:delete a.b;`)
+}
+
+func TestBlockScopingES5(t *testing.T) {
+	assert := makeOptionsAssert(t, "", &options.Options{
+		Target:  options.ES5,
+		TDZ:     true,
+		Runtime: "yawp/runtime",
+	})
+
+	assert(`let x = 1; { let x = 2; }`, `var x=1;{var _x=2;}`)
+	assert(
+		`for (let i = 0; i < 3; i++) { f(() => i); if (i) break; }`,
+		`var _ret;var _loop=function(i){{f(function(){return i;});if(i)return'break';}};for(var i=0;i<3;i++){_ret=_loop(i);if(_ret==='break')break;}`,
+	)
+	assert(`x; let x;`, `import{tdz as _tdz}from'yawp/runtime';_tdz('x');var x;`)
+	assert("const c = 1;\nc++;", `2:1 Assignment to constant variable "c"`)
+	assert("const c = 1;\n({d: [c = 1]} = x);", `2:7 Assignment to constant variable "c"`)
+	assert("const c = 1;\nfor (c in x);", `2:6 Assignment to constant variable "c"`)
+	assert("const {c} = o;\nc = 1;", `2:1 Assignment to constant variable "c"`)
+	assert("const [c] = o;\nc++;", `2:1 Assignment to constant variable "c"`)
+	assert("for (const {c} of o)\n  c = 1;", `2:3 Assignment to constant variable "c"`)
+	assert("const {d: [{e: c = 1}]} = o;\nc = 1;", `2:1 Assignment to constant variable "c"`)
+	assert(`[a] = x; let a;`, `import{slicedToArray as _slicedToArray,tdz as _tdz}from'yawp/runtime';var _ref;_ref=_slicedToArray(x,1),_ref[0],_tdz('a');var a;`)
+	assert(
+		`function* g() { for (let i = 0; i < 3; i++) { f(() => i); yield i; } }`,
+		`import{wrapGenerator as _wrapGenerator}from'yawp/runtime';function g(){var _loop,i;return _wrapGenerator(function(_context2){while(1)switch(_context2.prev=_context2.next){case 0:_loop=function(i){return _wrapGenerator(function(_context){while(1)switch(_context.prev=_context.next){case 0:f(function(){return i;});_context.next=1;return i;case 1:_context.sent;case 2:case'end':return _context.stop();}},this,[]);};i=0;case 1:if(!(i<3)){_context2.next=4;break;}return _context2.delegateYield(_loop(i),2);case 2:_context2.result;case 3:i++;_context2.next=1;break;case 4:case 5:case'end':return _context2.stop();}},this,[]);}`,
+	)
+	assert(
+		`async function g() { for (let i = 0; i < 3; i++) { f(() => i); await i; } }`,
+		`import{wrapGenerator as _wrapGenerator,asyncToGenerator as _asyncToGenerator}from'yawp/runtime';function g(){return _asyncToGenerator(function(){var _loop,i;return _wrapGenerator(function(_context2){while(1)switch(_context2.prev=_context2.next){case 0:_loop=function(i){return _asyncToGenerator(function(){return _wrapGenerator(function(_context){while(1)switch(_context.prev=_context.next){case 0:f(function(){return i;});_context.next=1;return i;case 1:_context.sent;case 2:case'end':return _context.stop();}},this,[]);}).apply(this,arguments);};i=0;case 1:if(!(i<3)){_context2.next=4;break;}_context2.next=2;return _loop(i);case 2:_context2.sent;case 3:i++;_context2.next=1;break;case 4:case 5:case'end':return _context2.stop();}},this,[]);}).apply(this,arguments);}`,
+	)
+	assert(
+		"function* g() { for (let i = 0; i < 3; i++) { f(() => i);\n yield arguments; } }",
+		`1:17 Loop with closures capturing its variables can not read arguments of the generator`,
+	)
+}
+
+func TestParametersES5(t *testing.T) {
+	assert := makeOptionsAssert(t, "", &options.Options{
+		Target:  options.ES5,
+		Runtime: "yawp/runtime",
+	})
+
+	assert(
+		`function f(a, b = 0, {c} = {}) { var x; }`,
+		`function f(a){var b=arguments[1]===void 0?0:arguments[1],_ref=arguments[2]===void 0?{}:arguments[2],c=_ref.c;var x;}`,
+	)
+	assert(`function f({a}, ...b) {}`, `import{slicedArrayRest as _slicedArrayRest}from'yawp/runtime';function f(_ref){var a=_ref.a,b=_slicedArrayRest(arguments,1);}`)
+	assert(`function f(a = x) { let x; }`, `function f(){var a=arguments[0]===void 0?x:arguments[0];var _x;}`)
+	assert(`function f(x = 1) { function x() {} return x }`, `function f(){var x=arguments[0]===void 0?1:arguments[0];function _x(){}return _x;}`)
+	assert(`function f(x, ...y) { var x; function y() {} return [x, y] }`, `import{slicedArrayRest as _slicedArrayRest}from'yawp/runtime';function f(x){var y=_slicedArrayRest(arguments,1);var x;function _y(){}return[x,_y];}`)
+	assert(`function f(a = x) { function x() {} }`, `function f(){var a=arguments[0]===void 0?x:arguments[0];function _x(){}}`)
+	assert(`function f(x) { function x() {} }`, `function f(x){function x(){}}`)
+}
+
+func TestDestructuringES5(t *testing.T) {
+	assert := makeOptionsAssert(t, "", &options.Options{
+		Target:       options.ES5,
+		AssumeArrays: true,
+		Runtime:      "yawp/runtime",
+	})
+
+	assert(`var a, b; [a, b] = [b, a];`, `var _ref;var a,b;_ref=[b,a],a=_ref[0],b=_ref[1];`)
+	assert(`var x; ({x} = o);`, `var x;x=o.x;`)
+	assert(`for (var {k} in o);`, `var _key;for(_key in o){var k=_key.k;}`)
+	assert(`try {} catch ({message}) {}`, `try{}catch(_ref){var message=_ref.message;{}}`)
+	assert(`try {} catch {}`, `try{}catch(_unused){}`)
+	assert(`({[a()]: b().x, [c()]: d().y} = o)`, `var _key,_key2;_key=a(),b().x=o[_key],_key2=c(),d().y=o[_key2];`)
+}
+
+func TestSyntaxLoweringES5(t *testing.T) {
+	assert := makeOptionsAssert(t, "", &options.Options{
+		Target:  options.ES5,
+		Runtime: "yawp/runtime",
+	})
+
+	assert(`x = 2 ** 3 ** 2 * a; x **= 2; o[k()] **= 2`, `var _key;x=Math.pow(2,Math.pow(3,2))*a;x=Math.pow(x,2);o[_key=k()]=Math.pow(o[_key],2);`)
+	assert(`x = [0b1_01, 0o17, 1_000.5, 0xF_F]`, `x=[5,15,1000.5,0xFF];`)
+	assert(`x = [/a.b/s, /[^a]/u, /ab/u, /(?<y>\d)/]`, `x=[/a[\s\S]b/,new RegExp('[^a]','u'),/ab/,new RegExp('(?<y>\\d)')];`)
+	assert(`x = 1n`, `1:5 BigInt literal 1n is not supported by the target`)
+}
+
+func TestRuntimeHelpers(t *testing.T) {
+	assert := makeOptionsAssert(t, "", &options.Options{
+		Target: options.ES5,
+		TDZ:    true,
+	})
+
+	assert(`x; y; let x, y, _tdz;`, `function _tdz2(name){throw new ReferenceError("Cannot access '"+name+"' before initialization");}_tdz2('x');_tdz2('y');var x,y,_tdz;`)
+
+	assert = makeOptionsAssert(t, "", &options.Options{
+		Target: options.ES5,
+		Minify: true,
+		TDZ:    true,
+	})
+
+	assert(`x; let x;`, `function _(a){throw new ReferenceError("Cannot access '"+a+"' before initialization");}_('x');var $;`)
+}
+
+func TestBrowsers(t *testing.T) {
+	assert := makeOptionsAssert(t, "", &options.Options{
+		Browsers: "safari 13",
+		Runtime:  "yawp/runtime",
+	})
+
+	assert(`class A { m() { return a?.b ?? c; } }`, `class A{m(){var _ref;return(_ref=a==null?void 0:a.b)!=null?_ref:c;}}`)
+	assert(`x = 1_000`, `x=1_000;`)
+	assert(`x = 1n`, `1:5 BigInt literal 1n is not supported by the target`)
+
+	assert = makeOptionsAssert(t, "", &options.Options{
+		Browsers: "firefox esr",
+		Runtime:  "yawp/runtime",
+	})
+
+	assert(`class A { #x = /a/d; }`, `class A{#x=/a/d;}`)
+
+	assert = makeOptionsAssert(t, "", &options.Options{
+		Browsers: "safari 10",
+		TDZ:      true,
+		Runtime:  "yawp/runtime",
+	})
+
+	assert("const c = 1;\n[c] = x;", `2:2 Assignment to constant variable "c"`)
+	assert("const c = 1;\n({d: c} = x);", `2:6 Assignment to constant variable "c"`)
+	assert(`({a} = x); let a;`, `import{tdz as _tdz}from'yawp/runtime';x,_tdz('a');var a;`)
+
+	assert = makeOptionsAssert(t, "", &options.Options{
+		Browsers: "firefox esr, not firefox 115, last 2 versions",
+		Runtime:  "yawp/runtime",
+	})
+
+	assert(`x`, `unsupported browsers query "last 2 versions"`)
+}
+
+func TestCompliance(t *testing.T) {
+	prog, err := parser.ParseModule("a.js", "function F() { return new.target; }\ntry { x = import('x'); } catch { }\nx = [/(?<!a)b/, /[\\p{L}]/u, /(?<y>\\p{L})/u, /a/d]\ny = /(?<z>a)/u")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	warnings, err := transpiler.Transpile(prog, &options.Options{
+		Target:          options.ES2015,
+		CheckCompliance: true,
+		Runtime:         "yawp/runtime",
+	})
+
+	expected := []string{
+		"a.js:2:11 dynamic import is not supported by the target and can not be lowered",
+		"a.js:3:5 RegExp lookbehind assertion is not supported by the target and can not be lowered",
+		"a.js:3:16 RegExp unicode property escape is not supported by the target and can not be lowered",
+		"a.js:3:28 RegExp /(?<y>\\p{L})/u is not supported by the target, it is created at runtime",
+		"a.js:3:44 RegExp indices flag is not supported by the target and can not be lowered",
+		"a.js:4:4 RegExp /(?<z>a)/u is not supported by the target, it is created at runtime",
+	}
+
+	if err != nil || len(warnings) != len(expected) {
+		t.Fatalf("\nExpected warnings: %q\nActual warnings: %q %v", expected, warnings, err)
+	}
+
+	for index, warning := range warnings {
+		if warning.Error() != expected[index] {
+			t.Errorf("\nExpected warning: %s\nActual warning: %s", expected[index], warning)
+		}
+	}
+}
+
+func TestJSX(t *testing.T) {
+	assert := makeAssert(t, "test.jsx")
+
+	assert(`x = <div className="a &amp; b" {...p} aria-hidden>Hi {name}!</div>`, `x=React.createElement('div',{className:'a & b',...p,'aria-hidden':true},'Hi ',name,'!');`)
+	assert("x = <A.B>\n  first\n  second {/* c */}\n  <my-element />\n</A.B>", `x=React.createElement(A.B,null,'first second ',React.createElement('my-element',null));`)
+
+	assert = makeOptionsAssert(t, "test.jsx", &options.Options{
+		Target:        options.ES5,
+		Runtime:       "yawp/runtime",
+		JSXPragma:     "h",
+		JSXPragmaFrag: "Fragment",
+	})
+
+	assert(`x = <><A {...p} key="1" /></>`, `import{objectSpread as _objectSpread}from'yawp/runtime';x=h(Fragment,null,h(A,_objectSpread({},p,{key:'1'})));`)
+
+	assert = makeOptionsAssert(t, "test.jsx", &options.Options{
+		Target:  options.ES2020,
+		Runtime: "yawp/runtime",
+		JSX:     options.JSXAutomatic,
+	})
+
+	assert(`x = <div key={k} id="a"><b>{v}</b>text</div>`, `import{jsxs as _jsxs,jsx as _jsx}from'react/jsx-runtime';x=_jsxs('div',{id:'a',children:[_jsx('b',{children:v}),'text']},k);`)
+	assert(`x = <><A {...p} key="1" /></>`, `import{Fragment as _Fragment,jsx as _jsx}from'react/jsx-runtime';import{createElement as _createElement}from'react';x=_jsx(_Fragment,{children:_createElement(A,{...p,key:'1'})});`)
+}
+
+func TestJSXDevelopment(t *testing.T) {
+	assert := makeOptionsAssert(t, "test.jsx", &options.Options{
+		Target:         options.ES2020,
+		Runtime:        "yawp/runtime",
+		JSX:            options.JSXAutomatic,
+		JSXDevelopment: true,
+	})
+
+	assert(`x = <a key="k"><b/>{c}</a>`, `import{jsxDEV as _jsxDEV}from'react/jsx-dev-runtime';x=_jsxDEV('a',{children:[_jsxDEV('b',{},void 0,false,{fileName:'test.jsx',lineNumber:1,columnNumber:16},this),c]},'k',true,{fileName:'test.jsx',lineNumber:1,columnNumber:5},this);`)
+	assert(`class A extends B { constructor() { super(<i />); } }`, `import{jsxDEV as _jsxDEV}from'react/jsx-dev-runtime';class A extends B{constructor(){super(_jsxDEV('i',{},void 0,false,{fileName:'test.jsx',lineNumber:1,columnNumber:43},void 0));}}`)
+
+	assert = makeOptionsAssert(t, "test.jsx", &options.Options{
+		Target:         options.ES2020,
+		Runtime:        "yawp/runtime",
+		JSXDevelopment: true,
+	})
+
+	assert(`f = () => <i />`, `f=()=>React.createElement('i',{__source:{fileName:'test.jsx',lineNumber:1,columnNumber:11},__self:this});`)
+	assert(`class A extends B { static x = <i />; y = <b />; }`, `import{defineProperty as _defineProperty}from'yawp/runtime';class A extends B{constructor(){super(...arguments);_defineProperty(this,'y',React.createElement('b',{__source:{fileName:'test.jsx',lineNumber:1,columnNumber:43}}));}}_defineProperty(A,'x',React.createElement('i',{__source:{fileName:'test.jsx',lineNumber:1,columnNumber:32},__self:A}));`)
+
+	assert = makeOptionsAssert(t, "test.jsx", &options.Options{
+		Target:         options.ES5,
+		Runtime:        "yawp/runtime",
+		JSXDevelopment: true,
+	})
+
+	assert(`class A extends B { constructor() { super(); this.e = <i x={this.y} />; } }`, `import{inherits as _inherits,classCallCheck as _classCallCheck}from'yawp/runtime';var A=function(_B){_inherits(A,_B);function A(){var _this;_classCallCheck(this,A);_this=_B.call(this)||this;_this.e=React.createElement('i',{x:_this.y,__source:{fileName:'test.jsx',lineNumber:1,columnNumber:55},__self:_this});return _this;}return A;}(B);`)
+}
+
+func TestPragmas(t *testing.T) {
+	assert := makeOptionsAssert(t, "test.jsx", &options.Options{
+		Target:  options.ES2020,
+		Runtime: "yawp/runtime",
+		JSX:     options.JSXAutomatic,
+	})
+
+	assert("/** @jsx h\n * @jsxFrag Fragment */\nx = <><a /></>", `x=h(Fragment,null,h('a',null));`)
+	assert("'use client';\n// @jsxImportSource preact\nx = <a />", `'use client';import{jsx as _jsx}from'preact/jsx-runtime';x=_jsx('a',{});`)
+	assert("/* @jsxRuntime classic */ x = <a />", `x=React.createElement('a',null);`)
+	assert("x = 1; /** @jsx h */ y = <a />", `import{jsx as _jsx}from'react/jsx-runtime';x=1;y=_jsx('a',{});`)
+
+	assert("/*:: type A = number; */ let a /*: A */ = 1", `let a=1;`)
+	assert("// @noflow\n/*:: type A = number; */ type = 1", `type=1;`)
+
+	assert = makeOptionsAssert(t, "", &options.Options{
+		Target:  options.ES5,
+		Runtime: "yawp/runtime",
+	})
+
+	assert(`function f() { 'use strict'; return a.b?.c; }`, `function f(){'use strict';var _ref;return(_ref=a.b)==null?void 0:_ref.c;}`)
+}
+
+func TestFlowChecks(t *testing.T) {
+	assert := makeOptionsAssert(t, "a.js", &options.Options{
+		Target:     options.ES2020,
+		Runtime:    "yawp/runtime",
+		FlowChecks: true,
+	})
+
+	assert(
+		`function f(a: ?string): number { return a.length }`,
+		`import{flowTypeError as _flowTypeError}from'yawp/runtime';function f(a){a==null||typeof a==='string'||_flowTypeError('argument a','?string',a,'a.js:1:16');var _result;return _result=a.length,typeof _result==='number'||_flowTypeError('return value','number',_result,'a.js:1:25'),_result;}`,
+	)
+	assert(
+		`type P = {x: number, y?: 1 | 2}; const f = (p: P, ...r: string[]): void => {}`,
+		`import{flowTypeError as _flowTypeError}from'yawp/runtime';const f=(p,...r)=>{p!==null&&typeof p==='object'&&typeof p.x==='number'&&(p.y===void 0||(p.y===1||p.y===2))||_flowTypeError('argument p','P',p,'a.js:1:48');Array.isArray(r)&&r.every(function(_value){return typeof _value==='string';})||_flowTypeError('argument r','string[]',r,'a.js:1:57');};`,
+	)
+	assert(
+		`class A {} function f<T: A>(a: T, b: Array<?T>, c: mixed) {}`,
+		`import{flowTypeError as _flowTypeError}from'yawp/runtime';class A{}function f(a,b,c){a instanceof A||_flowTypeError('argument a','T',a,'a.js:1:32');Array.isArray(b)&&b.every(function(_value){return _value==null||_value instanceof A;})||_flowTypeError('argument b','Array<?T>',b,'a.js:1:38');}`,
+	)
+	assert(
+		`async function f(): Promise<string> { return g() }`,
+		`import{flowTypeError as _flowTypeError}from'yawp/runtime';async function f(){return Promise.resolve(g()).then(function(_result){return typeof _result==='string'||_flowTypeError('return value','string',_result,'a.js:1:29'),_result;});}`,
+	)
+	assert(
+		`function f({a}: {a: number}, [b]: string[]) {}`,
+		`import{flowTypeError as _flowTypeError}from'yawp/runtime';function f(_ref,_ref2){_ref!==null&&typeof _ref==='object'&&typeof _ref.a==='number'||_flowTypeError('argument 1','{a: number}',_ref,'a.js:1:17');Array.isArray(_ref2)&&_ref2.every(function(_value){return typeof _value==='string';})||_flowTypeError('argument 2','string[]',_ref2,'a.js:1:35');var{a:a}=_ref,[b]=_ref2;}`,
+	)
+	assert(`function f(): null { return g() }`, `import{flowTypeError as _flowTypeError}from'yawp/runtime';function f(){var _result;return _result=g(),_result===null||_flowTypeError('return value','null',_result,'a.js:1:15'),_result;}`)
+	assert(`function f(a): number { if (a) return 1 }`, `import{flowTypeError as _flowTypeError}from'yawp/runtime';function f(a){var _result;if(a)return _result=1,typeof _result==='number'||_flowTypeError('return value','number',_result,'a.js:1:16'),_result;typeof void 0==='number'||_flowTypeError('return value','number',void 0,'a.js:1:16');}`)
+	assert(`function f(a): number { if (a) { return 1 } else { throw a } }`, `import{flowTypeError as _flowTypeError}from'yawp/runtime';function f(a){var _result;if(a){return _result=1,typeof _result==='number'||_flowTypeError('return value','number',_result,'a.js:1:16'),_result;}else{throw a;}}`)
+	assert(`const f = (): ?number => {}, g = (): number => 1; async function h(): Promise<string> {}`, `import{flowTypeError as _flowTypeError}from'yawp/runtime';const f=()=>{void 0==null||typeof void 0==='number'||_flowTypeError('return value','?number',void 0,'a.js:1:16');},g=()=>{var _result;return _result=1,typeof _result==='number'||_flowTypeError('return value','number',_result,'a.js:1:38'),_result;};async function h(){typeof void 0==='string'||_flowTypeError('return value','string',void 0,'a.js:1:79');}`)
+	assert("// @noflow\nfunction f(a: string) {}", `function f(a){}`)
+}
@@ -8,14 +8,9 @@ func (g *Generator) Identifier(id *ast.Identifier) *ast.Identifier {
 	}
 
 	if id.LegacyRef != nil {
-		if id.LegacyRef.Type == ast.SRBuiltin && !id.LegacyRef.Mangled {
-			id.LegacyRef.Mangled = true
-			id.LegacyRef.Name = g.ids.Next()
-		}
-
-		g.str(id.LegacyRef.Name)
+		g.word(id.LegacyRef.Name)
 	} else {
-		g.str(id.Name)
+		g.word(id.Name)
 	}
 
 	return id
@@ -3,7 +3,7 @@ package generator
 import "yawp/parser/ast"
 
 func (g *Generator) Js(js *ast.Js) *ast.Js {
-	g.str(js.Code)
+	g.word(js.Code)
 
 	return js
 }
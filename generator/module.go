@@ -0,0 +1,189 @@
+package generator
+
+import (
+	"yawp/parser/ast"
+)
+
+func (g *Generator) ImportDeclaration(stmt *ast.ImportStatement) ast.IStmt {
+	if stmt.Kind != ast.IKValue {
+		return stmt
+	}
+
+	var named []*ast.ImportClause
+	separate := false
+
+	g.word("import")
+
+	// default and namespace clauses go before the named ones
+	for _, clause := range stmt.Imports {
+		if !clause.Namespace && clause.ModuleIdentifier.Name != "default" {
+			named = append(named, clause)
+			continue
+		}
+
+		if separate {
+			g.rune(',')
+		}
+
+		g.ImportClause(clause)
+		separate = true
+	}
+
+	if len(named) > 0 {
+		if separate {
+			g.rune(',')
+		}
+
+		g.rune('{')
+
+		for index, clause := range named {
+			if index > 0 {
+				g.rune(',')
+			}
+
+			g.ImportClause(clause)
+		}
+
+		g.rune('}')
+	}
+
+	if len(stmt.Imports) > 0 {
+		g.word("from")
+	}
+
+	g.str(stmt.From)
+	g.semicolon()
+
+	return stmt
+}
+
+func (g *Generator) ImportClause(clause *ast.ImportClause) *ast.ImportClause {
+	switch {
+	case clause.Namespace:
+		g.str("*as")
+	case clause.ModuleIdentifier.Name == "default":
+	case clause.ModuleIdentifier.Name != printedName(clause.LocalIdentifier):
+		g.word(clause.ModuleIdentifier.Name)
+		g.word("as")
+	}
+
+	g.Identifier(clause.LocalIdentifier)
+
+	return clause
+}
+
+func (g *Generator) ExportDeclaration(stmt *ast.ExportStatement) ast.IStmt {
+	if stmt.Kind == ast.EKType {
+		return stmt
+	}
+
+	switch stmt.Clause.(type) {
+	case *ast.FlowTypeStatement, *ast.FlowInterfaceStatement, *ast.TSDeclareStatement:
+		return stmt
+	}
+
+	g.word("export")
+	g.ExportClause(stmt.Clause)
+
+	return stmt
+}
+
+func (g *Generator) ExportNamespaceFromClause(c *ast.ExportNamespaceFromClause) *ast.ExportNamespaceFromClause {
+	g.rune('*')
+
+	if c.ModuleIdentifier != nil {
+		g.word("as")
+		g.word(c.ModuleIdentifier.Name)
+	}
+
+	g.word("from")
+	g.str(c.From)
+	g.semicolon()
+
+	return c
+}
+
+func (g *Generator) namedExports(exports []*ast.NamedExportClause) {
+	g.rune('{')
+
+	for index, export := range exports {
+		if index > 0 {
+			g.rune(',')
+		}
+
+		g.Identifier(export.LocalIdentifier)
+
+		if export.ModuleIdentifier.Name != printedName(export.LocalIdentifier) {
+			g.word("as")
+			g.word(export.ModuleIdentifier.Name)
+		}
+	}
+
+	g.rune('}')
+}
+
+// printedName is the name id is printed with after refs were resolved
+func printedName(id *ast.Identifier) string {
+	if id.LegacyRef != nil {
+		return id.LegacyRef.Name
+	}
+
+	return id.Name
+}
+
+func (g *Generator) ExportNamedFromClause(c *ast.ExportNamedFromClause) *ast.ExportNamedFromClause {
+	g.namedExports(c.Exports)
+	g.word("from")
+	g.str(c.From)
+	g.semicolon()
+
+	return c
+}
+
+func (g *Generator) ExportNamedClause(c *ast.ExportNamedClause) *ast.ExportNamedClause {
+	g.namedExports(c.Exports)
+	g.semicolon()
+
+	return c
+}
+
+func (g *Generator) ExportVarClause(c *ast.ExportVarClause) *ast.ExportVarClause {
+	g.VariableStatement(c.Declaration)
+
+	return c
+}
+
+func (g *Generator) ExportFunctionClause(c *ast.ExportFunctionClause) *ast.ExportFunctionClause {
+	g.FunctionLiteral(c.FunctionLiteral)
+
+	return c
+}
+
+func (g *Generator) ExportClassClause(c *ast.ExportClassClause) *ast.ExportClassClause {
+	g.ClassExpression(c.ClassExpression)
+
+	return c
+}
+
+func (g *Generator) ExportDefaultClause(c *ast.ExportDefaultClause) *ast.ExportDefaultClause {
+	g.word("default")
+
+	switch c.Declaration.(type) {
+	case *ast.FunctionLiteral, *ast.ClassExpression:
+		g.expression(c.Declaration, pSpread)
+
+		return c
+	}
+
+	if startsWith(c.Declaration, isStatementAmbiguous) {
+		g.rune('(')
+		g.expression(c.Declaration, pLowest)
+		g.rune(')')
+	} else {
+		g.expression(c.Declaration, pSpread)
+	}
+
+	g.semicolon()
+
+	return c
+}
@@ -1,49 +1,159 @@
 package generator
 
-import "yawp/parser/ast"
+import (
+	"yawp/parser/ast"
+	"yawp/parser/token"
+)
 
+// statements prints a list skipping empty statements,
+// these are left behind by erased declarations
+func (g *Generator) statements(list []ast.IStmt) {
+	wasLevel, wasForbidIn := g.level, g.forbidIn
+	g.level, g.forbidIn = pLowest, false
 
-func (g *Generator) Statement(s ast.IStmt) ast.IStmt {
-	s = g.Walker.Statement(s)
+	for _, stmt := range list {
+		if _, ok := stmt.(*ast.EmptyStatement); ok || stmt == nil {
+			continue
+		}
 
-	return s
+		g.Statement(stmt)
+	}
+
+	g.level, g.forbidIn = wasLevel, wasForbidIn
 }
 
-func (g *Generator) BlockStatement(bs *ast.BlockStatement) ast.IStmt {
-	g.rune('{').indentInc().nl()
+func (g *Generator) Body(stmts []ast.IStmt) []ast.IStmt {
+	g.statements(stmts)
 
-	for index, stmt := range bs.List {
-		if index > 0 {
-			g.semicolon()
-		}
+	return stmts
+}
 
-		g.Statement(stmt)
-	}
+func (g *Generator) Statements(stmts ast.Statements) ast.Statements {
+	g.statements(stmts)
+
+	return stmts
+}
 
+func (g *Generator) BlockStatement(bs *ast.BlockStatement) ast.IStmt {
+	g.rune('{')
+	g.statements(bs.List)
 	g.rune('}')
 
 	return bs
 }
 
+func (g *Generator) EmptyStatement(es *ast.EmptyStatement) ast.IStmt {
+	g.semicolon()
+
+	return es
+}
+
 func (g *Generator) ExpressionStatement(stmt *ast.ExpressionStatement) ast.IStmt {
-	g.Expression(stmt.Expression)
+	// {}, function(){} and class{} would be parsed as declarations
+	if startsWith(stmt.Expression, isStatementAmbiguous) {
+		g.rune('(')
+		g.expression(stmt.Expression, pLowest)
+		g.rune(')')
+	} else {
+		g.expression(stmt.Expression, pLowest)
+	}
+
+	g.semicolon()
 
 	return stmt
 }
 
 func (g *Generator) WhileStatement(stmt *ast.WhileStatement) ast.IStmt {
-	g.str("while(")
-	g.Expression(stmt.Test)
-	g.str("){")
+	g.word("while")
+	g.condition(stmt.Test)
+	g.Statement(stmt.Body)
+
+	return stmt
+}
+
+func (g *Generator) DoWhileStatement(stmt *ast.DoWhileStatement) ast.IStmt {
+	g.word("do")
+	g.Statement(stmt.Body)
+	g.word("while")
+	g.condition(stmt.Test)
+	g.semicolon()
+
+	return stmt
+}
+
+func (g *Generator) condition(exp ast.IExpr) {
+	g.rune('(')
+	g.expression(exp, pLowest)
+	g.rune(')')
+}
+
+func (g *Generator) forHead(stmt ast.IStmt) {
+	switch s := stmt.(type) {
+	case *ast.VariableStatement:
+		g.variableStatement(s)
+	case *ast.ExpressionStatement:
+		g.expression(s.Expression, pLowest)
+	}
+}
+
+func (g *Generator) ForStatement(stmt *ast.ForStatement) ast.IStmt {
+	g.word("for")
+	g.rune('(')
+
+	g.forbidIn = true
+	g.forHead(stmt.Initializer)
+	g.forbidIn = false
+
+	g.semicolon()
+
+	if stmt.Test != nil {
+		g.expression(stmt.Test, pLowest)
+	}
+
+	g.semicolon()
+
+	if stmt.Update != nil {
+		g.expression(stmt.Update, pLowest)
+	}
+
+	g.rune(')')
+	g.Statement(stmt.Body)
+
+	return stmt
+}
+
+func (g *Generator) ForInStatement(stmt *ast.ForInStatement) ast.IStmt {
+	g.word("for")
+	g.rune('(')
+	g.forHead(stmt.Left)
+	g.word("in")
+	g.expression(stmt.Right, pLowest)
+	g.rune(')')
+	g.Statement(stmt.Body)
+
+	return stmt
+}
+
+func (g *Generator) ForOfStatement(stmt *ast.ForOfStatement) ast.IStmt {
+	g.word("for")
+
+	if stmt.Await {
+		g.word("await")
+	}
+
+	g.rune('(')
+	g.forHead(stmt.Left)
+	g.word("of")
+	g.expression(stmt.Right, pSpread)
+	g.rune(')')
 	g.Statement(stmt.Body)
-	g.rune('}')
 
 	return stmt
 }
 
 func (g *Generator) DebuggerStatement(ds *ast.DebuggerStatement) ast.IStmt {
 	if !g.options.Minify {
-		g.str("debugger")
+		g.word("debugger")
 		g.semicolon()
 	}
 
@@ -51,24 +161,166 @@ func (g *Generator) DebuggerStatement(ds *ast.DebuggerStatement) ast.IStmt {
 }
 
 func (g *Generator) IfStatement(stmt *ast.IfStatement) ast.IStmt {
-	g.str("if(")
-	g.Expression(stmt.Test)
-	g.str("){")
-	g.Statement(stmt.Consequent)
-	g.rune('}')
+	g.word("if")
+	g.condition(stmt.Test)
+
+	// else would be bound to the nested if
+	if inner, ok := stmt.Consequent.(*ast.IfStatement); ok && inner.Alternate == nil && stmt.Alternate != nil {
+		g.rune('{')
+		g.Statement(stmt.Consequent)
+		g.rune('}')
+	} else {
+		g.Statement(stmt.Consequent)
+	}
 
 	if stmt.Alternate != nil {
-		g.str("else{")
+		g.word("else")
 		g.Statement(stmt.Alternate)
-		g.rune('}')
 	}
 
 	return stmt
 }
 
 func (g *Generator) ReturnStatement(rs *ast.ReturnStatement) ast.IStmt {
-	g.str("return ")
-	g.Expression(rs.Argument)
+	g.word("return")
+
+	if rs.Argument != nil {
+		g.expression(rs.Argument, pLowest)
+	}
+
+	g.semicolon()
 
 	return rs
 }
+
+func (g *Generator) ThrowStatement(ts *ast.ThrowStatement) ast.IStmt {
+	g.word("throw")
+	g.expression(ts.Argument, pLowest)
+	g.semicolon()
+
+	return ts
+}
+
+func (g *Generator) BranchStatement(bs *ast.BranchStatement) ast.IStmt {
+	if bs.Token == token.BREAK {
+		g.word("break")
+	} else {
+		g.word("continue")
+	}
+
+	if bs.Label != nil {
+		g.word(bs.Label.Name)
+	}
+
+	g.semicolon()
+
+	return bs
+}
+
+func (g *Generator) LabelledStatement(ls *ast.LabelledStatement) ast.IStmt {
+	g.word(ls.Label.Name)
+	g.rune(':')
+	g.Statement(ls.Statement)
+
+	return ls
+}
+
+func (g *Generator) SwitchStatement(ss *ast.SwitchStatement) ast.IStmt {
+	g.word("switch")
+	g.condition(ss.Discriminant)
+	g.rune('{')
+
+	for _, c := range ss.Body {
+		g.Statement(c)
+	}
+
+	g.rune('}')
+
+	return ss
+}
+
+func (g *Generator) CaseStatement(cs *ast.CaseStatement) ast.IStmt {
+	if cs.Test == nil {
+		g.word("default")
+	} else {
+		g.word("case")
+		g.expression(cs.Test, pLowest)
+	}
+
+	g.rune(':')
+	g.statements(cs.Consequent)
+
+	return cs
+}
+
+func (g *Generator) TryStatement(ts *ast.TryStatement) ast.IStmt {
+	g.word("try")
+	g.Statement(ts.Body)
+
+	if ts.Catch != nil {
+		g.Statement(ts.Catch)
+	}
+
+	if ts.Finally != nil {
+		g.word("finally")
+		g.Statement(ts.Finally)
+	}
+
+	return ts
+}
+
+func (g *Generator) CatchStatement(cs *ast.CatchStatement) ast.IStmt {
+	g.word("catch")
+
+	if cs.Parameter != nil {
+		g.rune('(')
+		g.PatternBinder(cs.Parameter)
+		g.rune(')')
+	}
+
+	g.Statement(cs.Body)
+
+	return cs
+}
+
+func (g *Generator) WithStatement(ws *ast.WithStatement) ast.IStmt {
+	g.word("with")
+	g.condition(ws.Object)
+	g.Statement(ws.Body)
+
+	return ws
+}
+
+// declarations without runtime semantics are not printed
+
+func (g *Generator) FlowTypeStatement(s *ast.FlowTypeStatement) *ast.FlowTypeStatement {
+	return s
+}
+
+func (g *Generator) FlowInterfaceStatement(s *ast.FlowInterfaceStatement) *ast.FlowInterfaceStatement {
+	return s
+}
+
+func (g *Generator) FlowDeclareStatement(s *ast.FlowDeclareStatement) *ast.FlowDeclareStatement {
+	return s
+}
+
+func (g *Generator) FlowDeclareClassStatement(s *ast.FlowDeclareClassStatement) *ast.FlowDeclareClassStatement {
+	return s
+}
+
+func (g *Generator) FlowDeclareExportStatement(s *ast.FlowDeclareExportStatement) *ast.FlowDeclareExportStatement {
+	return s
+}
+
+func (g *Generator) FlowDeclareModuleStatement(s *ast.FlowDeclareModuleStatement) *ast.FlowDeclareModuleStatement {
+	return s
+}
+
+func (g *Generator) FlowDeclareModuleExportsStatement(s *ast.FlowDeclareModuleExportsStatement) *ast.FlowDeclareModuleExportsStatement {
+	return s
+}
+
+func (g *Generator) TSDeclareStatement(s *ast.TSDeclareStatement) *ast.TSDeclareStatement {
+	return s
+}
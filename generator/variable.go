@@ -4,30 +4,40 @@ import (
 	"yawp/parser/ast"
 )
 
+// VariableBinding prints destructuring assignment, declarations are printed by variableStatement
 func (g *Generator) VariableBinding(b *ast.VariableBinding) *ast.VariableBinding {
+	defer g.wrap(pAssign)()
+
+	return g.binding(b)
+}
+
+func (g *Generator) binding(b *ast.VariableBinding) *ast.VariableBinding {
 	g.PatternBinder(b.Binder)
 
 	if b.Initializer != nil {
 		g.rune('=')
-		g.Expression(b.Initializer)
+		g.expression(b.Initializer, pSpread)
 	}
 
 	return b
 }
 
 func (g *Generator) VariableStatement(stmt *ast.VariableStatement) ast.IStmt {
-	g.str(stmt.Kind.String())
-	g.rune(' ')
+	g.variableStatement(stmt)
+	g.semicolon()
+
+	return stmt
+}
+
+// variableStatement prints declaration without semicolon, so it fits for heads
+func (g *Generator) variableStatement(stmt *ast.VariableStatement) {
+	g.word(stmt.Kind.String())
 
 	for index, binding := range stmt.List {
 		if index > 0 {
 			g.rune(',')
 		}
 
-		g.VariableBinding(binding)
+		g.binding(binding)
 	}
-
-	g.semicolon()
-
-	return stmt
 }
@@ -0,0 +1,269 @@
+package options
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// browserFeatures are the first versions of the browsers supporting the features,
+// those without the version are not supported by any, as all the features of IE
+var browserFeatures = map[string][featuresCount]string{
+	"ie": {},
+	"edge": {
+		ArrowFunctions: "13", BlockScoping: "14", Classes: "13", Destructuring: "15", ForOf: "15",
+		Generators: "13", ObjectExtensions: "12", Parameters: "18", Spread: "13", Templates: "13",
+		BinaryOctalLiterals: "12", StickyRegExp: "13", UnicodeRegExp: "13", NewTarget: "14", Exponentiation: "14",
+		AsyncFunctions: "15", AsyncGenerators: "79", ObjectRestSpread: "79", DotAllRegExp: "79", NamedGroupsRegExp: "79",
+		LookbehindRegExp: "79", UnicodePropertyRegExp: "79", OptionalCatchBinding: "79", OptionalChaining: "80", NullishCoalescing: "80",
+		BigInt: "79", DynamicImport: "79", NumericSeparators: "79", ClassFields: "84", RegExpMatchIndices: "90",
+	},
+	"chrome": {
+		ArrowFunctions: "47", BlockScoping: "50", Classes: "46", Destructuring: "51", ForOf: "51",
+		Generators: "50", ObjectExtensions: "44", Parameters: "49", Spread: "46", Templates: "41",
+		BinaryOctalLiterals: "41", StickyRegExp: "49", UnicodeRegExp: "50", NewTarget: "46", Exponentiation: "52",
+		AsyncFunctions: "55", AsyncGenerators: "63", ObjectRestSpread: "60", DotAllRegExp: "62", NamedGroupsRegExp: "64",
+		LookbehindRegExp: "62", UnicodePropertyRegExp: "64", OptionalCatchBinding: "66", OptionalChaining: "80", NullishCoalescing: "80",
+		BigInt: "67", DynamicImport: "63", NumericSeparators: "75", ClassFields: "84", RegExpMatchIndices: "90",
+	},
+	"firefox": {
+		ArrowFunctions: "43", BlockScoping: "53", Classes: "45", Destructuring: "53", ForOf: "53",
+		Generators: "53", ObjectExtensions: "34", Parameters: "53", Spread: "45", Templates: "34",
+		BinaryOctalLiterals: "25", StickyRegExp: "3", UnicodeRegExp: "46", NewTarget: "41", Exponentiation: "52",
+		AsyncFunctions: "52", AsyncGenerators: "57", ObjectRestSpread: "55", DotAllRegExp: "78", NamedGroupsRegExp: "78",
+		LookbehindRegExp: "78", UnicodePropertyRegExp: "78", OptionalCatchBinding: "58", OptionalChaining: "74", NullishCoalescing: "72",
+		BigInt: "68", DynamicImport: "67", NumericSeparators: "70", ClassFields: "90", RegExpMatchIndices: "88",
+	},
+	// tagged templates are cached per call site since 13, parameters are scoped right since 16.3
+	"safari": {
+		ArrowFunctions: "10", BlockScoping: "11", Classes: "10", Destructuring: "10", ForOf: "10",
+		Generators: "10", ObjectExtensions: "9", Parameters: "16.3", Spread: "10", Templates: "13",
+		BinaryOctalLiterals: "9", StickyRegExp: "10", UnicodeRegExp: "12", NewTarget: "10", Exponentiation: "10.1",
+		AsyncFunctions: "11", AsyncGenerators: "12", ObjectRestSpread: "11.1", DotAllRegExp: "11.1", NamedGroupsRegExp: "11.1",
+		LookbehindRegExp: "16.4", UnicodePropertyRegExp: "11.1", OptionalCatchBinding: "11.1", OptionalChaining: "13.1", NullishCoalescing: "13.1",
+		BigInt: "14", DynamicImport: "11.1", NumericSeparators: "13", ClassFields: "15", RegExpMatchIndices: "15",
+	},
+	"ios_saf": {
+		ArrowFunctions: "10", BlockScoping: "11", Classes: "10", Destructuring: "10", ForOf: "10",
+		Generators: "10", ObjectExtensions: "9", Parameters: "16.3", Spread: "10", Templates: "13",
+		BinaryOctalLiterals: "9", StickyRegExp: "10", UnicodeRegExp: "12", NewTarget: "10", Exponentiation: "10.3",
+		AsyncFunctions: "11", AsyncGenerators: "12", ObjectRestSpread: "11.3", DotAllRegExp: "11.3", NamedGroupsRegExp: "11.3",
+		LookbehindRegExp: "16.4", UnicodePropertyRegExp: "11.3", OptionalCatchBinding: "11.3", OptionalChaining: "13.4", NullishCoalescing: "13.4",
+		BigInt: "14", DynamicImport: "11.3", NumericSeparators: "13", ClassFields: "15", RegExpMatchIndices: "15",
+	},
+	"opera": {
+		ArrowFunctions: "34", BlockScoping: "37", Classes: "33", Destructuring: "38", ForOf: "38",
+		Generators: "37", ObjectExtensions: "31", Parameters: "36", Spread: "33", Templates: "28",
+		BinaryOctalLiterals: "28", StickyRegExp: "36", UnicodeRegExp: "37", NewTarget: "33", Exponentiation: "39",
+		AsyncFunctions: "42", AsyncGenerators: "50", ObjectRestSpread: "47", DotAllRegExp: "49", NamedGroupsRegExp: "51",
+		LookbehindRegExp: "49", UnicodePropertyRegExp: "51", OptionalCatchBinding: "53", OptionalChaining: "67", NullishCoalescing: "67",
+		BigInt: "54", DynamicImport: "50", NumericSeparators: "62", ClassFields: "70", RegExpMatchIndices: "76",
+	},
+	"samsung": {
+		ArrowFunctions: "5", BlockScoping: "5", Classes: "5", Destructuring: "5", ForOf: "5",
+		Generators: "5", ObjectExtensions: "4", Parameters: "5", Spread: "5", Templates: "3.4",
+		BinaryOctalLiterals: "3.4", StickyRegExp: "5", UnicodeRegExp: "5", NewTarget: "5", Exponentiation: "6",
+		AsyncFunctions: "6", AsyncGenerators: "8", ObjectRestSpread: "8", DotAllRegExp: "8", NamedGroupsRegExp: "9",
+		LookbehindRegExp: "8", UnicodePropertyRegExp: "9", OptionalCatchBinding: "9", OptionalChaining: "13", NullishCoalescing: "13",
+		BigInt: "9", DynamicImport: "8", NumericSeparators: "11", ClassFields: "14", RegExpMatchIndices: "15",
+	},
+	"node": {
+		ArrowFunctions: "6", BlockScoping: "6", Classes: "5", Destructuring: "6.5", ForOf: "6.5",
+		Generators: "6", ObjectExtensions: "4", Parameters: "6", Spread: "5", Templates: "4",
+		BinaryOctalLiterals: "4", StickyRegExp: "6", UnicodeRegExp: "6", NewTarget: "5", Exponentiation: "7",
+		AsyncFunctions: "7.6", AsyncGenerators: "10", ObjectRestSpread: "8.3", DotAllRegExp: "8.10", NamedGroupsRegExp: "10",
+		LookbehindRegExp: "8.10", UnicodePropertyRegExp: "10", OptionalCatchBinding: "10", OptionalChaining: "14", NullishCoalescing: "14",
+		BigInt: "10.4", DynamicImport: "13.2", NumericSeparators: "12.5", ClassFields: "14.6", RegExpMatchIndices: "16",
+	},
+}
+
+var browserAliases = map[string]string{
+	"explorer":        "ie",
+	"ff":              "firefox",
+	"ios":             "ios_saf",
+	"and_chr":         "chrome",
+	"chromeandroid":   "chrome",
+	"and_ff":          "firefox",
+	"firefoxandroid":  "firefox",
+	"samsunginternet": "samsung",
+}
+
+// browser is the browser version selected by the query, newer versions are selected as well
+// when orNewer is set, the query `chrome >= 80` selects those
+type browser struct {
+	name    string
+	version []int
+	orNewer bool
+}
+
+// QueryFeatures resolves features supported by all the browsers of browserslist-like query,
+// queries are separated by commas or `or`, those starting with `not` exclude the browsers:
+//
+// chrome 80, safari >= 13.1, firefox esr, node 14, not ie 11
+//
+// Usage statistics and release dates are not known, so the queries like `defaults`,
+// `> 0.5%` and `last 2 versions` are reported as unsupported
+func QueryFeatures(query string) (Features, error) {
+	var selected []browser
+
+	for _, part := range splitQuery(query) {
+		exclude := false
+
+		if strings.HasPrefix(part, "not ") {
+			exclude = true
+			part = strings.TrimSpace(strings.TrimPrefix(part, "not "))
+		}
+
+		b, err := parseBrowser(part)
+
+		if err != nil {
+			return 0, err
+		}
+
+		if !exclude {
+			selected = append(selected, b)
+
+			continue
+		}
+
+		kept := selected[:0]
+
+		for _, s := range selected {
+			if s.name != b.name || compareVersions(s.version, b.version) < 0 || !b.orNewer && compareVersions(s.version, b.version) > 0 {
+				kept = append(kept, s)
+			}
+		}
+
+		selected = kept
+	}
+
+	if len(selected) == 0 {
+		return 0, fmt.Errorf("browsers query %q selects no browsers", query)
+	}
+
+	features := Features(1<<uint(featuresCount) - 1)
+
+	for _, b := range selected {
+		for feature, since := range browserFeatures[b.name] {
+			if since == "" || compareVersions(b.version, parseVersion(since)) < 0 {
+				features &^= 1 << uint(feature)
+			}
+		}
+	}
+
+	return features, nil
+}
+
+func splitQuery(query string) []string {
+	var parts []string
+
+	for _, part := range strings.Split(strings.ToLower(query), ",") {
+		for _, p := range strings.Split(part, " or ") {
+			if p = strings.TrimSpace(p); p != "" {
+				parts = append(parts, p)
+			}
+		}
+	}
+
+	return parts
+}
+
+func parseBrowser(query string) (browser, error) {
+	fields := strings.Fields(query)
+
+	if len(fields) < 2 || len(fields) > 3 {
+		return browser{}, fmt.Errorf("unsupported browsers query %q", query)
+	}
+
+	name := fields[0]
+
+	if alias, ok := browserAliases[name]; ok {
+		name = alias
+	}
+
+	if _, ok := browserFeatures[name]; !ok {
+		return browser{}, fmt.Errorf("unsupported browsers query %q", query)
+	}
+
+	b := browser{name: name}
+	version := fields[1]
+
+	switch {
+	case len(fields) == 3 && fields[1] == ">=":
+		b.orNewer = true
+		version = fields[2]
+	case len(fields) == 3:
+		return browser{}, fmt.Errorf("unsupported browsers query %q", query)
+	case strings.HasPrefix(version, ">="):
+		b.orNewer = true
+		version = version[2:]
+	case name == "firefox" && version == "esr":
+		// Firefox ESR releases supported still are newer than the table,
+		// those support all the features the newest version of the table does
+		b.version = newestVersion(name)
+
+		return b, nil
+	}
+
+	// ranges like ios_saf 12.2-12.5 are checked by the first version
+	version = strings.SplitN(version, "-", 2)[0]
+
+	if b.version = parseVersion(version); b.version == nil {
+		return browser{}, fmt.Errorf("invalid version %q of browser %q", version, fields[0])
+	}
+
+	return b, nil
+}
+
+// newestVersion is the last version of the browser supporting a feature of the table
+func newestVersion(name string) []int {
+	var newest []int
+
+	for _, since := range browserFeatures[name] {
+		if version := parseVersion(since); since != "" && compareVersions(version, newest) > 0 {
+			newest = version
+		}
+	}
+
+	return newest
+}
+
+// parseVersion splits the version to numbers, nil is returned for invalid ones
+func parseVersion(version string) []int {
+	var numbers []int
+
+	for _, part := range strings.Split(version, ".") {
+		number, err := strconv.Atoi(part)
+
+		if err != nil || number < 0 {
+			return nil
+		}
+
+		numbers = append(numbers, number)
+	}
+
+	return numbers
+}
+
+func compareVersions(a []int, b []int) int {
+	for index := 0; index < len(a) || index < len(b); index++ {
+		var x, y int
+
+		if index < len(a) {
+			x = a[index]
+		}
+
+		if index < len(b) {
+			y = b[index]
+		}
+
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+
+	return 0
+}
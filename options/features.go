@@ -0,0 +1,160 @@
+package options
+
+// Feature is the syntax the targets may not support, the transpiler lowers most of it,
+// the rest is reported by the compliance check, see Options.CheckCompliance
+type Feature int
+
+const (
+	// ES2015
+	ArrowFunctions Feature = iota
+	BlockScoping
+	Classes
+	Destructuring
+	ForOf
+	Generators
+	ObjectExtensions // shorthand properties, methods, computed keys and __proto__
+	Parameters       // default values and rest
+	Spread
+	Templates
+	BinaryOctalLiterals
+	StickyRegExp
+	UnicodeRegExp
+	NewTarget
+
+	// ES2016
+	Exponentiation
+
+	// ES2017
+	AsyncFunctions
+
+	// ES2018
+	AsyncGenerators // and for await
+	ObjectRestSpread
+	DotAllRegExp
+	NamedGroupsRegExp
+	LookbehindRegExp
+	UnicodePropertyRegExp
+
+	// ES2019
+	OptionalCatchBinding
+
+	// ES2020
+	OptionalChaining
+	NullishCoalescing
+	BigInt
+	DynamicImport
+
+	// ES2021
+	NumericSeparators
+
+	// ES2022
+	ClassFields // private methods and accessors as well
+	RegExpMatchIndices
+
+	featuresCount
+)
+
+// featureTargets are the ES versions the features come with
+var featureTargets = [featuresCount]Target{
+	ArrowFunctions:        ES2015,
+	BlockScoping:          ES2015,
+	Classes:               ES2015,
+	Destructuring:         ES2015,
+	ForOf:                 ES2015,
+	Generators:            ES2015,
+	ObjectExtensions:      ES2015,
+	Parameters:            ES2015,
+	Spread:                ES2015,
+	Templates:             ES2015,
+	BinaryOctalLiterals:   ES2015,
+	StickyRegExp:          ES2015,
+	UnicodeRegExp:         ES2015,
+	NewTarget:             ES2015,
+	Exponentiation:        ES2016,
+	AsyncFunctions:        ES2017,
+	AsyncGenerators:       ES2018,
+	ObjectRestSpread:      ES2018,
+	DotAllRegExp:          ES2018,
+	NamedGroupsRegExp:     ES2018,
+	LookbehindRegExp:      ES2018,
+	UnicodePropertyRegExp: ES2018,
+	OptionalCatchBinding:  ES2019,
+	OptionalChaining:      ES2020,
+	NullishCoalescing:     ES2020,
+	BigInt:                ES2020,
+	DynamicImport:         ES2020,
+	NumericSeparators:     ES2021,
+	ClassFields:           ES2022,
+	RegExpMatchIndices:    ES2022,
+}
+
+var featureNames = [featuresCount]string{
+	ArrowFunctions:        "arrow function",
+	BlockScoping:          "let and const",
+	Classes:               "class",
+	Destructuring:         "destructuring",
+	ForOf:                 "for of loop",
+	Generators:            "generator function",
+	ObjectExtensions:      "object literal extension",
+	Parameters:            "default or rest parameter",
+	Spread:                "spread",
+	Templates:             "template literal",
+	BinaryOctalLiterals:   "binary or octal literal",
+	StickyRegExp:          "RegExp sticky flag",
+	UnicodeRegExp:         "RegExp unicode flag",
+	NewTarget:             "new.target",
+	Exponentiation:        "exponentiation",
+	AsyncFunctions:        "async function",
+	AsyncGenerators:       "async generator",
+	ObjectRestSpread:      "object rest or spread",
+	DotAllRegExp:          "RegExp dotAll flag",
+	NamedGroupsRegExp:     "RegExp named group",
+	LookbehindRegExp:      "RegExp lookbehind assertion",
+	UnicodePropertyRegExp: "RegExp unicode property escape",
+	OptionalCatchBinding:  "optional catch binding",
+	OptionalChaining:      "optional chaining",
+	NullishCoalescing:     "nullish coalescing",
+	BigInt:                "BigInt literal",
+	DynamicImport:         "dynamic import",
+	NumericSeparators:     "numeric separator",
+	ClassFields:           "class field",
+	RegExpMatchIndices:    "RegExp indices flag",
+}
+
+func (f Feature) String() string {
+	return featureNames[f]
+}
+
+// Features is the set of features the target supports
+type Features uint64
+
+func (f Features) Has(feature Feature) bool {
+	return f&(1<<uint(feature)) != 0
+}
+
+func (f Features) With(feature Feature) Features {
+	return f | 1<<uint(feature)
+}
+
+// Features returns the features of the ES version
+func (target Target) Features() Features {
+	var features Features
+
+	for feature, since := range featureTargets {
+		if target >= since {
+			features = features.With(Feature(feature))
+		}
+	}
+
+	return features
+}
+
+// Features resolves the features the transpiled code may use, those are the ones
+// supported by all the browsers of the query or by the target when there is no query
+func (o *Options) Features() (Features, error) {
+	if o.Browsers == "" {
+		return o.Target.Features(), nil
+	}
+
+	return QueryFeatures(o.Browsers)
+}
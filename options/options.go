@@ -10,9 +10,69 @@ const (
 	ES2018
 	ES2019
 	ES2020
+	ES2021
+	ES2022
+)
+
+// JSXRuntime is the way JSX elements are created
+type JSXRuntime int
+
+const (
+	// JSXClassic calls the pragma, React.createElement(type, props, ...children)
+	JSXClassic JSXRuntime = iota
+
+	// JSXAutomatic calls jsx(type, props, key) imported from the runtime of the import source,
+	// react/jsx-runtime, children are passed in props
+	JSXAutomatic
 )
 
 type Options struct {
 	Target Target
 	Minify bool
+
+	// Browsers is the browserslist-like query of the supported browsers, e.g. "chrome 80, safari 13",
+	// the syntax they support is not lowered, Target is ignored when it is set, see QueryFeatures.
+	// Browsers are selected by the versions only, the queries by usage or releases like `defaults`,
+	// `> 1%` and `last 2 versions` are not supported, those are reported as errors
+	Browsers string
+
+	// LegacyDecorators lowers decorators following Babel decorators-legacy mode
+	// instead of the 2022-03 proposal
+	LegacyDecorators bool
+
+	// AssumeArrays lowers for of loops, spread and array destructuring for ES5
+	// to indexing and concat of arrays instead of the iterator protocol
+	AssumeArrays bool
+
+	// SetPublicClassFields lowers public class fields to assignments instead of defining them,
+	// those call setters of the same name and fail on read only properties, e.g. static name
+	SetPublicClassFields bool
+
+	// JSX is the runtime JSX elements are created by, JSXPragma and JSXPragmaFrag are the function
+	// and the fragment of the classic one, React.createElement and React.Fragment when empty,
+	// JSXImportSource is the module of the automatic one, react when empty
+	JSX             JSXRuntime
+	JSXPragma       string
+	JSXPragmaFrag   string
+	JSXImportSource string
+
+	// JSXDevelopment passes the location of elements and this to the runtime for the dev tools,
+	// jsxDEV of jsx-dev-runtime is called by the automatic one, __source and __self props are added otherwise
+	JSXDevelopment bool
+
+	// CheckCompliance warns about the syntax the target does not support and the transpiler
+	// leaves as is, e.g. dynamic import or lookbehind of RegExp for ES5
+	CheckCompliance bool
+
+	// FlowChecks asserts the Flow annotations of function parameters and returned values
+	// at runtime for development builds, TypeError tells the annotation violated
+	FlowChecks bool
+
+	// TDZ throws ReferenceError for let and const lowered to var for ES5
+	// when those are used before the declaration
+	TDZ bool
+
+	// Runtime is the module the runtime helpers are imported from, e.g. yawp/runtime,
+	// helpers are declared by every module calling them when it is empty, see builtins.Runtime
+	Runtime string
 }
@@ -5,12 +5,29 @@ import (
 	"yawp/parser/token"
 )
 
+// parseExponentiationExpression parses right associative **, it binds tighter than multiplication
+func (p *Parser) parseExponentiationExpression() ast.IExpr {
+	left := p.parseUnaryExpression()
+
+	if p.is(token.EXPONENTIATION) {
+		p.next()
+
+		return &ast.BinaryExpression{
+			Operator: token.EXPONENTIATION,
+			Left:     left,
+			Right:    p.parseExponentiationExpression(),
+		}
+	}
+
+	return left
+}
+
 func (p *Parser) parseMultiplicativeExpression() ast.IExpr {
-	next := p.parseUnaryExpression
+	next := p.parseExponentiationExpression
 	left := next()
 
 	for p.is(token.MULTIPLY) || p.is(token.SLASH) ||
-		p.is(token.REMAINDER) || p.is(token.EXPONENTIATION) {
+		p.is(token.REMAINDER) {
 		tkn := p.token
 		p.next()
 		left = &ast.BinaryExpression{
@@ -80,12 +80,13 @@ func (p *Parser) parseArrowFunctionOrSequenceExpression(async bool) ast.IExpr {
 	// First try to parse as arrow function parameters list
 	parameters := p.maybeParseArrowFunctionParameterList()
 	var returnType ast.FlowType
+	var predicate *ast.FlowPredicate
 
 	// may be also arrow fn return type or type assertion
 	if parameters != nil && p.is(token.COLON) {
 		wasForbidden := p.forbidUnparenthesizedFunctionType
 		p.forbidUnparenthesizedFunctionType = true
-		returnType = p.parseFlowTypeAnnotation()
+		returnType, predicate = p.parseFlowReturnType()
 		p.forbidUnparenthesizedFunctionType = wasForbidden
 	}
 
@@ -98,6 +99,7 @@ func (p *Parser) parseArrowFunctionOrSequenceExpression(async bool) ast.IExpr {
 			ExprNode:   p.exprNodeAt(parameters.Loc),
 			Async:      async,
 			ReturnType: returnType,
+			Predicate:  predicate,
 			Parameters: parameters.List,
 			Body:       p.parseArrowFunctionBody(async),
 		}
@@ -153,13 +155,14 @@ func (p *Parser) tryParseAsyncArrowFunction(loc *file.Loc, st *ParserSnapshot) a
 
 	if p.is(token.LEFT_PARENTHESIS) {
 		var returnType ast.FlowType
+		var predicate *ast.FlowPredicate
 
 		sloc := p.loc()
 		parameters := p.parseFunctionParameterList()
 
 		if p.is(token.COLON) {
 			p.forbidUnparenthesizedFunctionType = true
-			returnType = p.parseFlowTypeAnnotation()
+			returnType, predicate = p.parseFlowReturnType()
 			p.forbidUnparenthesizedFunctionType = false
 		}
 
@@ -176,6 +179,7 @@ func (p *Parser) tryParseAsyncArrowFunction(loc *file.Loc, st *ParserSnapshot) a
 			Async:          true,
 			TypeParameters: typeParameters,
 			ReturnType:     returnType,
+			Predicate:      predicate,
 			Parameters:     parameters.List,
 			Body:           p.parseArrowFunctionBody(true),
 		}
@@ -192,11 +196,12 @@ func (p *Parser) parseParametrizedArrowFunction() *ast.ArrowFunctionExpression {
 
 	if p.is(token.LEFT_PARENTHESIS) {
 		var returnType ast.FlowType
+		var predicate *ast.FlowPredicate
 		parameters := p.parseFunctionParameterList()
 
 		if p.is(token.COLON) {
 			p.forbidUnparenthesizedFunctionType = true
-			returnType = p.parseFlowTypeAnnotation()
+			returnType, predicate = p.parseFlowReturnType()
 			p.forbidUnparenthesizedFunctionType = false
 		}
 
@@ -205,6 +210,7 @@ func (p *Parser) parseParametrizedArrowFunction() *ast.ArrowFunctionExpression {
 			Async:          false,
 			TypeParameters: typeParameters,
 			ReturnType:     returnType,
+			Predicate:      predicate,
 			Parameters:     parameters.List,
 			Body:           p.parseArrowFunctionBody(false),
 		}
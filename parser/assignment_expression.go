@@ -47,7 +47,7 @@ func (p *Parser) parseAssignmentExpression() ast.IExpr {
 		switch l := left.(type) {
 		case *ast.Identifier:
 			p.symbol(l, ast.SWrite.Add(l.Symbol.Flags), ast.SRUnknown)
-		case *ast.MemberExpression:
+		case *ast.MemberExpression, *ast.TSTypeAssertionExpression:
 			// these are the only valid types of left expression in this context
 			// pattern binding assignments are handled outside by
 			// parseArrayPatternAssignmentOrLiteral
@@ -1,5 +1,12 @@
 package ast
 
+type ExportKind int
+
+const (
+	EKValue ExportKind = iota
+	EKType
+)
+
 type (
 	ExportClause interface {
 		_exportClauseNode()
@@ -97,7 +97,6 @@ type (
 
 	ThisExpression struct {
 		ExprNode
-		Private bool
 	}
 
 	UnaryExpression struct {
@@ -111,6 +110,7 @@ type (
 		ExprNode
 		TypeParameters []*FlowTypeParameter
 		ReturnType     FlowType
+		Predicate      *FlowPredicate
 		Parameters     []FunctionParameter
 		Body           *FunctionBody
 		Async          bool
@@ -168,7 +168,7 @@ type (
 
 	TemplateExpression struct {
 		ExprNode
-		Strings       []string
+		Strings       []string // raw source of the parts, escapes are not processed
 		Substitutions []IExpr
 	}
 
@@ -186,10 +186,12 @@ type (
 
 	ClassExpression struct {
 		ExprNode
+		Decorators         []IExpr
 		Name               *Identifier
 		TypeParameters     []*FlowTypeParameter
 		SuperClass         IExpr
 		SuperTypeArguments []FlowType
+		Implements         []FlowType
 		Body               IStmt
 	}
 
@@ -30,9 +30,10 @@ type (
 
 	FlowInterfaceMethod struct {
 		Loc            *file.Loc
+		Static         bool
 		Name           *FlowIdentifier
 		TypeParameters []*FlowTypeParameter
-		Parameters     []interface{}
+		Parameters     []*FlowFunctionParameter
 		ReturnType     FlowType
 	}
 
@@ -59,6 +60,7 @@ type (
 
 	FlowNamedObjectProperty struct {
 		Loc           *file.Loc
+		Static        bool
 		Optional      bool
 		Covariant     bool
 		Contravariant bool
@@ -111,6 +113,14 @@ type (
 	FlowFunctionParameter struct {
 		Identifier *Identifier
 		Type       FlowType
+		Optional   bool
+		Rest       bool
+	}
+
+	// %checks or %checks(exp) of declared functions
+	FlowPredicate struct {
+		Loc        *file.Loc
+		Expression IExpr
 	}
 
 	FlowGenericType struct {
@@ -156,6 +166,14 @@ type (
 		Loc         *file.Loc
 		ElementType FlowType
 	}
+
+	// Obj['key'], Obj?.['key']
+	FlowIndexedAccessType struct {
+		Loc        *file.Loc
+		ObjectType FlowType
+		IndexType  FlowType
+		Optional   bool
+	}
 )
 
 func (*FlowPrimitiveType) _flowType()     {}
@@ -175,6 +193,7 @@ func (*FlowUnionType) _flowType()         {}
 func (*FlowIntersectionType) _flowType()  {}
 func (*FlowGenericType) _flowType()       {}
 func (*FlowArrayType) _flowType()         {}
+func (*FlowIndexedAccessType) _flowType() {}
 
 func (*FlowNamedObjectProperty) _flowObjectProperty()      {}
 func (*FlowIndexerObjectProperty) _flowObjectProperty()    {}
@@ -184,9 +203,11 @@ func (*FlowSpreadObjectProperty) _flowObjectProperty()     {}
 func (*FlowNamedObjectProperty) _flowInterfaceBodyStatement()   {}
 func (*FlowIndexerObjectProperty) _flowInterfaceBodyStatement() {}
 func (*FlowInterfaceMethod) _flowInterfaceBodyStatement()       {}
+func (*FlowInterfaceMethod) _flowObjectProperty()               {}
 
 func (*FlowTypeStatement) _exportClauseNode()      {}
 func (*FlowInterfaceStatement) _exportClauseNode() {}
+func (*FlowEnumStatement) _exportClauseNode()      {}
 
 func (f *FlowPrimitiveType) GetLoc() *file.Loc     { return f.Loc }
 func (f *FlowTrueType) GetLoc() *file.Loc          { return f.Loc }
@@ -202,6 +223,7 @@ func (f *FlowTupleType) GetLoc() *file.Loc         { return f.Loc }
 func (f *FlowArrayType) GetLoc() *file.Loc         { return f.Loc }
 func (f *FlowExistentialType) GetLoc() *file.Loc   { return f.Loc }
 func (f *FlowFunctionType) GetLoc() *file.Loc      { return f.Loc }
+func (f *FlowIndexedAccessType) GetLoc() *file.Loc { return f.Loc }
 
 func (f *FlowUnionType) GetLoc() *file.Loc {
 	return f.Loc.Add(f.Types[len(f.Types)-1].GetLoc())
@@ -240,3 +262,4 @@ func (f *FlowUnionType) GetNode() *Node         { return nil }
 func (f *FlowIntersectionType) GetNode() *Node  { return nil }
 func (f *FlowGenericType) GetNode() *Node       { return nil }
 func (f *FlowArrayType) GetNode() *Node         { return nil }
+func (f *FlowIndexedAccessType) GetNode() *Node { return nil }
@@ -10,6 +10,7 @@ type (
 		Id             *Identifier
 		TypeParameters []*FlowTypeParameter
 		ReturnType     FlowType
+		Predicate      *FlowPredicate
 		Parameters     *FunctionParameters
 		Body           *FunctionBody
 	}
@@ -35,6 +36,7 @@ type (
 	IdentifierParameter struct {
 		Id           *Identifier
 		DefaultValue IExpr
+		Modifiers    Flags
 
 		FlowType         FlowType
 		FlowTypeOptional bool
@@ -5,4 +5,5 @@ type MemberKind int
 const (
 	MKArray = iota
 	MKObject
+	MKPrivate // a.#b
 )
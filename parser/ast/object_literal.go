@@ -18,6 +18,7 @@ type (
 	ObjectPropertyValue struct {
 		PropertyName ObjectPropertyName
 		Value        IExpr
+		Method       bool // concise method, the value is the function literal
 	}
 
 	ObjectPropertyName interface {
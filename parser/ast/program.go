@@ -5,14 +5,29 @@ import (
 	"yawp/parser/file"
 )
 
+// ModuleAdditions are what the transpiled module needs besides its body
 type ModuleAdditions struct {
-	ObjectOmit bool
+	// Helpers are the runtime helpers called by the module in the order of the first call,
+	// ids are named as the helpers are and refer to the refs the module calls those by
+	Helpers []*Identifier
+}
+
+// Pragmas are set by the comments leading the module, e.g. /** @jsx h */,
+// those override the JSX options for the module
+type Pragmas struct {
+	NoFlow          bool // @noflow, the Flow annotations are not checked
+	JSX             string
+	JSXFrag         string
+	JSXImportSource string
+	JSXRuntime      string // classic or automatic
 }
 
 type Module struct {
-	File    *file.File
-	Body    []IStmt
-	Symbols *SymbolsScope
+	File       *file.File
+	Body       []IStmt
+	Symbols    *SymbolsScope
+	TypeScript bool
+	Pragmas    Pragmas
 
 	Ids       *ids.Ids
 	Additions ModuleAdditions
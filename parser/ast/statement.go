@@ -118,6 +118,7 @@ type (
 
 	ForOfStatement struct {
 		StmtNode
+		Await bool
 		Left  IStmt
 		Right IExpr
 		Body  IStmt
@@ -131,12 +132,6 @@ type (
 		Body        IStmt
 	}
 
-	LegacyDecoratorStatement struct {
-		StmtNode
-		Decorators []IExpr
-		Subject    IStmt
-	}
-
 	TryStatement struct {
 		StmtNode
 		Body    IStmt
@@ -157,22 +152,28 @@ type (
 
 	ClassFieldStatement struct {
 		StmtNode
+		Decorators  []IExpr
 		Name        ObjectPropertyName
 		Static      bool
 		Private     bool
+		Accessor    bool // accessor x = 1 defines a getter and setter pair backed by a private field
 		Type        FlowType
 		Initializer IExpr
 	}
 
 	ClassAccessorStatement struct {
 		StmtNode
-		Field ObjectPropertyName
-		Kind  string
-		Body  *FunctionLiteral
+		Decorators []IExpr
+		Field      ObjectPropertyName
+		Static     bool
+		Private    bool
+		Kind       string
+		Body       *FunctionLiteral
 	}
 
 	ClassMethodStatement struct {
 		StmtNode
+		Decorators     []IExpr
 		Name           ObjectPropertyName
 		Static         bool
 		Private        bool
@@ -186,6 +187,7 @@ type (
 
 	ExportStatement struct {
 		StmtNode
+		Kind   ExportKind
 		Clause ExportClause
 	}
 
@@ -203,6 +205,7 @@ type (
 		StmtNode
 		Name           *FlowIdentifier
 		Opaque         bool
+		Supertype      FlowType
 		Type           FlowType
 		TypeParameters []*FlowTypeParameter
 	}
@@ -211,6 +214,61 @@ type (
 		StmtNode
 		Name           *FlowIdentifier
 		TypeParameters []*FlowTypeParameter
+		Extends        []FlowType
 		Body           []FlowInterfaceBodyStatement
 	}
+
+	// declare var, function, class, type, opaque type or interface
+	FlowDeclareStatement struct {
+		StmtNode
+		Declaration IStmt
+	}
+
+	// declare class A extends B mixins C implements D {}
+	FlowDeclareClassStatement struct {
+		StmtNode
+		Name           *FlowIdentifier
+		TypeParameters []*FlowTypeParameter
+		Extends        FlowType
+		Mixins         []FlowType
+		Implements     []FlowType
+		Body           []FlowInterfaceBodyStatement
+	}
+
+	// declare export, only one of Declaration, Type and Clause is set
+	FlowDeclareExportStatement struct {
+		StmtNode
+		Default     bool
+		Declaration IStmt
+		Type        FlowType
+		Clause      ExportClause
+	}
+
+	// declare module 'a' {}, Name includes quotes for string names
+	FlowDeclareModuleStatement struct {
+		StmtNode
+		Name string
+		Body []IStmt
+	}
+
+	// declare module.exports: T
+	FlowDeclareModuleExportsStatement struct {
+		StmtNode
+		Type FlowType
+	}
+
+	FlowEnumMember struct {
+		Loc         *file.Loc
+		Name        string
+		Initializer IExpr
+	}
+
+	// enum E of string { A, B = 'b', ... }, Type is empty when not explicit
+	FlowEnumStatement struct {
+		StmtNode
+		Name              *Identifier
+		Type              string
+		Members           []*FlowEnumMember
+		HasUnknownMembers bool
+	}
 )
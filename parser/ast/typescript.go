@@ -0,0 +1,171 @@
+package ast
+
+import "yawp/parser/file"
+
+// Modifiers of parameter properties
+const (
+	TSPublic Flags = 1 << iota
+	TSPrivate
+	TSProtected
+	TSReadonly
+	TSOverride
+)
+
+type TSAssertionKind int
+
+const (
+	TSAKAs TSAssertionKind = iota
+	TSAKSatisfies
+	TSAKAngleBracket
+	TSAKNonNull
+)
+
+type (
+	// a as T, a satisfies T, <T>a, a!
+	TSTypeAssertionExpression struct {
+		ExprNode
+		Kind       TSAssertionKind
+		Expression IExpr
+		Type       FlowType
+	}
+
+	TSEnumMember struct {
+		Loc         *file.Loc
+		Name        string
+		Initializer IExpr
+	}
+
+	TSEnumStatement struct {
+		StmtNode
+		Name    *Identifier
+		Const   bool
+		Members []*TSEnumMember
+	}
+
+	// namespace A.B {} is represented as two nested namespaces
+	TSNamespaceStatement struct {
+		StmtNode
+		Name *Identifier
+		Body []IStmt
+	}
+
+	// Anything without runtime semantics:
+	// declare ..., overloads, abstract members and index signatures
+	TSDeclareStatement struct {
+		StmtNode
+		Declaration IStmt
+	}
+
+	// import a = require('a'), import a = B.c
+	TSImportEqualsStatement struct {
+		StmtNode
+		Name            *Identifier
+		ModuleReference IExpr
+	}
+
+	// export = a
+	TSExportAssignmentStatement struct {
+		StmtNode
+		Expression IExpr
+	}
+)
+
+type (
+	// keyof T, unique symbol, readonly T[]
+	TSTypeOperator struct {
+		Loc      *file.Loc
+		Operator string
+		Type     FlowType
+	}
+
+	TSConditionalType struct {
+		Loc         *file.Loc
+		CheckType   FlowType
+		ExtendsType FlowType
+		TrueType    FlowType
+		FalseType   FlowType
+	}
+
+	TSInferType struct {
+		Loc           *file.Loc
+		TypeParameter *FlowTypeParameter
+	}
+
+	// a is T, asserts a is T, asserts a
+	TSTypePredicate struct {
+		Loc       *file.Loc
+		Asserts   bool
+		Parameter string
+		Type      FlowType
+	}
+
+	TSThisType struct {
+		Loc *file.Loc
+	}
+
+	// import('a').B<C>
+	TSImportType struct {
+		Loc           *file.Loc
+		From          string
+		Qualifier     *FlowIdentifier
+		TypeArguments []FlowType
+	}
+
+	TSTemplateLiteralType struct {
+		Loc   *file.Loc
+		Types []FlowType
+	}
+
+	// new (a: A) => B
+	TSConstructorType struct {
+		Loc      *file.Loc
+		Function *FlowFunctionType
+	}
+
+	// { [K in T as U]-?: V }
+	TSMappedTypeProperty struct {
+		Loc           *file.Loc
+		KeyName       string
+		Constraint    FlowType
+		NameType      FlowType
+		Readonly      bool
+		Optional      bool
+		RemovesOption bool
+		Value         FlowType
+	}
+)
+
+func (*TSEnumStatement) _exportClauseNode()         {}
+func (*TSNamespaceStatement) _exportClauseNode()    {}
+func (*TSDeclareStatement) _exportClauseNode()      {}
+func (*TSImportEqualsStatement) _exportClauseNode() {}
+
+func (*TSTypeOperator) _flowType()        {}
+func (*TSConditionalType) _flowType()     {}
+func (*TSInferType) _flowType()           {}
+func (*TSTypePredicate) _flowType()       {}
+func (*TSThisType) _flowType()            {}
+func (*TSImportType) _flowType()          {}
+func (*TSTemplateLiteralType) _flowType() {}
+func (*TSConstructorType) _flowType()     {}
+
+func (*TSMappedTypeProperty) _flowObjectProperty()         {}
+func (*TSMappedTypeProperty) _flowInterfaceBodyStatement() {}
+
+func (t *TSTypeOperator) GetLoc() *file.Loc        { return t.Loc }
+func (t *TSConditionalType) GetLoc() *file.Loc     { return t.Loc }
+func (t *TSInferType) GetLoc() *file.Loc           { return t.Loc }
+func (t *TSTypePredicate) GetLoc() *file.Loc       { return t.Loc }
+func (t *TSThisType) GetLoc() *file.Loc            { return t.Loc }
+func (t *TSImportType) GetLoc() *file.Loc          { return t.Loc }
+func (t *TSTemplateLiteralType) GetLoc() *file.Loc { return t.Loc }
+func (t *TSConstructorType) GetLoc() *file.Loc     { return t.Loc }
+
+func (t *TSTypeOperator) GetNode() *Node        { return nil }
+func (t *TSConditionalType) GetNode() *Node     { return nil }
+func (t *TSInferType) GetNode() *Node           { return nil }
+func (t *TSTypePredicate) GetNode() *Node       { return nil }
+func (t *TSThisType) GetNode() *Node            { return nil }
+func (t *TSImportType) GetNode() *Node          { return nil }
+func (t *TSTemplateLiteralType) GetNode() *Node { return nil }
+func (t *TSConstructorType) GetNode() *Node     { return nil }
@@ -10,12 +10,22 @@ type Visitor interface {
 	ImportDeclaration(stmt *ImportStatement) IStmt
 	FlowTypeStatement(stmt *FlowTypeStatement) *FlowTypeStatement
 	FlowInterfaceStatement(stmt *FlowInterfaceStatement) *FlowInterfaceStatement
+	FlowDeclareStatement(stmt *FlowDeclareStatement) *FlowDeclareStatement
+	FlowDeclareClassStatement(stmt *FlowDeclareClassStatement) *FlowDeclareClassStatement
+	FlowDeclareExportStatement(stmt *FlowDeclareExportStatement) *FlowDeclareExportStatement
+	FlowDeclareModuleStatement(stmt *FlowDeclareModuleStatement) *FlowDeclareModuleStatement
+	FlowDeclareModuleExportsStatement(stmt *FlowDeclareModuleExportsStatement) *FlowDeclareModuleExportsStatement
+	FlowEnumStatement(stmt *FlowEnumStatement) *FlowEnumStatement
+	TSEnumStatement(stmt *TSEnumStatement) *TSEnumStatement
+	TSNamespaceStatement(stmt *TSNamespaceStatement) *TSNamespaceStatement
+	TSDeclareStatement(stmt *TSDeclareStatement) *TSDeclareStatement
+	TSImportEqualsStatement(stmt *TSImportEqualsStatement) *TSImportEqualsStatement
+	TSExportAssignmentStatement(stmt *TSExportAssignmentStatement) *TSExportAssignmentStatement
 	BlockStatement(stmt *BlockStatement) IStmt
 	ClassStatement(stmt *ClassStatement) IStmt
 	ClassFieldStatement(stmt *ClassFieldStatement) IStmt
 	ClassAccessorStatement(stmt *ClassAccessorStatement) IStmt
 	ClassMethodStatement(stmt *ClassMethodStatement) IStmt
-	LegacyDecoratorStatement(stmt *LegacyDecoratorStatement) IStmt
 	ForInStatement(stmt *ForInStatement) IStmt
 	ForOfStatement(stmt *ForOfStatement) IStmt
 	ForStatement(stmt *ForStatement) IStmt
@@ -46,6 +56,7 @@ type Visitor interface {
 	ImportCall(exp *ImportCall) *ImportCall
 
 	FlowTypeAssertion(exp *FlowTypeAssertionExpression) *FlowTypeAssertionExpression
+	TSTypeAssertion(exp *TSTypeAssertionExpression) *TSTypeAssertionExpression
 
 	FunctionLiteral(stmt *FunctionLiteral) *FunctionLiteral
 	StringLiteral(exp *StringLiteral) *StringLiteral
@@ -88,6 +99,7 @@ type Visitor interface {
 	// others
 	PatternBinder(binder PatternBinder) PatternBinder
 	IdentifierBinder(b *IdentifierBinder) *IdentifierBinder
+	ExpressionBinder(b *ExpressionBinder) *ExpressionBinder
 	ObjectRestBinder(b *ObjectRestBinder) *ObjectRestBinder
 	ArrayRestBinder(b *ArrayRestBinder) *ArrayRestBinder
 	ObjectPropertyBinder(b *ObjectPropertyBinder) *ObjectPropertyBinder
@@ -160,6 +172,28 @@ func (w *Walker) Statement(stmt IStmt) IStmt {
 		stmt = w.Visitor.FlowTypeStatement(s)
 	case *FlowInterfaceStatement:
 		stmt = w.Visitor.FlowInterfaceStatement(s)
+	case *FlowDeclareStatement:
+		stmt = w.Visitor.FlowDeclareStatement(s)
+	case *FlowDeclareClassStatement:
+		stmt = w.Visitor.FlowDeclareClassStatement(s)
+	case *FlowDeclareExportStatement:
+		stmt = w.Visitor.FlowDeclareExportStatement(s)
+	case *FlowDeclareModuleStatement:
+		stmt = w.Visitor.FlowDeclareModuleStatement(s)
+	case *FlowDeclareModuleExportsStatement:
+		stmt = w.Visitor.FlowDeclareModuleExportsStatement(s)
+	case *FlowEnumStatement:
+		stmt = w.Visitor.FlowEnumStatement(s)
+	case *TSEnumStatement:
+		stmt = w.Visitor.TSEnumStatement(s)
+	case *TSNamespaceStatement:
+		stmt = w.Visitor.TSNamespaceStatement(s)
+	case *TSDeclareStatement:
+		stmt = w.Visitor.TSDeclareStatement(s)
+	case *TSImportEqualsStatement:
+		stmt = w.Visitor.TSImportEqualsStatement(s)
+	case *TSExportAssignmentStatement:
+		stmt = w.Visitor.TSExportAssignmentStatement(s)
 	case *BlockStatement:
 		stmt = w.Visitor.BlockStatement(s)
 	case *ClassStatement:
@@ -170,8 +204,6 @@ func (w *Walker) Statement(stmt IStmt) IStmt {
 		stmt = w.Visitor.ClassAccessorStatement(s)
 	case *ClassMethodStatement:
 		stmt = w.Visitor.ClassMethodStatement(s)
-	case *LegacyDecoratorStatement:
-		stmt = w.Visitor.LegacyDecoratorStatement(s)
 	case *ForInStatement:
 		stmt = w.Visitor.ForInStatement(s)
 	case *ForOfStatement:
@@ -198,6 +230,8 @@ func (w *Walker) Statement(stmt IStmt) IStmt {
 		stmt = w.Visitor.ReturnStatement(s)
 	case *SwitchStatement:
 		stmt = w.Visitor.SwitchStatement(s)
+	case *CaseStatement:
+		stmt = w.Visitor.CaseStatement(s)
 	case *ThrowStatement:
 		stmt = w.Visitor.ThrowStatement(s)
 	case *TryStatement:
@@ -254,6 +288,8 @@ func (w *Walker) Expression(exp IExpr) IExpr {
 		exp = w.Visitor.ImportCall(s)
 	case *FlowTypeAssertionExpression:
 		exp = w.Visitor.FlowTypeAssertion(s)
+	case *TSTypeAssertionExpression:
+		exp = w.Visitor.TSTypeAssertion(s)
 	case *FunctionLiteral:
 		exp = w.Visitor.FunctionLiteral(s)
 	case *StringLiteral:
@@ -378,6 +414,16 @@ func (w *Walker) ExportClause(ac ExportClause) ExportClause {
 		return w.Visitor.FlowTypeStatement(c)
 	case *FlowInterfaceStatement:
 		return w.Visitor.FlowInterfaceStatement(c)
+	case *FlowEnumStatement:
+		return w.Visitor.FlowEnumStatement(c)
+	case *TSEnumStatement:
+		return w.Visitor.TSEnumStatement(c)
+	case *TSNamespaceStatement:
+		return w.Visitor.TSNamespaceStatement(c)
+	case *TSDeclareStatement:
+		return w.Visitor.TSDeclareStatement(c)
+	case *TSImportEqualsStatement:
+		return w.Visitor.TSImportEqualsStatement(c)
 
 	default:
 		panic("Unknown export clause type")
@@ -385,31 +431,45 @@ func (w *Walker) ExportClause(ac ExportClause) ExportClause {
 }
 
 func (w *Walker) ExportNamespaceFromClause(c *ExportNamespaceFromClause) *ExportNamespaceFromClause {
-	panic("implement me")
+	return c
 }
 
 func (w *Walker) ExportNamedFromClause(c *ExportNamedFromClause) *ExportNamedFromClause {
-	panic("implement me")
+	return c
 }
 
 func (w *Walker) ExportNamedClause(c *ExportNamedClause) *ExportNamedClause {
-	panic("implement me")
+	for _, e := range c.Exports {
+		e.LocalIdentifier = w.Visitor.Identifier(e.LocalIdentifier)
+	}
+
+	return c
 }
 
 func (w *Walker) ExportVarClause(c *ExportVarClause) *ExportVarClause {
-	panic("implement me")
+	if stmt, ok := w.Visitor.VariableStatement(c.Declaration).(*VariableStatement); ok {
+		c.Declaration = stmt
+	}
+
+	return c
 }
 
 func (w *Walker) ExportFunctionClause(c *ExportFunctionClause) *ExportFunctionClause {
-	panic("implement me")
+	c.FunctionLiteral = w.Visitor.FunctionLiteral(c.FunctionLiteral)
+
+	return c
 }
 
 func (w *Walker) ExportClassClause(c *ExportClassClause) *ExportClassClause {
-	panic("implement me")
+	c.ClassExpression = w.Visitor.ClassExpression(c.ClassExpression)
+
+	return c
 }
 
 func (w *Walker) ExportDefaultClause(c *ExportDefaultClause) *ExportDefaultClause {
-	panic("implement me")
+	c.Declaration = w.Visitor.Expression(c.Declaration)
+
+	return c
 }
 
 func (w *Walker) ImportDeclaration(stmt *ImportStatement) IStmt {
@@ -441,10 +501,74 @@ func (w *Walker) FlowInterfaceStatement(stmt *FlowInterfaceStatement) *FlowInter
 	return stmt
 }
 
+func (w *Walker) FlowDeclareStatement(stmt *FlowDeclareStatement) *FlowDeclareStatement {
+	return stmt
+}
+
+func (w *Walker) FlowDeclareClassStatement(stmt *FlowDeclareClassStatement) *FlowDeclareClassStatement {
+	return stmt
+}
+
+func (w *Walker) FlowDeclareExportStatement(stmt *FlowDeclareExportStatement) *FlowDeclareExportStatement {
+	return stmt
+}
+
+func (w *Walker) FlowDeclareModuleStatement(stmt *FlowDeclareModuleStatement) *FlowDeclareModuleStatement {
+	return stmt
+}
+
+func (w *Walker) FlowDeclareModuleExportsStatement(stmt *FlowDeclareModuleExportsStatement) *FlowDeclareModuleExportsStatement {
+	return stmt
+}
+
+func (w *Walker) FlowEnumStatement(stmt *FlowEnumStatement) *FlowEnumStatement {
+	return stmt
+}
+
 func (w *Walker) FlowTypeAssertion(exp *FlowTypeAssertionExpression) *FlowTypeAssertionExpression {
+	exp.Left = w.Visitor.Expression(exp.Left)
+
+	return exp
+}
+
+func (w *Walker) TSTypeAssertion(exp *TSTypeAssertionExpression) *TSTypeAssertionExpression {
+	exp.Expression = w.Visitor.Expression(exp.Expression)
+
 	return exp
 }
 
+func (w *Walker) TSEnumStatement(stmt *TSEnumStatement) *TSEnumStatement {
+	for _, member := range stmt.Members {
+		member.Initializer = w.Visitor.Expression(member.Initializer)
+	}
+
+	return stmt
+}
+
+func (w *Walker) TSNamespaceStatement(stmt *TSNamespaceStatement) *TSNamespaceStatement {
+	for index, s := range stmt.Body {
+		stmt.Body[index] = w.Visitor.Statement(s)
+	}
+
+	return stmt
+}
+
+func (w *Walker) TSDeclareStatement(stmt *TSDeclareStatement) *TSDeclareStatement {
+	return stmt
+}
+
+func (w *Walker) TSImportEqualsStatement(stmt *TSImportEqualsStatement) *TSImportEqualsStatement {
+	stmt.ModuleReference = w.Visitor.Expression(stmt.ModuleReference)
+
+	return stmt
+}
+
+func (w *Walker) TSExportAssignmentStatement(stmt *TSExportAssignmentStatement) *TSExportAssignmentStatement {
+	stmt.Expression = w.Visitor.Expression(stmt.Expression)
+
+	return stmt
+}
+
 func (w *Walker) BlockStatement(block *BlockStatement) IStmt {
 	for index, stmt := range block.List {
 		block.List[index] = w.Visitor.Statement(stmt)
@@ -460,38 +584,39 @@ func (w *Walker) ClassStatement(stmt *ClassStatement) IStmt {
 }
 
 func (w *Walker) ClassFieldStatement(stmt *ClassFieldStatement) IStmt {
-	stmt.Initializer = w.Visitor.Expression(stmt.Initializer)
+	stmt.Decorators = w.Decorators(stmt.Decorators)
 	stmt.Name = w.Visitor.ObjectPropertyName(stmt.Name)
+	stmt.Initializer = w.Visitor.Expression(stmt.Initializer)
 
 	return stmt
 }
 
+func (w *Walker) Decorators(decorators []IExpr) []IExpr {
+	for index, decorator := range decorators {
+		decorators[index] = w.Visitor.Expression(decorator)
+	}
+
+	return decorators
+}
+
 func (w *Walker) ComputedName(n *ComputedName) *ComputedName {
 	n.Expression = w.Visitor.Expression(n.Expression)
 	return n
 }
 
 func (w *Walker) ClassAccessorStatement(stmt *ClassAccessorStatement) IStmt {
-	stmt.Body = w.Visitor.FunctionLiteral(stmt.Body)
+	stmt.Decorators = w.Decorators(stmt.Decorators)
 	stmt.Field = w.Visitor.ObjectPropertyName(stmt.Field)
+	stmt.Body = w.Visitor.FunctionLiteral(stmt.Body)
 
 	return stmt
 }
 
 func (w *Walker) ClassMethodStatement(stmt *ClassMethodStatement) IStmt {
-	stmt.Body = w.Visitor.FunctionBody(stmt.Body)
+	stmt.Decorators = w.Decorators(stmt.Decorators)
 	stmt.Name = w.Visitor.ObjectPropertyName(stmt.Name)
 	stmt.Parameters = w.Visitor.FunctionParameters(stmt.Parameters)
-
-	return stmt
-}
-
-func (w *Walker) LegacyDecoratorStatement(stmt *LegacyDecoratorStatement) IStmt {
-	for index, dec := range stmt.Decorators {
-		stmt.Decorators[index] = w.Visitor.Expression(dec)
-	}
-
-	stmt.Subject = w.Visitor.Statement(stmt.Subject)
+	stmt.Body = w.Visitor.FunctionBody(stmt.Body)
 
 	return stmt
 }
@@ -723,6 +848,10 @@ func (w *Walker) ObjectPropertyName(an ObjectPropertyName) ObjectPropertyName {
 		return w.Visitor.ComputedName(n)
 	case *Identifier:
 		return w.Visitor.Identifier(n)
+	case *StringLiteral:
+		return w.Visitor.StringLiteral(n)
+	case *NumberLiteral:
+		return w.Visitor.NumberLiteral(n)
 
 	default:
 		panic("Unknown object property name type")
@@ -785,6 +914,7 @@ func (w *Walker) VariableBinding(exp *VariableBinding) *VariableBinding {
 }
 
 func (w *Walker) ClassExpression(exp *ClassExpression) *ClassExpression {
+	exp.Decorators = w.Decorators(exp.Decorators)
 	exp.Name = w.Visitor.Identifier(exp.Name)
 	exp.SuperClass = w.Visitor.Expression(exp.SuperClass)
 	exp.Body = w.Visitor.Statement(exp.Body)
@@ -793,6 +923,13 @@ func (w *Walker) ClassExpression(exp *ClassExpression) *ClassExpression {
 }
 
 func (w *Walker) MemberExpression(exp *MemberExpression) IExpr {
+	exp.Left = w.Visitor.Expression(exp.Left)
+
+	// a.b has b as a property name and not as a reference
+	if exp.Kind == MKArray {
+		exp.Right = w.Visitor.Expression(exp.Right)
+	}
+
 	return exp
 }
 
@@ -816,13 +953,45 @@ func (w *Walker) ConditionalExpression(exp *ConditionalExpression) *ConditionalE
 }
 
 func (w *Walker) JsxElement(exp *JSXElement) *JSXElement {
+	for _, attribute := range exp.Attributes {
+		switch a := attribute.(type) {
+		case *JSXNamedAttribute:
+			a.Value = w.Visitor.Expression(a.Value)
+		case *JSXSpreadAttribute:
+			a.Expression = w.Visitor.Expression(a.Expression)
+		}
+	}
+
+	exp.Children = w.jsxChildren(exp.Children)
+
 	return exp
 }
 
 func (w *Walker) JsxFragment(exp *JSXFragment) *JSXFragment {
+	exp.Children = w.jsxChildren(exp.Children)
+
 	return exp
 }
 
+func (w *Walker) jsxChildren(children []JSXChild) []JSXChild {
+	for index, child := range children {
+		switch c := child.(type) {
+		case *JSXChildExpression:
+			c.IExpr = w.Visitor.Expression(c.IExpr)
+		case *JSXElement, *JSXFragment:
+			exp := w.Visitor.Expression(c.(IExpr))
+
+			if jsx, ok := exp.(JSXChild); ok {
+				children[index] = jsx
+			} else {
+				children[index] = &JSXChildExpression{IExpr: exp}
+			}
+		}
+	}
+
+	return children
+}
+
 func (w *Walker) NewTargetExpression(exp *NewTargetExpression) *NewTargetExpression {
 	return exp
 }
@@ -890,12 +1059,12 @@ func (w *Walker) ArrowFunctionExpression(exp *ArrowFunctionExpression) *ArrowFun
 		return nil
 	}
 
-	exp.Body = w.Visitor.FunctionBody(exp.Body)
-
 	for index, param := range exp.Parameters {
 		exp.Parameters[index] = w.Visitor.FunctionParameter(param)
 	}
 
+	exp.Body = w.Visitor.FunctionBody(exp.Body)
+
 	return exp
 }
 
@@ -906,8 +1075,8 @@ func (w *Walker) AwaitExpression(exp *AwaitExpression) *AwaitExpression {
 }
 
 func (w *Walker) OptionalObjectMemberAccessExpression(exp *OptionalObjectMemberAccessExpression) *OptionalObjectMemberAccessExpression {
+	// a?.b has b as a property name and not as a reference
 	exp.Left = w.Visitor.Expression(exp.Left)
-	exp.Identifier = w.Visitor.Identifier(exp.Identifier)
 
 	return exp
 }
@@ -1000,6 +1169,8 @@ func (w *Walker) PatternBinder(p PatternBinder) PatternBinder {
 	switch b := p.(type) {
 	case *IdentifierBinder:
 		return w.Visitor.IdentifierBinder(b)
+	case *ExpressionBinder:
+		return w.Visitor.ExpressionBinder(b)
 	case *ObjectRestBinder:
 		return w.Visitor.ObjectRestBinder(b)
 	case *ArrayRestBinder:
@@ -1024,6 +1195,12 @@ func (w *Walker) IdentifierBinder(b *IdentifierBinder) *IdentifierBinder {
 	return b
 }
 
+func (w *Walker) ExpressionBinder(b *ExpressionBinder) *ExpressionBinder {
+	b.Expression = w.Visitor.Expression(b.Expression)
+
+	return b
+}
+
 func (w *Walker) ObjectRestBinder(b *ObjectRestBinder) *ObjectRestBinder {
 	b.Binder = w.Visitor.PatternBinder(b.Binder)
 
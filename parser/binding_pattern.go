@@ -28,6 +28,32 @@ func (p *Parser) parseBindingMemberExpressionOrIdentifier() ast.PatternBinder {
 	}
 }
 
+// declareBinder gives the kind of the declaration to the ids of the pattern, e.g. const {a} = o
+func declareBinder(binder ast.PatternBinder, refType ast.SymbolRefType) {
+	switch b := binder.(type) {
+	case *ast.IdentifierBinder:
+		if b.Id.Symbol != nil {
+			b.Id.Symbol.RefType = refType
+		}
+	case *ast.ObjectBinding:
+		for _, item := range b.List {
+			declareBinder(item, refType)
+		}
+	case *ast.ArrayBinding:
+		for _, item := range b.List {
+			declareBinder(item, refType)
+		}
+	case *ast.ObjectPropertyBinder:
+		declareBinder(b.Binder, refType)
+	case *ast.ArrayItemBinder:
+		declareBinder(b.Binder, refType)
+	case *ast.ObjectRestBinder:
+		declareBinder(b.Binder, refType)
+	case *ast.ArrayRestBinder:
+		declareBinder(b.Binder, refType)
+	}
+}
+
 func (p *Parser) parseBinder() ast.PatternBinder {
 	switch p.token {
 	case token.LEFT_BRACKET:
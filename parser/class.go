@@ -7,16 +7,63 @@ import (
 
 func (p *Parser) parseClassBodyStatement() ast.IStmt {
 	loc := p.loc()
+
+	if !p.typescript {
+		// members of /*:: */ comments are declarations only, like declare fields
+		if p.flowComment {
+			return &ast.TSDeclareStatement{
+				StmtNode:    p.stmtNodeAt(loc),
+				Declaration: p.parseClassElement(0),
+			}
+		}
+
+		return p.parseClassElement(0)
+	}
+
+	if p.isTSIndexSignatureStart() {
+		return p.parseTSIndexSignature()
+	}
+
+	modifiers, _ := p.parseTSModifiers(tsClassMemberModifiers)
+	stmt := p.parseClassElement(modifiers)
+
+	// abstract and declared members are never emitted
+	if modifiers.Has(tsDeclare | tsAbstract) {
+		if _, ok := stmt.(*ast.TSDeclareStatement); !ok {
+			return &ast.TSDeclareStatement{
+				StmtNode:    p.stmtNodeAt(loc),
+				Declaration: stmt,
+			}
+		}
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseClassElement(modifiers ast.Flags) ast.IStmt {
+	loc := p.loc()
 	async := false
 	static := false
 	private := false
 	generator := false
+	autoAccessor := false
 
 	if p.is(token.STATIC) {
 		static = true
 		p.next()
 	}
 
+	if p.typescript {
+		// static readonly, static override
+		p.parseTSModifiers(tsClassMemberModifiers)
+	}
+
+	// accessor x = 1, but accessor() {} and accessor = 1 are regular members
+	if p.isContextualFollowedBy("accessor", token.IDENTIFIER, token.KEYWORD, token.STRING, token.NUMBER, token.LEFT_BRACKET, token.HASH) {
+		autoAccessor = true
+		p.next()
+	}
+
 	if p.is(token.ASYNC) {
 		async = true
 		p.next()
@@ -34,36 +81,72 @@ func (p *Parser) parseClassBodyStatement() ast.IStmt {
 
 	identifier := p.parseObjectPropertyName()
 
+	if identifier == nil {
+		p.unexpectedToken()
+		return nil
+	}
+
 	p.insertSemicolon = true
 
 	// Could be set/get
-	if accessor, ok := identifier.(*ast.Identifier); ok {
+	if accessor, ok := identifier.(*ast.Identifier); ok && !private && !autoAccessor {
 		if accessor.Name == "set" || accessor.Name == "get" {
-			if async || private || generator {
+			if async || generator {
 				p.unexpectedToken()
 				return nil
 			}
 
 			kind := accessor.Name
 
+			if p.is(token.HASH) {
+				private = true
+				p.next()
+			}
+
 			if field := p.parseObjectPropertyName(); field != nil {
+				p.useSymbolsScope(ast.SSTFunction)
+				defer p.restoreSymbolsScope()
+
 				node := &ast.FunctionLiteral{
 					Node:       p.nodeAt(loc),
 					Parameters: p.parseFunctionParameterList(),
 				}
 
-				p.parseFunctionNodeBody(node)
+				if p.is(token.COLON) {
+					node.ReturnType = p.parseFlowTypeAnnotation()
+				}
 
-				return &ast.ClassAccessorStatement{
+				stmt := &ast.ClassAccessorStatement{
 					StmtNode: p.stmtNodeAt(loc),
 					Field:    field,
 					Kind:     kind,
+					Static:   static,
+					Private:  private,
 					Body:     node,
 				}
+
+				if p.typescript && !p.is(token.LEFT_BRACE) {
+					return &ast.TSDeclareStatement{
+						StmtNode:    p.stmtNodeAt(loc),
+						Declaration: stmt,
+					}
+				}
+
+				p.parseFunctionNodeBody(node)
+
+				return stmt
 			}
 		}
 	}
 
+	if p.typescript {
+		// optional a?: T and definite a!: T
+		if p.is(token.QUESTION_MARK) || p.is(token.NOT) {
+			p.next()
+			p.insertSemicolon = true
+		}
+	}
+
 	switch p.token {
 	case token.ASSIGN, token.COLON:
 		// Field can not be async or generator
@@ -79,6 +162,7 @@ func (p *Parser) parseClassBodyStatement() ast.IStmt {
 			Name:     identifier,
 			Static:   static,
 			Private:  private,
+			Accessor: autoAccessor,
 		}
 
 		if p.is(token.COLON) {
@@ -95,6 +179,11 @@ func (p *Parser) parseClassBodyStatement() ast.IStmt {
 
 		return stmt
 	case token.LESS, token.LEFT_PARENTHESIS:
+		if autoAccessor {
+			p.unexpectedToken()
+			return nil
+		}
+
 		// Method declaration
 		method := &ast.ClassMethodStatement{
 			StmtNode:  p.stmtNodeAt(loc),
@@ -118,6 +207,14 @@ func (p *Parser) parseClassBodyStatement() ast.IStmt {
 			method.ReturnType = p.parseFlowTypeAnnotation()
 		}
 
+		// overloads and abstract methods have no body
+		if p.typescript && !p.is(token.LEFT_BRACE) {
+			return &ast.TSDeclareStatement{
+				StmtNode:    p.stmtNodeAt(loc),
+				Declaration: method,
+			}
+		}
+
 		body := p.parseFunctionBody(generator, async)
 
 		method.Body = body
@@ -130,6 +227,7 @@ func (p *Parser) parseClassBodyStatement() ast.IStmt {
 			Name:        identifier,
 			Static:      static,
 			Private:     private,
+			Accessor:    autoAccessor,
 			Initializer: nil,
 		}
 	default:
@@ -140,6 +238,7 @@ func (p *Parser) parseClassBodyStatement() ast.IStmt {
 				Name:        identifier,
 				Static:      static,
 				Private:     private,
+				Accessor:    autoAccessor,
 				Initializer: nil,
 			}
 		} else {
@@ -161,26 +260,7 @@ func (p *Parser) parseClassBodyStatementList() []ast.IStmt {
 		}
 
 		if p.is(token.AT) {
-			loc := p.loc()
-			decorators := p.parseDecoratorsList()
-			statement := p.parseClassBodyStatement()
-
-			var subject ast.IStmt
-
-			switch refinedStatement := statement.(type) {
-			case *ast.ClassFieldStatement:
-				subject = refinedStatement
-			case *ast.ClassMethodStatement:
-				subject = refinedStatement
-			default:
-				p.error(loc, "Class accessor definition can not be decorated")
-				return nil
-			}
-
-			stmts = append(stmts, &ast.LegacyDecoratorStatement{
-				Decorators: decorators,
-				Subject:    subject,
-			})
+			stmts = append(stmts, p.parseDecoratedClassElement())
 		} else {
 			stmts = append(stmts, p.parseClassBodyStatement())
 		}
@@ -196,6 +276,7 @@ func (p *Parser) parseClassBody() ast.IStmt {
 	defer closeClassScope()
 
 	p.useSymbolsScope(ast.SSTClass)
+	defer p.restoreSymbolsScope()
 
 	node := &ast.BlockStatement{
 		StmtNode: p.stmtNode(),
@@ -235,6 +316,10 @@ func (p *Parser) parseClassExpression() *ast.ClassExpression {
 		}
 	}
 
+	if p.typescript && p.isContextual("implements") {
+		exp.Implements = p.parseTSImplements()
+	}
+
 	exp.Body = p.parseClassBody()
 
 	return exp
@@ -5,21 +5,140 @@ import (
 	"yawp/parser/token"
 )
 
+// parseDecorator parses the restricted decorator grammar:
+// @a.b.c, @a.b.c(arguments) and @(expression)
+func (p *Parser) parseDecorator() ast.IExpr {
+	p.consumeExpected(token.AT)
+
+	if p.is(token.LEFT_PARENTHESIS) {
+		p.next()
+		exp := p.parseExpression()
+		p.consumeExpected(token.RIGHT_PARENTHESIS)
+
+		return exp
+	}
+
+	if !p.is(token.IDENTIFIER) {
+		p.unexpectedToken()
+
+		return nil
+	}
+
+	var exp ast.IExpr = p.symbol(p.parseIdentifier(), ast.SRead, ast.SRUnknown)
+
+	for p.is(token.PERIOD) {
+		exp = p.parseDotMember(exp)
+	}
+
+	if p.is(token.LEFT_PARENTHESIS) {
+		exp = p.parseCallExpression(exp, nil)
+	}
+
+	return exp
+}
+
 func (p *Parser) parseDecoratorsList() []ast.IExpr {
 	decorators := make([]ast.IExpr, 0, 1)
 
 	for p.is(token.AT) {
-		p.consumeExpected(token.AT)
-
-		decorators = append(decorators, p.parseAssignmentExpression())
+		decorators = append(decorators, p.parseDecorator())
 	}
 
 	return decorators
 }
 
-func (p *Parser) parseLegacyClassDecoratorStatement() *ast.LegacyDecoratorStatement {
-	return &ast.LegacyDecoratorStatement{
-		Decorators: p.parseDecoratorsList(),
-		Subject:    p.parseClassStatement(),
+// parseDecoratedStatement parses @a class A {} and @a export class A {}
+func (p *Parser) parseDecoratedStatement() ast.IStmt {
+	loc := p.loc()
+	decorators := p.parseDecoratorsList()
+
+	switch p.token {
+	case token.CLASS:
+		stmt := p.parseClassStatement()
+		stmt.Expression.Decorators = decorators
+
+		return stmt
+	case token.EXPORT:
+		stmt := p.parseExportDeclaration()
+
+		if class := exportedClass(stmt); class != nil && len(class.Decorators) == 0 {
+			class.Decorators = decorators
+
+			return stmt
+		}
 	}
+
+	p.error(loc, "Leading decorators must be attached to a class declaration")
+
+	return nil
+}
+
+// parseDecoratedClassExpression parses @a class {} as an expression, e.g. export default @a class {}
+func (p *Parser) parseDecoratedClassExpression() *ast.ClassExpression {
+	decorators := p.parseDecoratorsList()
+
+	if !p.is(token.CLASS) {
+		p.unexpectedToken()
+
+		return nil
+	}
+
+	exp := p.parseClassExpression()
+	exp.Decorators = decorators
+
+	return exp
+}
+
+func exportedClass(stmt ast.IStmt) *ast.ClassExpression {
+	if export, ok := stmt.(*ast.ExportStatement); ok {
+		switch c := export.Clause.(type) {
+		case *ast.ExportClassClause:
+			return c.ClassExpression
+		case *ast.ExportDefaultClause:
+			if class, ok := c.Declaration.(*ast.ClassExpression); ok {
+				return class
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseDecoratedClassElement attaches decorators to the class member,
+// constructors and static blocks can not be decorated
+func (p *Parser) parseDecoratedClassElement() ast.IStmt {
+	loc := p.loc()
+	decorators := p.parseDecoratorsList()
+
+	switch stmt := p.parseClassBodyStatement().(type) {
+	case *ast.ClassFieldStatement:
+		stmt.Decorators = decorators
+
+		return stmt
+	case *ast.ClassAccessorStatement:
+		stmt.Decorators = decorators
+
+		return stmt
+	case *ast.ClassMethodStatement:
+		if isConstructor(stmt) {
+			p.error(loc, "Decorators can not be used with a constructor")
+		}
+
+		stmt.Decorators = decorators
+
+		return stmt
+	case *ast.TSDeclareStatement:
+		// declared members are erased with their decorators
+		return stmt
+	}
+
+	p.error(loc, "Decorators are not valid here")
+
+	return nil
+}
+
+func isConstructor(method *ast.ClassMethodStatement) bool {
+	name, ok := method.Name.(*ast.Identifier)
+
+	return ok && name.Name == "constructor" && !method.Static && !method.Private
 }
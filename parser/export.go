@@ -19,6 +19,7 @@ func (p *Parser) parseExportNamespaceFromClause() *ast.ExportNamespaceFromClause
 		From:             "",
 	}
 
+	p.allowToken(token.AS)
 	if p.is(token.AS) {
 		p.consumeExpected(token.AS)
 		clause.ModuleIdentifier = p.symbol(p.parseIdentifier(), ast.SDeclaration, ast.SRExport)
@@ -27,29 +28,56 @@ func (p *Parser) parseExportNamespaceFromClause() *ast.ExportNamespaceFromClause
 	p.allowToken(token.FROM)
 	p.consumeExpected(token.FROM)
 
-	if p.is(token.STRING) {
-		clause.From = p.literal
-	} else {
-		p.unexpectedToken()
-		p.next()
-	}
+	clause.From = p.literal
+	p.consumeExpected(token.STRING)
 
 	return clause
 }
 
-func (p *Parser) parseExportNamedClause() *ast.ExportNamedClause {
+// parseExportNamedClause reports whether all the specifiers export types, those are left out
+func (p *Parser) parseExportNamedClause() (*ast.ExportNamedClause, bool) {
 	clause := &ast.ExportNamedClause{
 		Exports: make([]*ast.NamedExportClause, 0),
 	}
 
 	p.consumeExpected(token.LEFT_BRACE)
-	p.allowToken(token.AS)
+
+	specifiers := 0
 
 	for !p.is(token.RIGHT_BRACE) {
+		typeOnly := false
+		specifiers++
+
+		// export { type A }
+		if p.isContextual("type") {
+			if tkn, literal, isKeyword, _ := p.peek(); (tkn == token.IDENTIFIER || isKeyword) && literal != "as" {
+				typeOnly = true
+				p.next()
+			}
+		}
+
+		// export { type }
+		if p.is(token.TYPE_TYPE) {
+			p.token = token.IDENTIFIER
+		}
+
+		if typeOnly {
+			p.parseIdentifier()
+
+			if p.isAllowed(token.AS) {
+				p.consumeExpected(token.AS)
+				p.parseIdentifier()
+			}
+
+			p.consumePossible(token.COMMA)
+
+			continue
+		}
+
 		localIdentifier := p.symbol(p.parseIdentifier(), ast.SRead, ast.SRExport)
 		moduleIdentifier := localIdentifier
 
-		if p.is(token.AS) {
+		if p.isAllowed(token.AS) {
 			p.consumeExpected(token.AS)
 			moduleIdentifier = p.symbol(p.parseIdentifier(), ast.SRead, ast.SRExport)
 		}
@@ -64,29 +92,26 @@ func (p *Parser) parseExportNamedClause() *ast.ExportNamedClause {
 
 	p.consumeExpected(token.RIGHT_BRACE)
 
-	return clause
+	return clause, specifiers > 0 && len(clause.Exports) == 0
 }
 
-func (p *Parser) parseExportNamedMaybeFromClause() ast.ExportClause {
-	exportNamedClause := p.parseExportNamedClause()
+func (p *Parser) parseExportNamedMaybeFromClause() (ast.ExportClause, bool) {
+	exportNamedClause, typeOnly := p.parseExportNamedClause()
 
 	p.allowToken(token.FROM)
 	if p.is(token.FROM) {
 		p.next()
 
-		var from string
-
-		if p.is(token.STRING) {
-			from = p.literal
-		}
+		from := p.literal
+		p.consumeExpected(token.STRING)
 
 		return &ast.ExportNamedFromClause{
 			Exports: exportNamedClause.Exports,
 			From:    from,
-		}
+		}, typeOnly
 	}
 
-	return exportNamedClause
+	return exportNamedClause, typeOnly
 }
 
 func (p *Parser) parseExportDefaultClause() *ast.ExportDefaultClause {
@@ -99,28 +124,108 @@ func (p *Parser) parseExportDefaultClause() *ast.ExportDefaultClause {
 	return clause
 }
 
-func (p *Parser) parseExportDeclaration() *ast.ExportStatement {
+func (p *Parser) parseExportFunctionClause(async bool) ast.ExportClause {
+	loc := p.loc()
+
+	if async {
+		p.consumeExpected(token.ASYNC)
+	}
+
+	switch stmt := p.parseFunctionStatement(loc, async).(type) {
+	case *ast.FunctionLiteral:
+		return &ast.ExportFunctionClause{
+			FunctionLiteral: stmt,
+		}
+	case *ast.TSDeclareStatement:
+		return stmt
+	}
+
+	return nil
+}
+
+func (p *Parser) parseExportClassClause() *ast.ExportClassClause {
+	return &ast.ExportClassClause{
+		ClassExpression: p.parseClassExpression(),
+	}
+}
+
+func (p *Parser) parseExportDeclaration() ast.IStmt {
 	loc := p.loc()
 	p.consumeExpected(token.EXPORT)
 	p.allowToken(token.TYPE_TYPE)
 
+	if p.isContextualFollowedBy("opaque", token.TYPE_TYPE) {
+		p.allowToken(token.TYPE_OPAQUE)
+	}
+
 	declaration := &ast.ExportStatement{
 		StmtNode: p.stmtNodeAt(loc),
 	}
 
+	if p.typescript {
+		if stmt := p.parseTSExportDeclaration(declaration); stmt != nil {
+			return stmt
+		}
+	}
+
 	switch p.token {
 	case token.CONST, token.LET, token.VAR:
 		declaration.Clause = p.parseExportVarClause()
+	case token.FUNCTION:
+		declaration.Clause = p.parseExportFunctionClause(false)
+	case token.ASYNC:
+		declaration.Clause = p.parseExportFunctionClause(true)
+	case token.CLASS:
+		declaration.Clause = p.parseExportClassClause()
+	case token.AT:
+		// export @a class A {}
+		declaration.Clause = &ast.ExportClassClause{
+			ClassExpression: p.parseDecoratedClassExpression(),
+		}
 	case token.MULTIPLY:
 		declaration.Clause = p.parseExportNamespaceFromClause()
 	case token.LEFT_BRACE:
-		declaration.Clause = p.parseExportNamedMaybeFromClause()
+		var typeOnly bool
+		declaration.Clause, typeOnly = p.parseExportNamedMaybeFromClause()
+
+		// export { type A } is erased completely, unlike export {}
+		if typeOnly {
+			declaration.Kind = ast.EKType
+		}
 	case token.DEFAULT:
-		declaration.Clause = p.parseExportDefaultClause()
+		if tkn, _, _, _ := p.peek(); p.typescript && tkn == token.INTERFACE {
+			// export default interface A {}
+			p.next()
+			declaration.Clause = p.parseFlowInterfaceStatement()
+		} else {
+			declaration.Clause = p.parseExportDefaultClause()
+		}
 	case token.TYPE_TYPE:
+		if tkn, _, _, _ := p.peek(); tkn == token.LEFT_BRACE || tkn == token.MULTIPLY {
+			// export type { A }
+			p.next()
+			declaration.Kind = ast.EKType
+
+			if p.is(token.MULTIPLY) {
+				declaration.Clause = p.parseExportNamespaceFromClause()
+			} else {
+				declaration.Clause, _ = p.parseExportNamedMaybeFromClause()
+			}
+		} else {
+			declaration.Clause = p.parseFlowTypeStatement()
+		}
+	case token.TYPE_OPAQUE:
 		declaration.Clause = p.parseFlowTypeStatement()
 	case token.INTERFACE:
 		declaration.Clause = p.parseFlowInterfaceStatement()
+	default:
+		if p.isFlowEnumStart() {
+			declaration.Clause = p.parseFlowEnumStatement()
+
+			break
+		}
+
+		p.unexpectedToken()
 	}
 
 	return declaration
@@ -225,6 +225,7 @@ func (p *Parser) parseShiftExpression() ast.IExpr {
 	return left
 }
 
+// parseRelationalExpression parses left-associative relational operators, a < b < c is (a < b) < c
 func (p *Parser) parseRelationalExpression() ast.IExpr {
 	next := p.parseShiftExpression
 	left := next()
@@ -235,41 +236,47 @@ func (p *Parser) parseRelationalExpression() ast.IExpr {
 		p.scope.allowIn = allowIn
 	}()
 
-	switch p.token {
-	case token.LESS, token.LESS_OR_EQUAL, token.GREATER, token.GREATER_OR_EQUAL:
-		tkn := p.token
-		p.next()
-		return &ast.BinaryExpression{
-			ExprNode:   p.exprNodeAt(left.GetLoc()),
-			Operator:   tkn,
-			Left:       left,
-			Right:      p.parseRelationalExpression(),
-			Comparison: true,
+	for {
+		if p.typescript {
+			left = p.parseTSAsExpression(left)
 		}
-	case token.INSTANCEOF:
-		tkn := p.token
-		p.next()
-		return &ast.BinaryExpression{
-			ExprNode: p.exprNodeAt(left.GetLoc()),
-			Operator: tkn,
-			Left:     left,
-			Right:    p.parseRelationalExpression(),
-		}
-	case token.IN:
-		if !allowIn {
+
+		switch p.token {
+		case token.LESS, token.LESS_OR_EQUAL, token.GREATER, token.GREATER_OR_EQUAL:
+			tkn := p.token
+			p.next()
+			left = &ast.BinaryExpression{
+				ExprNode:   p.exprNodeAt(left.GetLoc()),
+				Operator:   tkn,
+				Left:       left,
+				Right:      next(),
+				Comparison: true,
+			}
+		case token.INSTANCEOF:
+			tkn := p.token
+			p.next()
+			left = &ast.BinaryExpression{
+				ExprNode: p.exprNodeAt(left.GetLoc()),
+				Operator: tkn,
+				Left:     left,
+				Right:    next(),
+			}
+		case token.IN:
+			if !allowIn {
+				return left
+			}
+			tkn := p.token
+			p.next()
+			left = &ast.BinaryExpression{
+				ExprNode: p.exprNodeAt(left.GetLoc()),
+				Operator: tkn,
+				Left:     left,
+				Right:    next(),
+			}
+		default:
 			return left
 		}
-		tkn := p.token
-		p.next()
-		return &ast.BinaryExpression{
-			ExprNode: p.exprNodeAt(left.GetLoc()),
-			Operator: tkn,
-			Left:     left,
-			Right:    p.parseRelationalExpression(),
-		}
 	}
-
-	return left
 }
 
 func (p *Parser) parseEqualityExpression() ast.IExpr {
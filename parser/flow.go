@@ -74,24 +74,41 @@ func (p *Parser) parseSimpleFlowType() ast.FlowType {
 			Kind: kind,
 		}
 	case token.STRING:
+		literal := p.literal
 		p.next()
 
 		return &ast.FlowStringLiteralType{
 			Loc:    loc,
-			String: p.literal,
+			String: literal,
 		}
 	case token.NUMBER:
 		number := p.literal
 		p.next()
 
+		return &ast.FlowNumberLiteralType{
+			Loc:    loc,
+			Number: number,
+		}
+	case token.MINUS:
+		// negative number literal, -1
+		p.next()
+		number := "-" + p.literal
+		p.consumeExpected(token.NUMBER)
+
 		return &ast.FlowNumberLiteralType{
 			Loc:    loc,
 			Number: number,
 		}
 	case token.IDENTIFIER:
+		if p.typescript {
+			if tsType := p.parseTSIdentifierType(); tsType != nil {
+				return tsType
+			}
+		}
+
 		id := p.parseFlowTypeIdentifier()
 
-		if p.isFlowTypeArgumentsStart() {
+		if p.isFlowTypeArgumentsStart() && !p.implicitSemicolon {
 			return &ast.FlowGenericType{
 				Name:          id,
 				TypeArguments: p.parseFlowTypeArguments(),
@@ -137,6 +154,10 @@ func (p *Parser) parseSimpleFlowType() ast.FlowType {
 		return functionType
 	}
 
+	if p.typescript {
+		return p.parseTSSimpleType()
+	}
+
 	return nil
 }
 
@@ -151,6 +172,10 @@ func (p *Parser) parseFlowFunctionRemainder(loc *file.Loc, params []*ast.FlowFun
 }
 
 func (p *Parser) parseFlowFunctionParameter() *ast.FlowFunctionParameter {
+	if p.typescript {
+		return p.parseTSFunctionTypeParameter()
+	}
+
 	if p.is(token.IDENTIFIER) {
 		// possible var identifier
 		identifier := p.parseIdentifier()
@@ -190,6 +215,10 @@ func (p *Parser) parseFlowFunctionParameters() []*ast.FlowFunctionParameter {
 }
 
 func (p *Parser) parseFlowExpressionOrFunction() ast.FlowType {
+	if p.typescript {
+		return p.parseTSParenthesizedOrFunctionType()
+	}
+
 	loc := p.loc()
 
 	p.consumeExpected(token.LEFT_PARENTHESIS)
@@ -320,6 +349,15 @@ func (p *Parser) parseFlowType() ast.FlowType {
 
 	loc := p.loc()
 
+	// leading | or &
+	if p.is(token.OR) && p.scope.allowUnionType {
+		return p.parseFlowUnionType(nil)
+	}
+
+	if p.is(token.AND) && p.scope.allowIntersectionType {
+		return p.parseFlowIntersectionType(nil)
+	}
+
 	// could be type expression enclosure or function args
 	if p.is(token.LEFT_PARENTHESIS) {
 		flowType = p.parseFlowExpressionOrFunction()
@@ -328,16 +366,13 @@ func (p *Parser) parseFlowType() ast.FlowType {
 		flowType = p.parseSimpleFlowType()
 	}
 
-	// it's an array type
-	if p.is(token.LEFT_BRACKET) {
-		p.next()
-
-		return &ast.FlowArrayType{
-			Loc:         loc.End(p.consumeExpected(token.RIGHT_BRACKET)),
-			ElementType: flowType,
-		}
+	if flowType == nil {
+		p.unexpectedToken()
+		return nil
 	}
 
+	flowType = p.parseFlowPostfixType(loc, flowType)
+
 	// it's a flow function type
 	if !p.forbidUnparenthesizedFunctionType && p.is(token.ARROW) {
 		p.next()
@@ -361,14 +396,98 @@ func (p *Parser) parseFlowType() ast.FlowType {
 		return p.parseFlowIntersectionType(flowType)
 	}
 
-	if flowType == nil {
+	if p.typescript && p.is(token.EXTENDS) {
+		return p.parseTSConditionalType(flowType)
+	}
+
+	return flowType
+}
+
+// T[], T[K], T?.[K]
+func (p *Parser) parseFlowPostfixType(loc *file.Loc, flowType ast.FlowType) ast.FlowType {
+	for p.isAny(token.LEFT_BRACKET, token.OPTIONAL_CHAINING) && !p.implicitSemicolon {
+		optional := p.is(token.OPTIONAL_CHAINING)
+
+		if optional {
+			p.next()
+
+			if !p.is(token.LEFT_BRACKET) {
+				p.unexpectedToken()
+
+				return flowType
+			}
+		}
+
 		p.next()
-		return nil
+
+		if !optional && p.is(token.RIGHT_BRACKET) {
+			flowType = &ast.FlowArrayType{
+				Loc:         loc.Copy().End(p.consumeExpected(token.RIGHT_BRACKET)),
+				ElementType: flowType,
+			}
+
+			continue
+		}
+
+		indexType := p.parseFlowType()
+
+		flowType = &ast.FlowIndexedAccessType{
+			Loc:        loc.Copy().End(p.consumeExpected(token.RIGHT_BRACKET)),
+			ObjectType: flowType,
+			IndexType:  indexType,
+			Optional:   optional,
+		}
 	}
 
 	return flowType
 }
 
+// return type of functions can be followed by predicate: a is string in TypeScript
+// and %checks in Flow, it is the only thing in : %checks => ...
+func (p *Parser) parseFlowReturnType() (ast.FlowType, *ast.FlowPredicate) {
+	var returnType ast.FlowType
+
+	closeTypeScope := p.openTypeScope()
+	p.consumeExpected(token.COLON)
+
+	if !p.isFlowPredicateStart() {
+		returnType = p.parseFlowType()
+	}
+
+	closeTypeScope()
+
+	if !p.isFlowPredicateStart() {
+		return returnType, nil
+	}
+
+	predicate := &ast.FlowPredicate{
+		Loc: p.loc(),
+	}
+
+	// %checks
+	p.next()
+	p.next()
+
+	// predicate of declared function is an expression
+	if p.is(token.LEFT_PARENTHESIS) && p.scope.inDeclare {
+		p.next()
+		predicate.Expression = p.parseExpression()
+		predicate.Loc.End(p.consumeExpected(token.RIGHT_PARENTHESIS))
+	}
+
+	return returnType, predicate
+}
+
+func (p *Parser) isFlowPredicateStart() bool {
+	if p.typescript || !p.is(token.REMAINDER) {
+		return false
+	}
+
+	_, literal, _, sameLine := p.peek()
+
+	return literal == "checks" && sameLine
+}
+
 func (p *Parser) parseFlowTypeAnnotation() ast.FlowType {
 	closeTypeScope := p.openTypeScope()
 	defer closeTypeScope()
@@ -0,0 +1,181 @@
+package parser
+
+import (
+	"yawp/parser/ast"
+	"yawp/parser/file"
+	"yawp/parser/token"
+)
+
+// declare is a contextual keyword, `declare(a)` or `declare = 1` are still valid js
+func (p *Parser) isFlowDeclareStart() bool {
+	return p.flow && p.isContextualFollowedBy("declare", token.IDENTIFIER, token.KEYWORD)
+}
+
+// Declared values are defined somewhere else, e.g. in libdefs of flow-typed,
+// so their names are never bound in the module and refs stay global
+func (p *Parser) parseFlowDeclareStatement() ast.IStmt {
+	loc := p.loc()
+
+	// declare
+	p.next()
+
+	p.useSymbolsScope(ast.SSTBlock)
+	defer p.dropSymbolsScope()
+
+	wasInDeclare := p.scope.inDeclare
+	p.scope.inDeclare = true
+	defer func() {
+		p.scope.inDeclare = wasInDeclare
+	}()
+
+	switch {
+	case p.isContextual("module"):
+		return p.parseFlowDeclareModuleStatement(loc)
+	case p.is(token.EXPORT):
+		return p.parseFlowDeclareExportStatement(loc)
+	}
+
+	return &ast.FlowDeclareStatement{
+		StmtNode:    p.stmtNodeAt(loc),
+		Declaration: p.parseFlowDeclaration(),
+	}
+}
+
+func (p *Parser) parseFlowDeclaration() ast.IStmt {
+	switch {
+	case p.isVariableStatementStart():
+		return p.parseVariableStatement()
+	case p.is(token.FUNCTION):
+		return p.parseFunction(true, p.loc(), false)
+	case p.is(token.CLASS):
+		return p.parseFlowDeclareClassStatement()
+	case p.isAllowed(token.TYPE_TYPE), p.isAllowed(token.TYPE_OPAQUE):
+		return p.parseFlowTypeStatement()
+	case p.is(token.INTERFACE):
+		return p.parseFlowInterfaceStatement()
+	}
+
+	p.unexpectedToken()
+	p.next()
+
+	return nil
+}
+
+func (p *Parser) parseFlowDeclareClassStatement() *ast.FlowDeclareClassStatement {
+	loc := p.loc()
+
+	p.consumeExpected(token.CLASS)
+
+	stmt := &ast.FlowDeclareClassStatement{
+		StmtNode: p.stmtNodeAt(loc),
+		Name:     p.parseFlowTypeIdentifier(),
+	}
+
+	if p.isFlowTypeParametersStart() {
+		stmt.TypeParameters = p.parseFlowTypeParameters()
+	}
+
+	closeTypeScope := p.openTypeScope()
+
+	if p.is(token.EXTENDS) {
+		p.next()
+		stmt.Extends = p.parseFlowType()
+	}
+
+	if p.isContextual("mixins") {
+		p.next()
+		stmt.Mixins = p.parseFlowTypeList()
+	}
+
+	if p.isContextual("implements") {
+		p.next()
+		stmt.Implements = p.parseFlowTypeList()
+	}
+
+	closeTypeScope()
+
+	stmt.Body = p.parseFlowInterfaceBody(loc, true)
+
+	return stmt
+}
+
+func (p *Parser) parseFlowDeclareExportStatement(loc *file.Loc) *ast.FlowDeclareExportStatement {
+	p.consumeExpected(token.EXPORT)
+
+	stmt := &ast.FlowDeclareExportStatement{
+		StmtNode: p.stmtNodeAt(loc),
+	}
+
+	switch p.token {
+	case token.MULTIPLY:
+		stmt.Clause = p.parseExportNamespaceFromClause()
+		p.optionalSemicolon()
+	case token.LEFT_BRACE:
+		stmt.Clause, _ = p.parseExportNamedMaybeFromClause()
+		p.optionalSemicolon()
+	case token.DEFAULT:
+		p.next()
+		stmt.Default = true
+
+		if p.isAny(token.FUNCTION, token.CLASS) {
+			stmt.Declaration = p.parseFlowDeclaration()
+
+			break
+		}
+
+		// declare export default string
+		closeTypeScope := p.openTypeScope()
+		stmt.Type = p.parseFlowType()
+		closeTypeScope()
+
+		p.implicitSemicolon = true
+		p.optionalSemicolon()
+	default:
+		stmt.Declaration = p.parseFlowDeclaration()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseFlowDeclareModuleStatement(loc *file.Loc) ast.IStmt {
+	// module
+	p.next()
+
+	// declare module.exports: T
+	if p.is(token.PERIOD) {
+		p.next()
+
+		if !p.isContextual("exports") {
+			p.unexpectedToken()
+		}
+
+		p.next()
+
+		stmt := &ast.FlowDeclareModuleExportsStatement{
+			StmtNode: p.stmtNodeAt(loc),
+			Type:     p.parseFlowTypeAnnotation(),
+		}
+
+		p.implicitSemicolon = true
+		p.optionalSemicolon()
+
+		return stmt
+	}
+
+	stmt := &ast.FlowDeclareModuleStatement{
+		StmtNode: p.stmtNodeAt(loc),
+	}
+
+	if p.is(token.STRING) {
+		stmt.Name = p.literal
+		p.next()
+	} else if identifier := p.parseIdentifier(); identifier != nil {
+		stmt.Name = identifier.Name
+	}
+
+	p.consumeExpected(token.LEFT_BRACE)
+	stmt.Body = p.parseStatementList()
+	loc.End(p.consumeExpected(token.RIGHT_BRACE))
+
+	return stmt
+}
@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"yawp/parser/ast"
+	"yawp/parser/token"
+)
+
+func (p *Parser) isFlowEnumStart() bool {
+	return p.flow && p.isContextualFollowedBy("enum", token.IDENTIFIER)
+}
+
+// Flow enums have nothing in common with TypeScript ones:
+// members are not numbered, values are literals of the single type
+// and there is no reverse mapping
+func (p *Parser) parseFlowEnumStatement() *ast.FlowEnumStatement {
+	loc := p.loc()
+
+	// enum
+	p.next()
+
+	stmt := &ast.FlowEnumStatement{
+		StmtNode: p.stmtNodeAt(loc),
+		Name:     p.symbol(p.parseIdentifier(), ast.SDeclaration, ast.SRConst),
+		Members:  make([]*ast.FlowEnumMember, 0),
+	}
+
+	if p.isContextual("of") {
+		p.next()
+
+		switch p.literal {
+		case "string", "number", "boolean", "symbol":
+			stmt.Type = p.literal
+			p.next()
+		default:
+			p.unexpectedToken()
+		}
+	}
+
+	p.consumeExpected(token.LEFT_BRACE)
+
+	for p.until(token.RIGHT_BRACE) {
+		// enum E { A, ... } may have members not known statically
+		if p.is(token.DOTDOTDOT) {
+			p.next()
+			stmt.HasUnknownMembers = true
+
+			break
+		}
+
+		member := &ast.FlowEnumMember{
+			Loc: p.loc(),
+		}
+
+		identifier := p.parseIdentifierIncludingKeywords()
+
+		if identifier == nil {
+			p.unexpectedToken()
+		}
+
+		member.Name = identifier.Name
+
+		if p.is(token.ASSIGN) {
+			p.next()
+			member.Initializer = p.parseFlowEnumMemberInitializer(stmt.Type)
+		} else if stmt.Type == "number" || stmt.Type == "boolean" {
+			p.error(member.Loc, "Enum member %s of %s enum %s requires initializer", member.Name, stmt.Type, stmt.Name.Name)
+		}
+
+		stmt.Members = append(stmt.Members, member)
+
+		if !p.is(token.RIGHT_BRACE) {
+			p.consumeExpected(token.COMMA)
+		}
+	}
+
+	stmt.Loc.End(p.consumeExpected(token.RIGHT_BRACE))
+
+	return stmt
+}
+
+func (p *Parser) parseFlowEnumMemberInitializer(enumType string) ast.IExpr {
+	loc := p.loc()
+	initializer := p.parsePrimaryExpression()
+
+	switch initializer.(type) {
+	case *ast.StringLiteral:
+		if enumType == "" || enumType == "string" {
+			return initializer
+		}
+	case *ast.NumberLiteral:
+		if enumType == "" || enumType == "number" {
+			return initializer
+		}
+	case *ast.BooleanLiteral:
+		if enumType == "" || enumType == "boolean" {
+			return initializer
+		}
+	}
+
+	p.error(loc, "Invalid enum member initializer")
+
+	return nil
+}
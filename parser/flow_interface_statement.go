@@ -2,14 +2,20 @@ package parser
 
 import (
 	"yawp/parser/ast"
+	"yawp/parser/file"
 	"yawp/parser/token"
 )
 
-func (p *Parser) parseFlowInterfaceMethodParts() ([]interface{}, ast.FlowType) {
+func (p *Parser) parseFlowInterfaceMethodParts() ([]*ast.FlowFunctionParameter, ast.FlowType) {
 	p.consumeExpected(token.LEFT_PARENTHESIS)
+	params := p.parseFlowFunctionParameters()
 	p.consumeExpected(token.RIGHT_PARENTHESIS)
 
-	params := make([]interface{}, 0)
+	// return type is implicit any in TypeScript
+	if p.typescript && !p.is(token.COLON) {
+		return params, nil
+	}
+
 	retType := p.parseFlowTypeAnnotation()
 
 	return params, retType
@@ -39,6 +45,14 @@ func (p *Parser) parseFlowInterfaceBodyStatement() ast.FlowInterfaceBodyStatemen
 		return p.parseFlowInterfaceMethod()
 	}
 
+	if p.is(token.LEFT_BRACKET) {
+		if prop := p.parseFlowIndexerObjectProperty(); prop != nil {
+			return prop
+		}
+
+		return nil
+	}
+
 	if p.isIdentifierOrKeyword() {
 		identifier := p.parseFlowTypeIdentifierIncludingKeywords()
 
@@ -83,12 +97,55 @@ func (p *Parser) parseFlowInterfaceStatement() *ast.FlowInterfaceStatement {
 		typeParameters = p.parseFlowTypeParameters()
 	}
 
+	var extends []ast.FlowType
+
+	if p.is(token.EXTENDS) {
+		closeTypeScope := p.openTypeScope()
+		p.next()
+
+		extends = p.parseFlowTypeList()
+
+		closeTypeScope()
+	}
+
+	stmts := p.parseFlowInterfaceBody(loc, false)
+
+	return &ast.FlowInterfaceStatement{
+		StmtNode:       p.stmtNodeAt(loc),
+		Name:           name,
+		TypeParameters: typeParameters,
+		Extends:        extends,
+		Body:           stmts,
+	}
+}
+
+// parseFlowInterfaceBody parses members of interfaces and declared classes,
+// only the latter can have static members
+func (p *Parser) parseFlowInterfaceBody(loc *file.Loc, allowStatic bool) []ast.FlowInterfaceBodyStatement {
 	p.consumeExpected(token.LEFT_BRACE)
 
 	stmts := make([]ast.FlowInterfaceBodyStatement, 0)
 
 	for p.until(token.RIGHT_BRACE) {
-		stmts = append(stmts, p.parseFlowInterfaceBodyStatement())
+		if p.typescript {
+			stmts = append(stmts, p.parseTSTypeMember().(ast.FlowInterfaceBodyStatement))
+		} else if allowStatic && p.isFlowStaticMemberStart() {
+			p.next()
+
+			switch stmt := p.parseFlowInterfaceBodyStatement().(type) {
+			case *ast.FlowInterfaceMethod:
+				stmt.Static = true
+				stmts = append(stmts, stmt)
+			case *ast.FlowNamedObjectProperty:
+				stmt.Static = true
+				stmts = append(stmts, stmt)
+			case nil:
+			default:
+				stmts = append(stmts, stmt)
+			}
+		} else if stmt := p.parseFlowInterfaceBodyStatement(); stmt != nil {
+			stmts = append(stmts, stmt)
+		}
 
 		p.implicitSemicolon = true
 
@@ -101,10 +158,27 @@ func (p *Parser) parseFlowInterfaceStatement() *ast.FlowInterfaceStatement {
 
 	loc.End(p.consumeExpected(token.RIGHT_BRACE))
 
-	return &ast.FlowInterfaceStatement{
-		StmtNode:       p.stmtNodeAt(loc),
-		Name:           name,
-		TypeParameters: typeParameters,
-		Body:           stmts,
+	return stmts
+}
+
+// static(): void and static: number are members named static
+func (p *Parser) isFlowStaticMemberStart() bool {
+	if !p.is(token.STATIC) {
+		return false
 	}
+
+	tkn, _, _, _ := p.peek()
+
+	return tkn != token.LEFT_PARENTHESIS && tkn != token.LESS && tkn != token.COLON && tkn != token.QUESTION_MARK
+}
+
+func (p *Parser) parseFlowTypeList() []ast.FlowType {
+	list := []ast.FlowType{p.parseFlowType()}
+
+	for p.is(token.COMMA) {
+		p.next()
+		list = append(list, p.parseFlowType())
+	}
+
+	return list
 }
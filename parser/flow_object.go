@@ -62,6 +62,61 @@ func (p *Parser) parseFlowNamedObjectProperty() *ast.FlowNamedObjectProperty {
 	)
 }
 
+// [key: K]: V or [K]: V
+func (p *Parser) parseFlowIndexerObjectProperty() *ast.FlowIndexerObjectProperty {
+	prop := &ast.FlowIndexerObjectProperty{
+		Loc:     p.loc(),
+		KeyName: "",
+		KeyType: nil,
+		Value:   nil,
+	}
+
+	p.consumeExpected(token.LEFT_BRACKET)
+
+	if p.isIdentifierOrKeyword() {
+		isKeyword := p.tokenIsKeyword
+		identifier := p.parseIdentifierIncludingKeywords()
+
+		if identifier == nil {
+			p.unexpectedToken()
+			p.next()
+
+			return nil
+		}
+
+		if p.is(token.COLON) {
+			if isKeyword {
+				// keywords aren't legal identifiers
+				p.error(identifier.GetLoc(), "Cannot use keyword as type identifier")
+				p.next()
+
+				return nil
+			}
+
+			prop.KeyName = identifier.Name
+			prop.KeyType = p.parseFlowTypeAnnotation()
+		} else if p.is(token.RIGHT_BRACKET) {
+			prop.KeyType = &ast.FlowIdentifier{
+				Loc:  identifier.Loc,
+				Name: identifier.Name,
+			}
+		} else {
+			p.unexpectedToken()
+			p.next()
+		}
+	} else if p.is(token.RIGHT_BRACKET) {
+		p.unexpectedToken()
+	} else {
+		prop.KeyType = p.parseFlowType()
+	}
+
+	p.consumeExpected(token.RIGHT_BRACKET)
+
+	prop.Value = p.parseFlowTypeAnnotation()
+
+	return prop
+}
+
 func (p *Parser) parseFlowObjectProperties(exact bool) []ast.FlowObjectProperty {
 	var terminator token.Token
 
@@ -76,6 +131,13 @@ func (p *Parser) parseFlowObjectProperties(exact bool) []ast.FlowObjectProperty
 	for p.until(terminator) {
 		loc := p.loc()
 
+		if p.typescript {
+			props = append(props, p.parseTSTypeMember())
+			p.consumeTSTypeMemberSeparator(terminator)
+
+			continue
+		}
+
 		if p.isIdentifierOrKeyword() || p.isAny(token.PLUS, token.MINUS) {
 			prop := p.parseFlowNamedObjectProperty()
 
@@ -106,55 +168,9 @@ func (p *Parser) parseFlowObjectProperties(exact bool) []ast.FlowObjectProperty
 				})
 			}
 		} else if p.is(token.LEFT_BRACKET) {
-			p.next()
-
-			prop := &ast.FlowIndexerObjectProperty{
-				Loc:     loc,
-				KeyName: "",
-				KeyType: nil,
-				Value:   nil,
+			if prop := p.parseFlowIndexerObjectProperty(); prop != nil {
+				props = append(props, prop)
 			}
-
-			if p.isIdentifierOrKeyword() {
-				isKeyword := p.tokenIsKeyword
-				identifier := p.parseIdentifierIncludingKeywords()
-
-				if identifier == nil {
-					p.unexpectedToken()
-					p.next()
-					goto Next
-				}
-
-				if p.is(token.COLON) {
-					if isKeyword {
-						// keywords aren't legal identifiers
-						p.error(identifier.GetLoc(), "Cannot use keyword as type identifier")
-						p.next()
-						goto Next
-					}
-
-					prop.KeyName = identifier.Name
-					prop.KeyType = p.parseFlowTypeAnnotation()
-				} else if p.is(token.RIGHT_BRACKET) {
-					prop.KeyType = &ast.FlowIdentifier{
-						Loc:  identifier.Loc,
-						Name: identifier.Name,
-					}
-				} else {
-					p.unexpectedToken()
-					p.next()
-				}
-			} else if p.is(token.RIGHT_BRACKET) {
-				p.unexpectedToken()
-			} else {
-				prop.KeyType = p.parseFlowType()
-			}
-
-			p.consumeExpected(token.RIGHT_BRACKET)
-
-			prop.Value = p.parseFlowTypeAnnotation()
-
-			props = append(props, prop)
 		} else {
 			p.unexpectedToken()
 			p.next()
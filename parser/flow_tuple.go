@@ -12,7 +12,11 @@ func (p *Parser) parseFlowTupleType() *ast.FlowTupleType {
 	elements := make([]ast.FlowType, 0)
 
 	for p.until(token.RIGHT_BRACKET) {
-		elements = append(elements, p.parseFlowType())
+		if p.typescript {
+			elements = append(elements, p.parseTSTupleElement())
+		} else {
+			elements = append(elements, p.parseFlowType())
+		}
 
 		if !p.is(token.RIGHT_BRACKET) {
 			p.consumeExpected(token.COMMA)
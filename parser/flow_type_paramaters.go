@@ -30,6 +30,15 @@ func (p *Parser) parseFlowTypeParameters() []*ast.FlowTypeParameter {
 			p.next()
 		}
 
+		// variance and const modifiers, <in out T>, <const T>
+		for p.typescript && (p.isAny(token.IN, token.CONST) || p.isContextual("in") || p.isContextual("out")) {
+			if tkn, _, _, _ := p.peek(); tkn != token.IDENTIFIER {
+				break
+			}
+
+			p.next()
+		}
+
 		parameterNameLoc := p.loc()
 		parameter.Name = p.parseFlowTypeIdentifierIncludingKeywords()
 
@@ -39,7 +48,7 @@ func (p *Parser) parseFlowTypeParameters() []*ast.FlowTypeParameter {
 			continue
 		}
 
-		if p.is(token.COLON) {
+		if p.is(token.COLON) || p.typescript && p.is(token.EXTENDS) {
 			p.next()
 
 			parameter.Boundary = p.parseFlowType()
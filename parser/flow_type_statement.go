@@ -25,8 +25,25 @@ func (p *Parser) parseFlowTypeStatement() *ast.FlowTypeStatement {
 		typeParameters = p.parseFlowTypeParameters()
 	}
 
-	p.consumeExpected(token.ASSIGN)
-	value := p.parseFlowType()
+	var supertype, value ast.FlowType
+
+	// declare opaque type A hides the underlying type completely
+	hidden := opaque && p.scope.inDeclare
+
+	closeTypeScope := p.openTypeScope()
+
+	// opaque type A: Super = B
+	if opaque && p.is(token.COLON) {
+		p.next()
+		supertype = p.parseFlowType()
+	}
+
+	if !hidden || p.is(token.ASSIGN) {
+		p.consumeExpected(token.ASSIGN)
+		value = p.parseFlowType()
+	}
+
+	closeTypeScope()
 
 	p.implicitSemicolon = true
 	p.optionalSemicolon()
@@ -35,6 +52,7 @@ func (p *Parser) parseFlowTypeStatement() *ast.FlowTypeStatement {
 		StmtNode:       p.stmtNodeAt(loc),
 		Name:           name,
 		Opaque:         opaque,
+		Supertype:      supertype,
 		Type:           value,
 		TypeParameters: typeParameters,
 	}
@@ -30,7 +30,7 @@ func (p *Parser) parseForIn(loc *file.Loc, into ast.IStmt) *ast.ForInStatement {
 	}
 }
 
-func (p *Parser) parseForOf(loc *file.Loc, into ast.IStmt) *ast.ForOfStatement {
+func (p *Parser) parseForOf(loc *file.Loc, into ast.IStmt, await bool) *ast.ForOfStatement {
 	// Already have consumed "<into> of"
 
 	source := p.parseExpression()
@@ -38,6 +38,7 @@ func (p *Parser) parseForOf(loc *file.Loc, into ast.IStmt) *ast.ForOfStatement {
 
 	return &ast.ForOfStatement{
 		StmtNode: p.stmtNodeAt(loc),
+		Await:    await,
 		Left:     into,
 		Right:    source,
 		Body:     p.parseIterationStatement(),
@@ -91,6 +92,14 @@ func (p *Parser) getForKind() (isForIn, isForOf, isFor bool) {
 func (p *Parser) parseForStatement() ast.IStmt {
 	start := p.loc()
 	p.consumeExpected(token.FOR)
+
+	// for await is allowed in async functions only, await is identifier elsewhere
+	await := p.is(token.AWAIT)
+
+	if await {
+		p.next()
+	}
+
 	p.consumeExpected(token.LEFT_PARENTHESIS)
 
 	p.useSymbolsScope(ast.SSTBlock)
@@ -98,6 +107,10 @@ func (p *Parser) parseForStatement() ast.IStmt {
 
 	// for (;
 	if p.is(token.SEMICOLON) {
+		if await {
+			p.unexpectedToken()
+		}
+
 		p.next()
 		return p.parseFor(start, nil)
 	}
@@ -117,6 +130,10 @@ func (p *Parser) parseForStatement() ast.IStmt {
 
 		isForIn, isForOf, isFor := p.getForKind()
 
+		if await && !isForOf {
+			p.unexpectedToken()
+		}
+
 		if isForIn || isForOf {
 			if len(left.List) > 1 {
 				p.error(start, fmt.Sprintf("for-%s can not declare multiple variables", token.IN.String()))
@@ -129,7 +146,7 @@ func (p *Parser) parseForStatement() ast.IStmt {
 			if isForIn {
 				return p.parseForIn(start, left)
 			} else {
-				return p.parseForOf(start, left)
+				return p.parseForOf(start, left, await)
 			}
 		}
 
@@ -175,6 +192,10 @@ func (p *Parser) parseForStatement() ast.IStmt {
 
 	isForIn, isForOf, isFor := p.getForKind()
 
+	if await && !isForOf {
+		p.unexpectedToken()
+	}
+
 	// consume in/for/;
 	p.next()
 
@@ -212,7 +233,7 @@ func (p *Parser) parseForStatement() ast.IStmt {
 	}
 
 	if isForOf {
-		return p.parseForOf(start, left)
+		return p.parseForOf(start, left, await)
 	}
 
 	if isFor {
@@ -13,6 +13,10 @@ func (p *Parser) parseRestParameterFollowedBy(value token.Token) *ast.RestParame
 		Binder: p.parseBinder(),
 	}
 
+	if p.is(token.COLON) {
+		restParameter.SetTypeAnnotation(p.parseFlowTypeAnnotation())
+	}
+
 	p.shouldBe(value)
 
 	return restParameter
@@ -20,8 +24,26 @@ func (p *Parser) parseRestParameterFollowedBy(value token.Token) *ast.RestParame
 
 func (p *Parser) parseFunctionParameterEndingBy(ending token.Token) ast.FunctionParameter {
 	var parameter ast.FunctionParameter
+	var modifiers ast.Flags
 	loc := p.loc()
 
+	if p.typescript {
+		// function f(this: T) only types this
+		if p.is(token.THIS) {
+			p.next()
+			p.parseFlowTypeAnnotation()
+
+			if !p.is(ending) {
+				p.consumeExpected(token.COMMA)
+			}
+
+			return nil
+		}
+
+		// constructor(private a: T) declares a property
+		modifiers, _ = p.parseTSModifiers(tsParameterModifiers)
+	}
+
 	switch p.token {
 	case token.DOTDOTDOT:
 		parameter = p.parseRestParameterFollowedBy(ending)
@@ -29,6 +51,7 @@ func (p *Parser) parseFunctionParameterEndingBy(ending token.Token) ast.Function
 		parameter = &ast.IdentifierParameter{
 			Id:           p.symbol(p.parseIdentifier(), ast.SDeclaration, ast.SRFnParam),
 			DefaultValue: nil,
+			Modifiers:    modifiers,
 		}
 	case token.LEFT_BRACKET, token.LEFT_BRACE:
 		parameter = &ast.PatternParameter{
@@ -83,7 +106,9 @@ func (p *Parser) parseFunctionParameterList() *ast.FunctionParameters {
 	var list []ast.FunctionParameter
 
 	for !p.is(token.RIGHT_PARENTHESIS) && !p.is(token.EOF) {
-		list = append(list, p.parseFunctionParameterEndingBy(token.RIGHT_PARENTHESIS))
+		if parameter := p.parseFunctionParameterEndingBy(token.RIGHT_PARENTHESIS); parameter != nil {
+			list = append(list, parameter)
+		}
 	}
 
 	loc.End(p.consumeExpected(token.RIGHT_PARENTHESIS))
@@ -137,10 +162,31 @@ func (p *Parser) parseFunction(declaration bool, loc *file.Loc, async bool) *ast
 	node.Parameters = p.parseFunctionParameterList()
 
 	if p.is(token.COLON) {
-		node.ReturnType = p.parseFlowTypeAnnotation()
+		node.ReturnType, node.Predicate = p.parseFlowReturnType()
+	}
+
+	// overloads and ambient declarations have no body
+	if (p.typescript || p.scope.inDeclare) && declaration && !p.is(token.LEFT_BRACE) {
+		p.optionalSemicolon()
+
+		return node
 	}
 
 	p.parseFunctionNodeBody(node)
 
 	return node
 }
+
+// function declarations without a body only declare types
+func (p *Parser) parseFunctionStatement(loc *file.Loc, async bool) ast.IStmt {
+	fn := p.parseFunction(true, loc, async)
+
+	if fn.Body == nil {
+		return &ast.TSDeclareStatement{
+			StmtNode:    p.stmtNodeAt(loc),
+			Declaration: fn,
+		}
+	}
+
+	return fn
+}
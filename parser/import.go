@@ -6,7 +6,7 @@ import (
 )
 
 func (p *Parser) parseImportDefaultClause(stmt *ast.ImportStatement) {
-	identifier := p.symbol(p.parseIdentifier(), ast.SRead, ast.SRImport)
+	identifier := p.symbol(p.parseIdentifier(), ast.SDeclaration, ast.SRImport)
 	moduleIdentifier := &ast.Identifier{
 		ExprNode: p.exprNodeAt(identifier.Loc),
 		Name:     "default",
@@ -26,7 +26,25 @@ func (p *Parser) parseImportDefaultClause(stmt *ast.ImportStatement) {
 func (p *Parser) parseImportNamedClause(stmt *ast.ImportStatement) {
 	p.consumeExpected(token.LEFT_BRACE)
 
+	specifiers := 0
+
 	for !p.is(token.RIGHT_BRACE) {
+		typeOnly := false
+		specifiers++
+
+		// import { type A, typeof B }, types are erased with the whole specifier
+		if p.isContextual("type") || (!p.typescript && p.is(token.TYPEOF)) {
+			if tkn, literal, isKeyword, _ := p.peek(); (tkn == token.IDENTIFIER || isKeyword) && literal != "as" {
+				typeOnly = true
+				p.next()
+			}
+		}
+
+		// import { type }
+		if p.is(token.TYPE_TYPE) {
+			p.token = token.IDENTIFIER
+		}
+
 		if !p.is(token.IDENTIFIER) {
 			p.unexpectedToken()
 			return
@@ -43,18 +61,25 @@ func (p *Parser) parseImportNamedClause(stmt *ast.ImportStatement) {
 			localIdentifier = p.symbol(p.parseIdentifier(), ast.SDeclaration, ast.SRImport)
 		}
 
-		stmt.Imports = append(stmt.Imports, &ast.ImportClause{
-			ExprNode:         p.exprNodeAt(moduleIdentifier.Loc),
-			Namespace:        false,
-			ModuleIdentifier: moduleIdentifier,
-			LocalIdentifier:  localIdentifier,
-		})
+		if !typeOnly {
+			stmt.Imports = append(stmt.Imports, &ast.ImportClause{
+				ExprNode:         p.exprNodeAt(moduleIdentifier.Loc),
+				Namespace:        false,
+				ModuleIdentifier: moduleIdentifier,
+				LocalIdentifier:  localIdentifier,
+			})
+		}
 
 		p.consumePossible(token.COMMA)
 	}
 
 	p.consumeExpected(token.RIGHT_BRACE)
 
+	// import { type A } is erased completely, unlike import {} from 'a'
+	if specifiers > 0 && len(stmt.Imports) == 0 {
+		stmt.Kind = ast.IKType
+	}
+
 	stmt.HasNamedClause = true
 }
 
@@ -91,17 +116,31 @@ func (p *Parser) parseImportFromClause(stmt *ast.ImportStatement) {
 	}
 }
 
-func (p *Parser) parseImportDeclaration() *ast.ImportStatement {
+// import type from 'a' and import type, { a } from 'a' import default named type
+func (p *Parser) isImportedTypeIdentifier() bool {
+	tkn, literal, _, _ := p.peek()
+
+	return tkn == token.COMMA || literal == "from"
+}
+
+func (p *Parser) parseImportDeclaration() ast.IStmt {
 	loc := p.loc()
 
 	p.consumeExpected(token.IMPORT)
+
+	if p.typescript && p.is(token.IDENTIFIER) {
+		if tkn, _, _, _ := p.peek(); tkn == token.ASSIGN {
+			return p.parseTSImportEqualsStatement(loc)
+		}
+	}
+
 	stmt := &ast.ImportStatement{
 		StmtNode: p.stmtNodeAt(loc),
 		Imports:  make([]*ast.ImportClause, 0),
 	}
 
 	p.allowToken(token.TYPE_TYPE)
-	if p.is(token.TYPE_TYPE) {
+	if p.is(token.TYPE_TYPE) && !p.isImportedTypeIdentifier() {
 		stmt.Kind = ast.IKType
 		p.next()
 	} else if p.is(token.TYPEOF) {
@@ -115,6 +154,10 @@ func (p *Parser) parseImportDeclaration() *ast.ImportStatement {
 	case token.MULTIPLY:
 		// import * as identifier
 		p.parseImportNamespaceClause(stmt)
+	case token.TYPE_TYPE:
+		// import type from 'a'
+		p.token = token.IDENTIFIER
+		p.parseImportDefaultClause(stmt)
 	case token.IDENTIFIER:
 		// import defaultExport
 		p.parseImportDefaultClause(stmt)
@@ -161,12 +204,12 @@ func (p *Parser) parseImportCall() ast.IExpr {
 	p.consumeExpected(token.IMPORT)
 	p.consumeExpected(token.LEFT_PARENTHESIS)
 
-	loc = loc.End(p.consumeExpected(token.RIGHT_PARENTHESIS))
-
 	call := &ast.ImportCall{
 		ExprNode:   p.exprNodeAt(loc),
 		Expression: p.parseAssignmentExpression(),
 	}
 
+	loc.End(p.consumeExpected(token.RIGHT_PARENTHESIS))
+
 	return call
 }
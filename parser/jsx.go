@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"strings"
 	"yawp/parser/ast"
 	"yawp/parser/file"
 	"yawp/parser/token"
@@ -13,10 +14,18 @@ func areElementNamesEqual(a, b *ast.JSXElementName) bool {
 func (p *Parser) parseJSXElementName() *ast.JSXElementName {
 	var left ast.IExpr
 
-	rootIdentifier := p.parseIdentifier()
+	rootIdentifier := p.parseJSXDashedName(p.parseIdentifier())
 	stringName := rootIdentifier.Name
 	left = rootIdentifier
 
+	// custom-element
+	if strings.Contains(stringName, "-") {
+		return &ast.JSXElementName{
+			Expression: rootIdentifier,
+			StringName: stringName,
+		}
+	}
+
 	if p.is(token.COLON) {
 		// x:tag
 		p.consumeExpected(token.COLON)
@@ -68,6 +77,32 @@ func (p *Parser) parseJSXElementName() *ast.JSXElementName {
 	}
 }
 
+// parseJSXDashedName continues the name of the element or the attribute with the dashed parts,
+// those have no spaces in between, e.g. aria-label
+func (p *Parser) parseJSXDashedName(id *ast.Identifier) *ast.Identifier {
+	if id == nil {
+		return nil
+	}
+
+	end := id.Loc.From + file.Idx(len(id.Name))
+
+	for p.is(token.MINUS) && p.tokenOffset == end {
+		p.next()
+		id.Name += "-"
+		end++
+
+		if p.tokenOffset == end && matchIdentifier.MatchString(p.literal) {
+			id.Name += p.literal
+			end += file.Idx(len(p.literal))
+			p.next()
+		}
+	}
+
+	id.Loc.To = end
+
+	return id
+}
+
 func (p *Parser) parseJSXChild() ast.JSXChild {
 	switch p.token {
 	case token.LESS:
@@ -83,8 +118,7 @@ func (p *Parser) parseJSXChild() ast.JSXChild {
 			exp = p.parseAssignmentExpression()
 		}
 
-		p.jsxTextParseFrom = int(p.tokenOffset)
-		p.consumeExpected(token.RIGHT_BRACE)
+		p.jsxTextParseFrom = int(p.consumeExpected(token.RIGHT_BRACE))
 
 		return &ast.JSXChildExpression{
 			IExpr: exp,
@@ -117,9 +151,10 @@ func (p *Parser) parseJSXElementAttributes() []ast.JSXAttribute {
 
 	// until we meet /> or > or EOF
 	for !p.is(token.EOF) && !p.is(token.JSX_TAG_SELF_CLOSE) && !p.is(token.GREATER) {
-		if p.is(token.IDENTIFIER) {
+		if matchIdentifier.MatchString(p.literal) {
+			// keywords are names of attributes as well, e.g. class
 			attribute := &ast.JSXNamedAttribute{
-				Name: p.parseIdentifier(),
+				Name: p.parseJSXDashedName(p.parseIdentifierIncludingKeywords()),
 			}
 
 			// attribute with initializer
@@ -168,7 +203,7 @@ func (p *Parser) parseJSXElementAttributes() []ast.JSXAttribute {
 func (p *Parser) parseJSXFragment() *ast.JSXFragment {
 	loc := p.loc()
 
-	p.consumeExpected(token.JSX_FRAGMENT_START)
+	p.jsxTextParseFrom = int(p.consumeExpected(token.JSX_FRAGMENT_START))
 	children := make([]ast.JSXChild, 0)
 
 	for p.until(token.JSX_FRAGMENT_END) {
@@ -176,8 +211,7 @@ func (p *Parser) parseJSXFragment() *ast.JSXFragment {
 	}
 
 	loc.End(p.consumeExpected(token.JSX_FRAGMENT_END))
-	// it's 3 chars wide token
-	p.jsxTextParseFrom = int(loc.To) + 2
+	p.jsxTextParseFrom = int(loc.To)
 
 	return &ast.JSXFragment{
 		ExprNode: p.exprNodeAt(loc),
@@ -206,15 +240,13 @@ func (p *Parser) parseJSXElement() *ast.JSXElement {
 	// self closing element />
 	if p.is(token.JSX_TAG_SELF_CLOSE) {
 		elm.Loc.End(p.consumeExpected(token.JSX_TAG_SELF_CLOSE))
-		// it's a 2 chars wide token
-		p.jsxTextParseFrom = int(elm.Loc.To) + 1
+		p.jsxTextParseFrom = int(elm.Loc.To)
 
 		return elm
 	}
 
-	// end of element >
-	p.jsxTextParseFrom = int(p.tokenOffset)
-	p.consumeExpected(token.GREATER)
+	// end of element >, the text starts right after
+	p.jsxTextParseFrom = int(p.consumeExpected(token.GREATER))
 
 	// until </
 	for p.until(token.JSX_TAG_CLOSE) {
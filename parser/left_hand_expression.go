@@ -22,6 +22,8 @@ func (p *Parser) parseLeftHandSideExpression() ast.IExpr {
 			left = p.parseBracketMember(left)
 		} else if p.is(token.TEMPLATE_QUOTE) {
 			left = p.parseTaggedTemplateExpression(left)
+		} else if p.isTSNonNullAssertion() {
+			left = p.parseTSNonNullAssertion(left)
 		} else {
 			break
 		}
@@ -51,7 +53,7 @@ func (p *Parser) parseLeftHandSideExpressionAllowCall() ast.IExpr {
 			left = p.parseOptionalExpression(left)
 		} else if p.is(token.LEFT_BRACKET) {
 			left = p.parseBracketMember(left)
-		} else if p.isFlowTypeArgumentsStart() {
+		} else if (p.typescript || p.flow) && p.isFlowTypeArgumentsStart() {
 			snapshot := p.snapshot()
 			typeArguments := p.tryParseFlowTypeArguments()
 
@@ -70,6 +72,8 @@ func (p *Parser) parseLeftHandSideExpressionAllowCall() ast.IExpr {
 			left = p.parseCallExpression(left, nil)
 		} else if p.is(token.TEMPLATE_QUOTE) {
 			left = p.parseTaggedTemplateExpression(left)
+		} else if p.isTSNonNullAssertion() {
+			left = p.parseTSNonNullAssertion(left)
 		} else {
 			break
 		}
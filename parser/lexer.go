@@ -4,6 +4,7 @@ import (
 	"errors"
 	"regexp"
 	"strconv"
+	"strings"
 	"unicode"
 	"unicode/utf8"
 
@@ -117,6 +118,7 @@ func (p *Parser) scan() (tkn token.Token, literal string, idx file.Idx) {
 					token.BREAK,
 					token.THROW, // A newline after a throw is not allowed, but we need to detect it
 					token.RETURN,
+					token.YIELD, // the same for the argument of yield
 					token.CONTINUE,
 					token.DEBUGGER:
 						p.insertSemicolon = true
@@ -133,7 +135,7 @@ func (p *Parser) scan() (tkn token.Token, literal string, idx file.Idx) {
 
 				case token.TYPE_TYPE:
 					p.insertSemicolon = true
-					if !p.scope.inModuleRoot() {
+					if !p.scope.inModuleRoot() || !p.typescript && !p.flow {
 						tkn = token.IDENTIFIER
 					}
 					return
@@ -146,11 +148,17 @@ func (p *Parser) scan() (tkn token.Token, literal string, idx file.Idx) {
 					token.TYPE_BOOLEAN,
 					token.TYPE_ANY,
 					token.TYPE_OPAQUE:
+						p.insertSemicolon = true
 						if !p.scope.inType {
 							tkn = token.IDENTIFIER
 						}
 						return
 
+				case token.VOID:
+					// void is a type as well
+					p.insertSemicolon = p.scope.inType
+					return
+
 				case token.AWAIT:
 					p.insertSemicolon = true
 					if !p.scope.allowAwait {
@@ -247,6 +255,13 @@ func (p *Parser) scan() (tkn token.Token, literal string, idx file.Idx) {
 					insertSemicolon = true
 				}
 			case '*':
+				if p.flowComment && p.chr == '/' {
+					// end of /*:: */
+					p.read()
+					p.flowComment = false
+					continue
+				}
+
 				if p.chr == '*' {
 					p.read()
 					if p.chr == '=' {
@@ -263,7 +278,12 @@ func (p *Parser) scan() (tkn token.Token, literal string, idx file.Idx) {
 					p.skipSingleLineComment()
 					continue
 				} else if p.chr == '*' {
-					p.skipMultiLineComment()
+					if p.flow && !p.flowComment && p.skipFlowCommentStart() {
+						p.flowComment = true
+					} else {
+						p.skipMultiLineComment()
+					}
+
 					continue
 				} else if p.chr == '>' {
 					p.read()
@@ -296,6 +316,7 @@ func (p *Parser) scan() (tkn token.Token, literal string, idx file.Idx) {
 			case '>':
 				// shifts are not allowed inside of type
 				if p.scope.inType {
+					insertSemicolon = true
 					tkn = token.GREATER
 				} else {
 					tkn = p.switchAssignment6(
@@ -451,6 +472,36 @@ func (p *Parser) skipSingleLineComment() {
 	}
 }
 
+// Flow types can be written in comments to keep the code valid js:
+// /*: T */ for annotations, /*:: type A = T */ and /*flow-include type A = T */ for anything else
+func (p *Parser) skipFlowCommentStart() bool {
+	offset := p.chrOffset + 1
+
+	for offset < p.length && (p.src[offset] == ' ' || p.src[offset] == '\t') {
+		offset++
+	}
+
+	rest := p.src[offset:]
+	skip := 0
+
+	switch {
+	case strings.HasPrefix(rest, "::"):
+		skip = 2
+	case strings.HasPrefix(rest, ":"):
+		// colon is a part of annotation
+	case strings.HasPrefix(rest, "flow-include"):
+		skip = len("flow-include")
+	default:
+		return false
+	}
+
+	for p.chrOffset < offset+skip {
+		p.read()
+	}
+
+	return true
+}
+
 func (p *Parser) skipMultiLineComment() {
 	p.read()
 	for p.chr >= 0 {
@@ -506,7 +557,8 @@ func (p *Parser) skipLineWhiteSpace() {
 }
 
 func (p *Parser) scanNumberRemainder(base int) {
-	for digitValue(p.chr) < base {
+	// numeric separators are allowed between digits only
+	for digitValue(p.chr) < base || p.chr == '_' && digitValue(p.peekChr()) < base {
 		p.read()
 	}
 }
@@ -642,6 +694,7 @@ func (p *Parser) scanNumericLiteral(decimalPoint bool) (token.Token, string) {
 
 	offset := p.chrOffset
 	tkn := token.NUMBER
+	integer := !decimalPoint // BigInt literals are integers with n suffix
 
 	if decimalPoint {
 		offset--
@@ -715,12 +768,14 @@ func (p *Parser) scanNumericLiteral(decimalPoint bool) (token.Token, string) {
 
 float:
 	if p.chr == '.' {
+		integer = false
 		p.read()
 		p.scanNumberRemainder(10)
 	}
 
 exponent:
 	if p.chr == 'e' || p.chr == 'E' {
+		integer = false
 		p.read()
 		if p.chr == '-' || p.chr == '+' {
 			p.read()
@@ -736,6 +791,10 @@ exponent:
 binary:
 hexadecimal:
 octal:
+	if p.chr == 'n' && integer {
+		p.read()
+	}
+
 	if isIdentifierStart(p.chr) || isDecimalDigit(p.chr) {
 		return token.ILLEGAL, p.src[offset:p.chrOffset]
 	}
@@ -30,17 +30,18 @@ func (p *Parser) parseDotMember(left ast.IExpr) ast.IExpr {
 
 	p.scope.allowIn = wasAllowIn
 
-	// this.#bla
-	if p.is(token.HASH) && p.scope.inClass {
-		if leftThisExp, ok := left.(*ast.ThisExpression); ok {
-			p.consumeExpected(token.HASH)
+	kind := ast.MemberKind(ast.MKObject)
 
-			leftThisExp.Private = true
-		} else {
+	// a.#b is allowed anywhere inside of the class body
+	if p.is(token.HASH) {
+		if !p.scope.allowPrivateNames {
 			p.unexpectedToken()
 
 			return nil
 		}
+
+		p.next()
+		kind = ast.MKPrivate
 	}
 
 	identifier := p.parseIdentifierIncludingKeywords()
@@ -54,7 +55,7 @@ func (p *Parser) parseDotMember(left ast.IExpr) ast.IExpr {
 	return &ast.MemberExpression{
 		Left:  left,
 		Right: identifier,
-		Kind:  ast.MKObject,
+		Kind:  kind,
 	}
 }
 
@@ -31,8 +31,6 @@ func (p *Parser) parseObjectPropertyName() ast.ObjectPropertyName {
 
 		return id
 	}
-
-	return nil
 }
 
 func (p *Parser) parseObjectPropertyComputedName() ast.ObjectPropertyName {
@@ -60,7 +58,7 @@ func (p *Parser) parseObjectPropertyFromShorthand(propertyName ast.ObjectPropert
 
 			return &ast.ObjectPropertyValue{
 				PropertyName: propertyName,
-				Value:        propertyStringName,
+				Value:        p.symbol(propertyStringName.Copy(), ast.SRead, ast.SRUnknown),
 			}
 		}
 	}
@@ -75,12 +73,23 @@ func (p *Parser) parseObjectPropertyMethodShorthand(
 	generator bool,
 	propertyName ast.ObjectPropertyName,
 ) *ast.ObjectPropertyValue {
+	var typeParameters []*ast.FlowTypeParameter
+
+	if p.isFlowTypeParametersStart() {
+		typeParameters = p.parseFlowTypeParameters()
+	}
+
 	parameterList := p.parseFunctionParameterList()
 	functionLiteral := &ast.FunctionLiteral{
-		Node:       p.nodeAt(loc),
-		Async:      async,
-		Generator:  generator,
-		Parameters: parameterList,
+		Node:           p.nodeAt(loc),
+		Async:          async,
+		Generator:      generator,
+		TypeParameters: typeParameters,
+		Parameters:     parameterList,
+	}
+
+	if p.is(token.COLON) {
+		functionLiteral.ReturnType = p.parseFlowTypeAnnotation()
 	}
 
 	p.parseFunctionNodeBody(functionLiteral)
@@ -88,24 +97,14 @@ func (p *Parser) parseObjectPropertyMethodShorthand(
 	return &ast.ObjectPropertyValue{
 		PropertyName: propertyName,
 		Value:        functionLiteral,
+		Method:       true,
 	}
 }
 
 func (p *Parser) parseObjectPropertyValue(loc *file.Loc, propertyName ast.ObjectPropertyName) *ast.ObjectPropertyValue {
 	// Object function shorthand
-	if p.is(token.LEFT_PARENTHESIS) {
-		parameterList := p.parseFunctionParameterList()
-		functionLiteral := &ast.FunctionLiteral{
-			Node:       p.nodeAt(loc),
-			Parameters: parameterList,
-		}
-
-		p.parseFunctionNodeBody(functionLiteral)
-
-		return &ast.ObjectPropertyValue{
-			PropertyName: propertyName,
-			Value:        functionLiteral,
-		}
+	if p.is(token.LEFT_PARENTHESIS) || p.isFlowTypeParametersStart() {
+		return p.parseObjectPropertyMethodShorthand(loc, false, false, propertyName)
 	}
 
 	if p.is(token.COLON) {
@@ -174,7 +173,7 @@ func (p *Parser) parseObjectProperty() ast.ObjectProperty {
 		// [...](){} / [...]: ...
 		case *ast.StringLiteral, *ast.NumberLiteral, *ast.ComputedName:
 			switch p.token {
-			case token.LEFT_PARENTHESIS:
+			case token.LEFT_PARENTHESIS, token.LESS:
 				return p.parseObjectPropertyMethodShorthand(loc, false, false, propertyName)
 			case token.COLON:
 				p.next()
@@ -214,6 +213,11 @@ func (p *Parser) parseObjectProperty() ast.ObjectProperty {
 						Node:       p.nodeAt(loc),
 						Parameters: parameterList,
 					}
+
+					if p.is(token.COLON) {
+						functionLiteral.ReturnType = p.parseFlowTypeAnnotation()
+					}
+
 					p.parseFunctionNodeBody(functionLiteral)
 
 					if accessor == "set" {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io"
 	"io/ioutil"
+	"path"
 	"yawp/parser/ast"
 	"yawp/parser/file"
 	"yawp/parser/token"
@@ -38,12 +39,20 @@ type Parser struct {
 
 	advanceLine bool
 
+	flowComment bool // inside of /*:: */, the content of comment is parsed as code
+
 	genericTypeParametersMode                  bool
 	forbidUnparenthesizedFunctionType          bool
 	allowPatternBindingLeftHandSideExpressions bool
 
 	jsxTextParseFrom int
 
+	typescript bool // .ts, .tsx, .mts and .cts sources
+	jsx        bool // everything but plain .ts sources
+	flow       bool // JavaScript sources, but those marked @noflow
+
+	pragmas ast.Pragmas // of the leading comments, see readPragmas
+
 	err error
 
 	file *file.File
@@ -52,6 +61,9 @@ type Parser struct {
 }
 
 func newParser(filename, src string) *Parser {
+	ext := path.Ext(filename)
+	typescript := ext == ".ts" || ext == ".tsx" || ext == ".mts" || ext == ".cts"
+
 	return &Parser{
 		chr:    ' ', // This is set so we can start scanning by skipping whitespace
 		chrCol: 1,
@@ -60,6 +72,11 @@ func newParser(filename, src string) *Parser {
 		length: len(src),
 		file:   file.NewFile(filename, src),
 		scope:  &Scope{},
+
+		typescript: typescript,
+		jsx:        ext != ".ts" && ext != ".mts" && ext != ".cts",
+		flow:       !typescript,
+
 		symbolsScope: &ast.SymbolsScope{
 			Type:     ast.SSTModule,
 			Symbols:  make([]*ast.Symbol, 0),
@@ -121,6 +138,8 @@ func ParseModule(filename string, src interface{}) (*ast.Module, error) {
 }
 
 func (p *Parser) parse() (*ast.Module, error) {
+	p.readPragmas()
+
 	if !p.tryNext() {
 		return nil, p.err
 	}
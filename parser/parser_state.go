@@ -9,6 +9,8 @@ type ParserSnapshot struct {
 	wasNewLine        bool
 	tokenOffset       file.Idx
 	token             token.Token
+	tokenIsKeyword    bool
+	tokenCol          int
 	line              int
 	col               int
 	nextChrOffset     int
@@ -18,6 +20,7 @@ type ParserSnapshot struct {
 	parsedStr         string
 	insertSemicolon   bool
 	implicitSemicolon bool
+	flowComment       bool
 }
 
 func (p *Parser) snapshot() *ParserSnapshot {
@@ -27,6 +30,8 @@ func (p *Parser) snapshot() *ParserSnapshot {
 		col:               p.chrCol,
 		tokenOffset:       p.tokenOffset,
 		token:             p.token,
+		tokenIsKeyword:    p.tokenIsKeyword,
+		tokenCol:          p.tokenCol,
 		nextChrOffset:     p.nextChrOffset,
 		chrOffset:         p.chrOffset,
 		chr:               p.chr,
@@ -34,6 +39,7 @@ func (p *Parser) snapshot() *ParserSnapshot {
 		parsedStr:         p.parsedSrc,
 		insertSemicolon:   p.insertSemicolon,
 		implicitSemicolon: p.implicitSemicolon,
+		flowComment:       p.flowComment,
 	}
 }
 
@@ -43,6 +49,8 @@ func (p *Parser) toSnapshot(state *ParserSnapshot) {
 	p.chrCol = state.col
 	p.tokenOffset = state.tokenOffset
 	p.token = state.token
+	p.tokenIsKeyword = state.tokenIsKeyword
+	p.tokenCol = state.tokenCol
 	p.nextChrOffset = state.nextChrOffset
 	p.chrOffset = state.chrOffset
 	p.chr = state.chr
@@ -50,4 +58,5 @@ func (p *Parser) toSnapshot(state *ParserSnapshot) {
 	p.parsedSrc = state.parsedStr
 	p.insertSemicolon = state.insertSemicolon
 	p.implicitSemicolon = state.implicitSemicolon
+	p.flowComment = state.flowComment
 }
@@ -46,3 +46,146 @@ func TestJSXAmbiguities(t *testing.T) {
 	assert(`type T = | a & b | c`, nil)
 	assert(`type T = a & (b | f) | c`, nil)
 }
+
+func TestJSXNames(t *testing.T) {
+	assert := makeAssert(t)
+
+	assert(`t=<div aria-label="a" data-x-y={1} class="b" for="c" />`, nil)
+	assert(`t=<my-element><svg:rect /></my-element>`, nil)
+}
+
+func TestTypeScript(t *testing.T) {
+	assert := makeFileAssert(t, "test.ts")
+
+	assert(`let a: string = 'a'`, nil)
+	assert(`let a!: string`, nil)
+	assert(`let a = b as unknown as string`, nil)
+	assert(`let a = b satisfies C`, nil)
+	assert(`let a = b!.c!`, nil)
+	assert(`let a = <string>b`, nil)
+	assert(`x = y as any
+z()`, nil)
+
+	assert(`interface A extends B<C> { a?: string; readonly b: number; [k: string]: any }`, nil)
+	assert(`type A<T> = { readonly [K in keyof T]-?: T[K] extends infer U ? U : never }`, nil)
+	assert(`type A = [a: string, b?: number, ...c: D[]]`, nil)
+
+	assert(`function f<T>(this: Window, a?: T, ...r: T[]): a is T { return true }`, nil)
+	assert(`function f(a: string): void
+function f(a) {}`, nil)
+	assert(`let o = { m<T>(a: T): T { return a }, get a(): string { return '' } }`, nil)
+
+	assert(`enum E { A, B = 2, C = 'c' }`, nil)
+	assert(`namespace A.B { export const c = 1 }`, nil)
+	assert(`declare module 'x' { export const a: string }`, nil)
+	assert(`abstract class A<T> extends B<T> implements C { private x: number = 1; declare y: string; constructor(private readonly a?: string) { super() } abstract m(): void }`, nil)
+
+	assert(`import x = require('x')`, nil)
+	assert(`import { type A, B } from 'a'`, nil)
+	assert(`export type { A } from 'a'`, nil)
+	assert(`export default interface A {}`, nil)
+	assert(`export = a`, nil)
+
+	assert(`let a: = 1`, "1:8 Unexpected token =")
+	assert(`enum E { A B }`, "1:12 Unexpected identifier")
+	assert(`let a = b as`, "1:13 Unexpected end of input")
+}
+
+func TestFlowDeclare(t *testing.T) {
+	assert := makeAssert(t)
+
+	assert(`declare var a: string; declare let b: number; declare const c: number`, nil)
+	assert(`declare function f(a: string): void;
+f('a')`, nil)
+	assert(`declare class A<T> extends B<T> mixins C implements D { static x: number; m(): void; [k: string]: any; static(): void }`, nil)
+	assert(`declare type A = string; declare opaque type B; declare opaque type C: string; declare interface I {}`, nil)
+	assert(`declare export function f(): void; declare export default string; declare export { a }; declare export * from 'x'`, nil)
+	assert(`declare module 'x' { declare export class A {} declare module.exports: { a: A } }`, nil)
+	assert(`opaque type A: string = string; export opaque type B = string`, nil)
+
+	// declare is not a reserved word
+	assert(`declare(a); declare = 1; declare
+foo`, nil)
+
+	assert(`declare module.foo: number`, "1:16 Unexpected identifier")
+}
+
+func TestFlow(t *testing.T) {
+	assert := makeAssert(t)
+
+	assert(`function f(a /*: string */, b /* : number */) /*: void */ {}`, nil)
+	assert(`/*:: type A = string */ let a /*: A */ = 1 /* comment */ * 2`, nil)
+	assert(`/*flow-include type B = number */`, nil)
+
+	assert(`enum A {B, C}`, nil)
+	assert(`enum A of number {B = 1, C = 2}`, nil)
+	assert(`export enum A of symbol {B, C, ...}`, nil)
+	assert(`enum A of number {B, C}`, "1:19 Enum member B of number enum A requires initializer")
+	assert(`enum A of string {B = 1}`, "1:23 Invalid enum member initializer")
+
+	assert(`function f(x: mixed): boolean %checks { return !!x }`, nil)
+	assert(`const f = (x: mixed): %checks => !!x`, nil)
+	assert(`declare function f(x: mixed): boolean %checks(typeof x === 'string')`, nil)
+
+	assert(`type A = B['c']['d']; type E = F?.['g']`, nil)
+
+	assert(`import typeof A, {b} from 'a'; import typeof * as C from 'c'`, nil)
+	assert(`import {typeof a, type B, c} from 'a'; import type from 'b'`, nil)
+}
+
+func TestDecorators(t *testing.T) {
+	assert := makeAssert(t)
+
+	assert(`@a @b.c @d.e(f) @(g[0]) class A {}`, nil)
+	assert(`@a export class A {} export @b class B {} export default @c class {}`, nil)
+	assert(`const A = @(x) class extends B {}`, nil)
+	assert(`class A { @a m() {} @b static accessor #x = 1; @c get y() {} @d [e] = 1 }`, nil)
+	assert(`class A { accessor x; static accessor y = 1; accessor() {} accessor = 1 }`, nil)
+
+	assert(`@a function f() {}`, "1:1 Leading decorators must be attached to a class declaration")
+	assert(`class A { @a constructor() {} }`, "1:11 Decorators can not be used with a constructor")
+}
+
+func TestPrivateNames(t *testing.T) {
+	assert := makeAssert(t)
+
+	assert(`class A { #x = 1; m(o) { return this.#x + o.#x } static #s() { return () => A.#s } }`, nil)
+	assert(`class A { #x; m() { class B { n(o) { return o.#x } } } }`, nil)
+
+	assert(`this.#x`, "1:6 Unexpected token #")
+}
+
+func TestForAwait(t *testing.T) {
+	assert := makeAssert(t)
+
+	assert(`async function f(a) { for await (const x of a) {} for await (x of a); }`, nil)
+	assert(`async function* f(a) { for await ([x, y] of a) yield x; }`, nil)
+
+	assert(`async function f(a) { for await (const x in a) {} }`, "1:42 Unexpected token in")
+	assert(`function f(a) { for await (x of a); }`, "1:21 Unexpected identifier")
+}
+
+func TestYield(t *testing.T) {
+	assert := makeAssert(t)
+
+	assert("function* g() { x = yield; f() }", nil)
+	assert("function* g() { x = yield\n f() }", nil)
+	assert("function* g() { [yield, yield]; f(yield); a ? yield : yield }", nil)
+}
+
+func TestTemplates(t *testing.T) {
+	assert := makeAssert(t)
+
+	assert("tag`\\unicode ${a}\\u{`", nil)
+
+	assert("`abc${a}", "1:8 Unexpected end of input")
+}
+
+func TestNumericLiterals(t *testing.T) {
+	assert := makeAssert(t)
+
+	assert(`1_000_000; 0b1_0; 0xF_F; 10n; 0x1Fn`, nil)
+	assert(`1_`, "1:1 Unexpected token ILLEGAL")
+	assert(`1__0`, "1:1 Unexpected token ILLEGAL")
+	assert(`1.5n`, "1:1 Unexpected token ILLEGAL")
+}
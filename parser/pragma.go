@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// pragma matches the name and the value of pragmas in comments, e.g. @jsx h
+var pragma = regexp.MustCompile(`@(flow|noflow|jsxRuntime|jsxImportSource|jsxFrag|jsx)\b[ \t]*(\S*)`)
+
+// readPragmas reads the pragmas of the comments leading the module, those may be mixed
+// with directives like 'use client'. Flow is not parsed for @noflow sources, the JSX ones
+// are kept by the module for the transpiler
+func (p *Parser) readPragmas() {
+	src := p.src
+
+	for {
+		src = strings.TrimLeft(src, " \t\r\n\ufeff")
+		var comment string
+
+		switch {
+		case strings.HasPrefix(src, "//"):
+			end := strings.IndexAny(src, "\r\n")
+
+			if end < 0 {
+				end = len(src)
+			}
+
+			comment, src = src[2:end], src[end:]
+		case strings.HasPrefix(src, "/*"):
+			end := strings.Index(src, "*/")
+
+			if end < 0 {
+				return
+			}
+
+			comment, src = src[2:end], src[end+2:]
+		case strings.HasPrefix(src, "'") || strings.HasPrefix(src, `"`):
+			end := directiveEnd(src)
+
+			if end < 0 {
+				return
+			}
+
+			src = strings.TrimPrefix(strings.TrimLeft(src[end:], " \t"), ";")
+
+			continue
+		default:
+			return
+		}
+
+		for _, match := range pragma.FindAllStringSubmatch(comment, -1) {
+			p.setPragma(match[1], match[2])
+		}
+	}
+}
+
+func (p *Parser) setPragma(name string, value string) {
+	switch name {
+	case "flow":
+		p.flow = !p.typescript
+		p.pragmas.NoFlow = false
+	case "noflow":
+		p.flow = false
+		p.pragmas.NoFlow = true
+	case "jsx":
+		p.pragmas.JSX = value
+	case "jsxFrag":
+		p.pragmas.JSXFrag = value
+	case "jsxImportSource":
+		p.pragmas.JSXImportSource = value
+	case "jsxRuntime":
+		p.pragmas.JSXRuntime = value
+	}
+}
+
+// directiveEnd is the offset following the string the source starts with, -1 when it is not closed
+func directiveEnd(src string) int {
+	for index := 1; index < len(src); index++ {
+		switch src[index] {
+		case '\\':
+			index++
+		case '\r', '\n':
+			return -1
+		case src[0]:
+			return index + 1
+		}
+	}
+
+	return -1
+}
@@ -24,6 +24,8 @@ func (p *Parser) parsePrimaryExpression() ast.IExpr {
 		}
 	case token.CLASS:
 		return p.parseClassExpression()
+	case token.AT:
+		return p.parseDecoratedClassExpression()
 	case token.AWAIT:
 		p.next()
 
@@ -85,6 +87,10 @@ func (p *Parser) parsePrimaryExpression() ast.IExpr {
 	case token.JSX_FRAGMENT_START:
 		return p.parseJSXFragment()
 	case token.LESS:
+		if p.typescript && !p.jsx {
+			return p.parseTSAngleBracketAssertionOrGenericArrowFunction()
+		}
+
 		return p.parseJSXElementOrGenericArrowFunction()
 	case token.IMPORT:
 		return p.parseImportCall()
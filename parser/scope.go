@@ -13,6 +13,9 @@ type Scope struct {
 	inSwitch    bool
 	inFunction  bool
 	inType      bool
+	inDeclare   bool
+
+	allowPrivateNames bool // inherited by the scopes nested in class body
 
 	allowUnionType        bool
 	allowIntersectionType bool
@@ -21,8 +24,11 @@ type Scope struct {
 }
 
 func (p *Parser) openScope() {
+	allowPrivateNames := p.scope != nil && p.scope.allowPrivateNames
+
 	p.scope = &Scope{
 		outer:                 p.scope,
+		allowPrivateNames:     allowPrivateNames,
 		allowIn:               true,
 		allowTypeAssertion:    false,
 		allowUnionType:        true,
@@ -39,6 +45,7 @@ func (p *Parser) openClassScope() func() {
 	p.openScope()
 	wasInClass := p.scope.inClass
 	p.scope.inClass = true
+	p.scope.allowPrivateNames = true
 	p.scope.allowYield = wasAllowYield
 
 	return func() {
@@ -31,10 +31,12 @@ func (p *Parser) parseModule() *ast.Module {
 	defer p.closeScope()
 
 	module := &ast.Module{
-		Symbols: p.symbolsScope,
-		Body:    p.parseSourceElements(),
-		File:    p.file,
-		Ids:     ids.NewIds(),
+		Symbols:    p.symbolsScope,
+		Body:       p.parseSourceElements(),
+		File:       p.file,
+		TypeScript: p.typescript,
+		Pragmas:    p.pragmas,
+		Ids:        ids.NewIds(),
 	}
 
 	p.symbolsScope.ReferenceSymbols()
@@ -65,8 +67,22 @@ func (p *Parser) parseStatement() ast.IStmt {
 		return nil
 	}
 
-	if p.scope.inModuleRoot() {
+	if p.scope.inModuleRoot() && (p.typescript || p.flow) {
 		p.allowToken(token.TYPE_TYPE)
+
+		if p.isContextualFollowedBy("opaque", token.TYPE_TYPE) {
+			p.allowToken(token.TYPE_OPAQUE)
+		}
+	}
+
+	if p.typescript {
+		if stmt := p.parseTSStatement(); stmt != nil {
+			return stmt
+		}
+	} else if p.isFlowDeclareStart() {
+		return p.parseFlowDeclareStatement()
+	} else if p.isFlowEnumStart() {
+		return p.parseFlowEnumStatement()
 	}
 
 	switch p.token {
@@ -98,7 +114,14 @@ func (p *Parser) parseStatement() ast.IStmt {
 	case token.VAR, token.CONST, token.LET:
 		return p.parseVariableStatement()
 	case token.FUNCTION:
-		return p.parseFunction(true, p.loc(), false)
+		return p.parseFunctionStatement(p.loc(), false)
+	case token.ASYNC:
+		if tkn, _, _, sameLine := p.peek(); tkn == token.FUNCTION && sameLine {
+			loc := p.loc()
+			p.next()
+
+			return p.parseFunctionStatement(loc, true)
+		}
 	case token.SWITCH:
 		return p.parseSwitchStatement()
 	case token.RETURN:
@@ -114,7 +137,7 @@ func (p *Parser) parseStatement() ast.IStmt {
 	case token.EXPORT:
 		return p.parseExportDeclaration()
 	case token.AT:
-		return p.parseLegacyClassDecoratorStatement()
+		return p.parseDecoratedStatement()
 	case token.TYPE_TYPE, token.TYPE_OPAQUE:
 		if p.scope.inModuleRoot() {
 			return p.parseFlowTypeStatement()
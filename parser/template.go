@@ -8,25 +8,41 @@ import (
 func (p *Parser) parseTemplateExpression() *ast.TemplateExpression {
 	exp := &ast.TemplateExpression{
 		ExprNode:      p.exprNode(),
-		Strings:       make([]string, 0),
 		Substitutions: make([]ast.IExpr, 0),
 	}
 
+	exp.Strings = p.parseTemplateParts(func() {
+		exp.Substitutions = append(exp.Substitutions, p.parseAssignmentExpression())
+	})
+	exp.Loc.End(p.tokenOffset)
+
+	// advance to the next token
+	p.next()
+
+	return exp
+}
+
+// parseTemplateParts reads template chars up to the closing `
+// and calls substitution to parse every ${} part in "tokens" mode,
+// parts are kept as they are in the source with line terminators normalized to \n
+func (p *Parser) parseTemplateParts(substitution func()) []string {
+	strings := make([]string, 0)
 	currentString := ""
 
 	// we're parsing template literal in chars mode mostly
 	// parsing until we meet another `
 	for p.chr != '`' {
+		if p.chr == -1 {
+			p.errorUnexpected(p.loc(), p.chr)
+		}
+
 		// escape, so the next chr will be just add to the current string part
 		if p.chr == '\\' {
 			// advance to the next chr after \
 			p.read()
 
-			// add current chr to string no matter what it is
-			currentString += string(p.chr)
-
-			// and advance to the next one
-			p.read()
+			// add escape to string no matter what it is
+			currentString += "\\" + p.readTemplateChr()
 			continue
 		} else if p.chr == '$' {
 			// advance to the next chr
@@ -35,7 +51,7 @@ func (p *Parser) parseTemplateExpression() *ast.TemplateExpression {
 			// start of substitution
 			if p.chr == '{' {
 				// adding current string to strings
-				exp.Strings = append(exp.Strings, currentString)
+				strings = append(strings, currentString)
 				currentString = ""
 
 				// advance to the next chr as we're at { now
@@ -43,13 +59,11 @@ func (p *Parser) parseTemplateExpression() *ast.TemplateExpression {
 				// and scan next token as substitution will be parsed as expression
 				// thus switching to "tokens" mode back from chars
 				p.next()
-				exp.Substitutions = append(exp.Substitutions, p.parseAssignmentExpression())
+				substitution()
 
 				// we're still in "tokens" mode, so check for next token instead of chr
 				if !p.is(token.RIGHT_BRACE) {
 					p.unexpectedToken()
-					p.next()
-					return nil
 				}
 
 				continue
@@ -59,19 +73,29 @@ func (p *Parser) parseTemplateExpression() *ast.TemplateExpression {
 			}
 		}
 
-		currentString += string(p.chr)
-		p.read()
+		currentString += p.readTemplateChr()
 	}
 
 	// reading past last ` so we can normally back to "tokens" mode
 	p.read()
-	exp.Strings = append(exp.Strings, currentString)
-	exp.Loc.End(p.tokenOffset)
 
-	// advance to the next token
-	p.next()
+	return append(strings, currentString)
+}
 
-	return exp
+// readTemplateChr reads the next chr, \r\n and \r are read as \n
+func (p *Parser) readTemplateChr() string {
+	chr := p.chr
+	p.read()
+
+	if chr == '\r' {
+		if p.chr == '\n' {
+			p.read()
+		}
+
+		return "\n"
+	}
+
+	return string(chr)
 }
 
 func (p *Parser) parseTaggedTemplateExpression(tag ast.IExpr) *ast.TaggedTemplateExpression {
@@ -22,8 +22,13 @@ func tt(t *testing.T, f func()) {
 }
 
 func makeAssert(t *testing.T) func(string, interface{}) {
+	return makeFileAssert(t, "")
+}
+
+// filename decides on the syntax extensions, e.g. TypeScript for .ts
+func makeFileAssert(t *testing.T, filename string) func(string, interface{}) {
 	return func(src string, expected interface{}) {
-		_, err := ParseModule("", src)
+		_, err := ParseModule(filename, src)
 
 		if err == nil && expected == nil {
 			return
@@ -0,0 +1,964 @@
+package parser
+
+import (
+	"yawp/parser/ast"
+	"yawp/parser/file"
+	"yawp/parser/token"
+)
+
+// TypeScript has a lot of contextual keywords, none of them are reserved words
+func (p *Parser) isContextual(literal string) bool {
+	return p.literal == literal && (p.is(token.IDENTIFIER) || p.tokenIsKeyword)
+}
+
+// peek scans the next token and restores parser state right away
+func (p *Parser) peek() (tkn token.Token, literal string, isKeyword bool, sameLine bool) {
+	snapshot := p.snapshot()
+	p.next()
+
+	tkn, literal, isKeyword, sameLine = p.token, p.literal, p.tokenIsKeyword, !p.implicitSemicolon
+
+	p.toSnapshot(snapshot)
+
+	return
+}
+
+// isContextualFollowedBy checks for the contextual keyword
+// that is followed by one of the tokens on the same line
+func (p *Parser) isContextualFollowedBy(literal string, tokens ...token.Token) bool {
+	if !p.isContextual(literal) {
+		return false
+	}
+
+	tkn, _, isKeyword, sameLine := p.peek()
+
+	if !sameLine {
+		return false
+	}
+
+	for _, value := range tokens {
+		if value == tkn || (value == token.KEYWORD && isKeyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *Parser) parseTSStatement() ast.IStmt {
+	switch {
+	case p.isContextualFollowedBy("enum", token.IDENTIFIER):
+		return p.parseTSEnumStatement(false)
+	case p.is(token.CONST):
+		if _, literal, _, _ := p.peek(); literal == "enum" {
+			return p.parseTSEnumStatement(true)
+		}
+	case p.isContextualFollowedBy("declare", token.IDENTIFIER, token.KEYWORD):
+		return p.parseTSDeclareStatement()
+	case p.isContextualFollowedBy("namespace", token.IDENTIFIER),
+		p.isContextualFollowedBy("module", token.IDENTIFIER, token.STRING):
+		return p.parseTSNamespaceStatement()
+	case p.isContextualFollowedBy("global", token.LEFT_BRACE):
+		return p.parseTSNamespaceStatement()
+	case p.isContextualFollowedBy("abstract", token.CLASS):
+		p.next()
+
+		return p.parseClassStatement()
+	case p.isContextualFollowedBy("type", token.IDENTIFIER, token.KEYWORD):
+		return p.parseFlowTypeStatement()
+	}
+
+	return nil
+}
+
+func (p *Parser) parseTSEnumStatement(isConst bool) *ast.TSEnumStatement {
+	loc := p.loc()
+
+	if isConst {
+		p.consumeExpected(token.CONST)
+	}
+
+	// enum
+	p.next()
+
+	stmt := &ast.TSEnumStatement{
+		StmtNode: p.stmtNodeAt(loc),
+		Name:     p.symbol(p.parseIdentifier(), ast.SDeclaration, ast.SRVar),
+		Const:    isConst,
+		Members:  make([]*ast.TSEnumMember, 0),
+	}
+
+	p.consumeExpected(token.LEFT_BRACE)
+
+	for p.until(token.RIGHT_BRACE) {
+		member := &ast.TSEnumMember{
+			Loc: p.loc(),
+		}
+
+		if p.is(token.STRING) {
+			member.Name = p.literal[1 : len(p.literal)-1]
+			p.next()
+		} else if identifier := p.parseIdentifierIncludingKeywords(); identifier != nil {
+			member.Name = identifier.Name
+		} else {
+			p.unexpectedToken()
+		}
+
+		if p.is(token.ASSIGN) {
+			p.next()
+			member.Initializer = p.parseAssignmentExpression()
+		}
+
+		stmt.Members = append(stmt.Members, member)
+
+		if !p.is(token.RIGHT_BRACE) {
+			p.consumeExpected(token.COMMA)
+		}
+	}
+
+	stmt.Loc.End(p.consumeExpected(token.RIGHT_BRACE))
+
+	return stmt
+}
+
+func (p *Parser) parseTSDeclareStatement() *ast.TSDeclareStatement {
+	loc := p.loc()
+
+	// declare
+	p.next()
+
+	return &ast.TSDeclareStatement{
+		StmtNode:    p.stmtNodeAt(loc),
+		Declaration: p.parseStatement(),
+	}
+}
+
+// namespace A.B {} is parsed as namespace A { export namespace B {} }
+func (p *Parser) parseTSNamespaceRemainder() ast.IStmt {
+	loc := p.loc()
+
+	if p.is(token.STRING) {
+		// declare module 'foo' can only be an ambient declaration
+		p.next()
+
+		if p.is(token.LEFT_BRACE) {
+			p.parseTSNamespaceBody()
+		} else {
+			p.optionalSemicolon()
+		}
+
+		return &ast.TSDeclareStatement{
+			StmtNode: p.stmtNodeAt(loc),
+		}
+	}
+
+	stmt := &ast.TSNamespaceStatement{
+		StmtNode: p.stmtNodeAt(loc),
+		Name:     p.symbol(p.parseIdentifier(), ast.SDeclaration, ast.SRVar),
+	}
+
+	if p.is(token.PERIOD) {
+		p.next()
+
+		stmt.Body = []ast.IStmt{
+			&ast.ExportStatement{
+				StmtNode: p.stmtNode(),
+				Clause:   p.parseTSNamespaceRemainder().(ast.ExportClause),
+			},
+		}
+
+		return stmt
+	}
+
+	stmt.Body = p.parseTSNamespaceBody()
+	stmt.Loc.End(p.tokenOffset)
+
+	return stmt
+}
+
+func (p *Parser) parseTSNamespaceBody() []ast.IStmt {
+	p.useSymbolsScope(ast.SSTFunction)
+	defer p.restoreSymbolsScope()
+
+	p.consumeExpected(token.LEFT_BRACE)
+	body := p.parseStatementList()
+	p.consumeExpected(token.RIGHT_BRACE)
+
+	return body
+}
+
+func (p *Parser) parseTSNamespaceStatement() ast.IStmt {
+	// namespace, module and global are all the same
+	if !p.isContextual("global") {
+		p.next()
+	}
+
+	return p.parseTSNamespaceRemainder()
+}
+
+// import a = require('a'), import a = A.b
+func (p *Parser) parseTSImportEqualsStatement(loc *file.Loc) *ast.TSImportEqualsStatement {
+	stmt := &ast.TSImportEqualsStatement{
+		StmtNode: p.stmtNodeAt(loc),
+		Name:     p.symbol(p.parseIdentifier(), ast.SDeclaration, ast.SRImport),
+	}
+
+	p.consumeExpected(token.ASSIGN)
+
+	stmt.ModuleReference = p.parseLeftHandSideExpressionAllowCall()
+	p.optionalSemicolon()
+
+	return stmt
+}
+
+// a as T, a satisfies T
+func (p *Parser) parseTSAsExpression(left ast.IExpr) ast.IExpr {
+	for p.isContextual("as") || p.isContextual("satisfies") {
+		if p.implicitSemicolon {
+			break
+		}
+
+		kind := ast.TSAKAs
+
+		if p.literal == "satisfies" {
+			kind = ast.TSAKSatisfies
+		}
+
+		closeTypeScope := p.openTypeScope()
+		p.next()
+
+		var tsType ast.FlowType
+
+		if p.is(token.CONST) {
+			// as const
+			tsType = &ast.FlowIdentifier{
+				Loc:  p.loc(),
+				Name: "const",
+			}
+
+			p.next()
+		} else {
+			tsType = p.parseFlowType()
+		}
+
+		closeTypeScope()
+
+		left = &ast.TSTypeAssertionExpression{
+			ExprNode:   p.exprNodeAt(left.GetLoc()),
+			Kind:       kind,
+			Expression: left,
+			Type:       tsType,
+		}
+	}
+
+	return left
+}
+
+// a!
+func (p *Parser) isTSNonNullAssertion() bool {
+	return p.typescript && p.is(token.NOT) && !p.implicitSemicolon
+}
+
+func (p *Parser) parseTSNonNullAssertion(left ast.IExpr) ast.IExpr {
+	p.consumeExpected(token.NOT)
+
+	return &ast.TSTypeAssertionExpression{
+		ExprNode:   p.exprNodeAt(left.GetLoc()),
+		Kind:       ast.TSAKNonNull,
+		Expression: left,
+	}
+}
+
+func (p *Parser) maybeParseParametrizedArrowFunction() (fn *ast.ArrowFunctionExpression) {
+	scope, symbolsScope := p.scope, p.symbolsScope
+
+	defer func() {
+		if recover() != nil {
+			p.scope, p.symbolsScope = scope, symbolsScope
+			fn = nil
+		}
+	}()
+
+	return p.parseParametrizedArrowFunction()
+}
+
+// <T>a, but only in .ts files as it would be ambiguous with JSX
+func (p *Parser) parseTSAngleBracketAssertionOrGenericArrowFunction() ast.IExpr {
+	loc := p.loc()
+	snapshot := p.snapshot()
+
+	if fn := p.maybeParseParametrizedArrowFunction(); fn != nil {
+		return fn
+	}
+
+	p.toSnapshot(snapshot)
+
+	closeTypeScope := p.openTypeScope()
+	p.consumeExpected(token.LESS)
+	tsType := p.parseFlowType()
+	closeTypeScope()
+	p.consumeExpected(token.GREATER)
+
+	return &ast.TSTypeAssertionExpression{
+		ExprNode:   p.exprNodeAt(loc),
+		Kind:       ast.TSAKAngleBracket,
+		Expression: p.parseUnaryExpression(),
+		Type:       tsType,
+	}
+}
+
+// public, private, protected, readonly, override, declare, abstract
+func (p *Parser) parseTSModifiers(allowed map[string]ast.Flags) (modifiers ast.Flags, names []string) {
+	for p.is(token.IDENTIFIER) || p.tokenIsKeyword {
+		flag, ok := allowed[p.literal]
+
+		if !ok {
+			return
+		}
+
+		// modifier has to be followed by a name on the same line,
+		// otherwise it is the name itself
+		tkn, _, isKeyword, sameLine := p.peek()
+
+		if !sameLine {
+			return
+		}
+
+		switch tkn {
+		case token.IDENTIFIER, token.STRING, token.NUMBER, token.LEFT_BRACKET,
+			token.LEFT_BRACE, token.HASH, token.MULTIPLY:
+		default:
+			if !isKeyword {
+				return
+			}
+		}
+
+		modifiers = modifiers.Add(flag)
+		names = append(names, p.literal)
+
+		p.next()
+	}
+
+	return
+}
+
+const (
+	tsDeclare ast.Flags = ast.TSOverride << (1 + iota)
+	tsAbstract
+)
+
+var tsParameterModifiers = map[string]ast.Flags{
+	"public":    ast.TSPublic,
+	"private":   ast.TSPrivate,
+	"protected": ast.TSProtected,
+	"readonly":  ast.TSReadonly,
+	"override":  ast.TSOverride,
+}
+
+var tsClassMemberModifiers = map[string]ast.Flags{
+	"public":    ast.TSPublic,
+	"private":   ast.TSPrivate,
+	"protected": ast.TSProtected,
+	"readonly":  ast.TSReadonly,
+	"override":  ast.TSOverride,
+	"declare":   tsDeclare,
+	"abstract":  tsAbstract,
+}
+
+// [key: string]: T
+func (p *Parser) isTSIndexSignatureStart() bool {
+	if !p.is(token.LEFT_BRACKET) {
+		return false
+	}
+
+	snapshot := p.snapshot()
+	defer p.toSnapshot(snapshot)
+
+	p.next()
+
+	if !p.is(token.IDENTIFIER) && !p.tokenIsKeyword {
+		return false
+	}
+
+	p.next()
+
+	return p.is(token.COLON)
+}
+
+func (p *Parser) parseTSIndexSignature() *ast.TSDeclareStatement {
+	loc := p.loc()
+
+	closeTypeScope := p.openTypeScope()
+	defer closeTypeScope()
+
+	p.consumeExpected(token.LEFT_BRACKET)
+	p.parseIdentifierIncludingKeywords()
+	p.parseFlowTypeAnnotation()
+	p.consumeExpected(token.RIGHT_BRACKET)
+	p.parseFlowTypeAnnotation()
+
+	return &ast.TSDeclareStatement{
+		StmtNode: p.stmtNodeAt(loc),
+	}
+}
+
+func (p *Parser) parseTSImplements() []ast.FlowType {
+	closeTypeScope := p.openTypeScope()
+	defer closeTypeScope()
+
+	// implements
+	p.next()
+
+	types := []ast.FlowType{p.parseFlowType()}
+
+	for p.is(token.COMMA) {
+		p.next()
+		types = append(types, p.parseFlowType())
+	}
+
+	return types
+}
+
+// tokens that can not start a type, so the preceding
+// contextual keyword has to be a type name itself
+func isTSTypeEnd(tkn token.Token) bool {
+	switch tkn {
+	case token.COMMA, token.RIGHT_PARENTHESIS, token.RIGHT_BRACKET, token.RIGHT_BRACE,
+		token.GREATER, token.ASSIGN, token.SEMICOLON, token.OR, token.AND, token.EOF,
+		token.COLON, token.QUESTION_MARK, token.PERIOD, token.LEFT_BRACKET, token.ARROW,
+		token.EXTENDS, token.LESS:
+		return true
+	}
+
+	return false
+}
+
+// keyof T, unique symbol, readonly T[], infer U, asserts a, a is T
+func (p *Parser) parseTSIdentifierType() ast.FlowType {
+	loc := p.loc()
+	literal := p.literal
+	tkn, nextLiteral, _, sameLine := p.peek()
+
+	if !sameLine || isTSTypeEnd(tkn) {
+		return nil
+	}
+
+	switch {
+	case literal == "keyof" || literal == "unique" || literal == "readonly":
+		p.next()
+
+		return &ast.TSTypeOperator{
+			Loc:      loc,
+			Operator: literal,
+			Type:     p.parseTSOperandType(),
+		}
+	case literal == "infer" && tkn == token.IDENTIFIER:
+		p.next()
+
+		return &ast.TSInferType{
+			Loc: loc,
+			TypeParameter: &ast.FlowTypeParameter{
+				Loc:  p.loc(),
+				Name: p.parseFlowTypeIdentifier(),
+			},
+		}
+	case literal == "asserts" && (tkn == token.IDENTIFIER || tkn == token.THIS) && nextLiteral != "is":
+		p.next()
+
+		return p.parseTSTypePredicate(loc, true)
+	case tkn == token.IDENTIFIER && nextLiteral == "is":
+		return p.parseTSTypePredicate(loc, false)
+	case literal == "abstract" && tkn == token.NEW:
+		p.next()
+
+		return p.parseTSSimpleType()
+	}
+
+	return nil
+}
+
+func (p *Parser) parseTSOperandType() ast.FlowType {
+	loc := p.loc()
+	var operand ast.FlowType
+
+	if p.is(token.LEFT_PARENTHESIS) {
+		operand = p.parseFlowExpressionOrFunction()
+	} else {
+		operand = p.parseSimpleFlowType()
+	}
+
+	if operand == nil {
+		p.unexpectedToken()
+	}
+
+	return p.parseFlowPostfixType(loc, operand)
+}
+
+func (p *Parser) parseTSTypePredicate(loc *file.Loc, asserts bool) *ast.TSTypePredicate {
+	predicate := &ast.TSTypePredicate{
+		Loc:       loc,
+		Asserts:   asserts,
+		Parameter: p.literal,
+	}
+
+	// parameter name or this
+	p.next()
+
+	if p.isContextual("is") && !p.implicitSemicolon {
+		p.next()
+		predicate.Type = p.parseFlowType()
+	}
+
+	return predicate
+}
+
+// this, import('a'), new () => T, `a${T}`
+func (p *Parser) parseTSSimpleType() ast.FlowType {
+	loc := p.loc()
+
+	switch p.token {
+	case token.THIS:
+		if tkn, literal, _, sameLine := p.peek(); tkn == token.IDENTIFIER && literal == "is" && sameLine {
+			return p.parseTSTypePredicate(loc, false)
+		}
+
+		p.next()
+
+		return &ast.TSThisType{
+			Loc: loc,
+		}
+	case token.IMPORT:
+		p.next()
+		p.consumeExpected(token.LEFT_PARENTHESIS)
+
+		importType := &ast.TSImportType{
+			Loc:  loc,
+			From: p.literal,
+		}
+
+		p.consumeExpected(token.STRING)
+		p.consumeExpected(token.RIGHT_PARENTHESIS)
+
+		if p.is(token.PERIOD) {
+			p.next()
+			importType.Qualifier = p.parseFlowTypeIdentifier()
+		}
+
+		if p.isFlowTypeArgumentsStart() && !p.implicitSemicolon {
+			importType.TypeArguments = p.parseFlowTypeArguments()
+		}
+
+		return importType
+	case token.NEW:
+		p.next()
+
+		var typeParameters []*ast.FlowTypeParameter
+
+		if p.isFlowTypeParametersStart() {
+			typeParameters = p.parseFlowTypeParameters()
+		}
+
+		parametersLoc := p.loc()
+		p.consumeExpected(token.LEFT_PARENTHESIS)
+		parameters := p.parseFlowFunctionParameters()
+		p.consumeExpected(token.RIGHT_PARENTHESIS)
+
+		function := p.parseFlowFunctionRemainder(parametersLoc, parameters)
+		function.TypeParameters = typeParameters
+
+		return &ast.TSConstructorType{
+			Loc:      loc,
+			Function: function,
+		}
+	case token.TEMPLATE_QUOTE:
+		templateType := &ast.TSTemplateLiteralType{
+			Loc:   loc,
+			Types: make([]ast.FlowType, 0),
+		}
+
+		p.parseTemplateParts(func() {
+			templateType.Types = append(templateType.Types, p.parseFlowType())
+		})
+		loc.End(p.tokenOffset)
+		p.next()
+
+		return templateType
+	}
+
+	return nil
+}
+
+func (p *Parser) parseTSConditionalType(checkType ast.FlowType) *ast.TSConditionalType {
+	p.consumeExpected(token.EXTENDS)
+
+	conditional := &ast.TSConditionalType{
+		Loc:         checkType.GetLoc(),
+		CheckType:   checkType,
+		ExtendsType: p.parseFlowType(),
+	}
+
+	p.consumeExpected(token.QUESTION_MARK)
+	conditional.TrueType = p.parseFlowType()
+	p.consumeExpected(token.COLON)
+	conditional.FalseType = p.parseFlowType()
+
+	return conditional
+}
+
+// destructuring is allowed in function types, but only the type matters
+func (p *Parser) skipTSBindingPattern() {
+	depth := 0
+
+	for {
+		switch p.token {
+		case token.LEFT_BRACE, token.LEFT_BRACKET:
+			depth++
+		case token.RIGHT_BRACE, token.RIGHT_BRACKET:
+			depth--
+		case token.EOF:
+			p.unexpectedToken()
+		}
+
+		p.next()
+
+		if depth == 0 {
+			return
+		}
+	}
+}
+
+// (a: A, b?: B, ...c: C[]) as well as (this: T) and ({ a }: T)
+func (p *Parser) parseTSFunctionTypeParameter() *ast.FlowFunctionParameter {
+	parameter := &ast.FlowFunctionParameter{}
+
+	if p.is(token.DOTDOTDOT) {
+		p.next()
+		parameter.Rest = true
+	}
+
+	switch {
+	case p.is(token.THIS):
+		parameter.Identifier = &ast.Identifier{
+			ExprNode: p.exprNode(),
+			Name:     "this",
+		}
+
+		p.next()
+	case p.isAny(token.LEFT_BRACE, token.LEFT_BRACKET):
+		p.skipTSBindingPattern()
+	default:
+		parameter.Identifier = p.parseIdentifierIncludingKeywords()
+
+		if parameter.Identifier == nil {
+			p.unexpectedToken()
+		}
+	}
+
+	if p.is(token.QUESTION_MARK) {
+		p.next()
+		parameter.Optional = true
+	}
+
+	if p.is(token.COLON) {
+		p.next()
+		parameter.Type = p.parseFlowType()
+	}
+
+	return parameter
+}
+
+func (p *Parser) maybeParseTSFunctionTypeParameters() (parameters []*ast.FlowFunctionParameter) {
+	scope, symbolsScope := p.scope, p.symbolsScope
+
+	defer func() {
+		if recover() != nil {
+			p.scope, p.symbolsScope = scope, symbolsScope
+			parameters = nil
+		}
+	}()
+
+	p.consumeExpected(token.LEFT_PARENTHESIS)
+	parameters = p.parseFlowFunctionParameters()
+	p.consumeExpected(token.RIGHT_PARENTHESIS)
+
+	return parameters
+}
+
+func (p *Parser) parseTSParenthesizedOrFunctionType() ast.FlowType {
+	loc := p.loc()
+
+	closeTypeScope := p.openTypeScope()
+	defer closeTypeScope()
+
+	snapshot := p.snapshot()
+
+	if parameters := p.maybeParseTSFunctionTypeParameters(); parameters != nil && p.is(token.ARROW) {
+		return p.parseFlowFunctionRemainder(loc, parameters)
+	}
+
+	p.toSnapshot(snapshot)
+
+	p.consumeExpected(token.LEFT_PARENTHESIS)
+	flowType := p.parseFlowType()
+	p.consumeExpected(token.RIGHT_PARENTHESIS)
+
+	return flowType
+}
+
+// [a: A, b?: B, ...c: C[]], [A, B?, ...C[]]
+func (p *Parser) parseTSTupleElement() ast.FlowType {
+	if p.is(token.DOTDOTDOT) {
+		p.next()
+	}
+
+	if p.is(token.IDENTIFIER) || p.tokenIsKeyword {
+		snapshot := p.snapshot()
+		p.next()
+		p.consumePossible(token.QUESTION_MARK)
+
+		if p.is(token.COLON) {
+			// labels are for documentation only
+			p.next()
+		} else {
+			p.toSnapshot(snapshot)
+		}
+	}
+
+	element := p.parseFlowType()
+
+	p.consumePossible(token.QUESTION_MARK)
+
+	return element
+}
+
+// members of object types and interfaces:
+// properties, methods, call and construct signatures, accessors,
+// index signatures and mapped types
+func (p *Parser) parseTSTypeMember() ast.FlowObjectProperty {
+	loc := p.loc()
+
+	if p.isAny(token.LEFT_PARENTHESIS, token.LESS) {
+		return p.parseFlowInterfaceMethod()
+	}
+
+	if p.is(token.NEW) {
+		if tkn, _, _, _ := p.peek(); tkn == token.LEFT_PARENTHESIS || tkn == token.LESS {
+			p.next()
+
+			method := p.parseFlowInterfaceMethod()
+			method.Loc = loc
+
+			return method
+		}
+	}
+
+	readonly := false
+
+	// +readonly and -readonly are only allowed in mapped types
+	if p.isAny(token.PLUS, token.MINUS) {
+		p.next()
+
+		if !p.isContextual("readonly") {
+			p.unexpectedToken()
+		}
+	}
+
+	if p.isContextual("readonly") {
+		if tkn, _, _, _ := p.peek(); !isTSTypeEnd(tkn) || tkn == token.LEFT_BRACKET {
+			readonly = true
+			p.next()
+		}
+	}
+
+	if p.isContextual("get") || p.isContextual("set") {
+		if tkn, _, isKeyword, _ := p.peek(); tkn == token.IDENTIFIER || tkn == token.STRING || isKeyword {
+			p.next()
+		}
+	}
+
+	var name string
+
+	switch p.token {
+	case token.LEFT_BRACKET:
+		if p.isTSIndexSignatureStart() {
+			p.next()
+
+			prop := &ast.FlowIndexerObjectProperty{
+				Loc:     loc,
+				KeyName: p.parseIdentifierIncludingKeywords().Name,
+				KeyType: p.parseFlowTypeAnnotation(),
+			}
+
+			p.consumeExpected(token.RIGHT_BRACKET)
+			prop.Value = p.parseFlowTypeAnnotation()
+
+			return prop
+		}
+
+		if p.isTSMappedTypeStart() {
+			return p.parseTSMappedTypeProperty(loc, readonly)
+		}
+
+		// computed key, [Symbol.iterator]
+		p.next()
+		p.parseFlowType()
+		p.consumeExpected(token.RIGHT_BRACKET)
+	case token.STRING:
+		name = p.literal[1 : len(p.literal)-1]
+		p.next()
+	case token.NUMBER:
+		name = p.literal
+		p.next()
+	default:
+		identifier := p.parseIdentifierIncludingKeywords()
+
+		if identifier == nil {
+			p.unexpectedToken()
+		}
+
+		name = identifier.Name
+	}
+
+	optional := false
+
+	if p.is(token.QUESTION_MARK) {
+		p.next()
+		optional = true
+	}
+
+	if p.isAny(token.LEFT_PARENTHESIS, token.LESS) {
+		method := p.parseFlowInterfaceMethod()
+		method.Loc = loc
+		method.Name = &ast.FlowIdentifier{
+			Loc:  loc,
+			Name: name,
+		}
+
+		return method
+	}
+
+	prop := &ast.FlowNamedObjectProperty{
+		Loc:       loc,
+		Name:      name,
+		Optional:  optional,
+		Covariant: readonly,
+	}
+
+	// type could be omitted, it is implicit any then
+	if p.is(token.COLON) {
+		prop.Value = p.parseFlowTypeAnnotation()
+	}
+
+	return prop
+}
+
+// members are separated by commas, semicolons or new lines
+func (p *Parser) consumeTSTypeMemberSeparator(terminator token.Token) {
+	if p.isAny(token.COMMA, token.SEMICOLON) {
+		p.next()
+	} else if !p.is(terminator) && !p.implicitSemicolon {
+		p.unexpectedToken()
+	}
+}
+
+// [K in T]
+func (p *Parser) isTSMappedTypeStart() bool {
+	snapshot := p.snapshot()
+	defer p.toSnapshot(snapshot)
+
+	p.next()
+
+	if !p.is(token.IDENTIFIER) {
+		return false
+	}
+
+	p.next()
+
+	return p.is(token.IN) || p.isContextual("in")
+}
+
+func (p *Parser) parseTSMappedTypeProperty(loc *file.Loc, readonly bool) *ast.TSMappedTypeProperty {
+	p.consumeExpected(token.LEFT_BRACKET)
+
+	prop := &ast.TSMappedTypeProperty{
+		Loc:      loc,
+		KeyName:  p.parseIdentifier().Name,
+		Readonly: readonly,
+	}
+
+	// in
+	p.next()
+	prop.Constraint = p.parseFlowType()
+
+	if p.isContextual("as") {
+		p.next()
+		prop.NameType = p.parseFlowType()
+	}
+
+	p.consumeExpected(token.RIGHT_BRACKET)
+
+	if p.isAny(token.PLUS, token.MINUS) {
+		prop.RemovesOption = p.is(token.MINUS)
+		p.next()
+		p.consumeExpected(token.QUESTION_MARK)
+	} else if p.is(token.QUESTION_MARK) {
+		prop.Optional = true
+		p.next()
+	}
+
+	if p.is(token.COLON) {
+		prop.Value = p.parseFlowTypeAnnotation()
+	}
+
+	return prop
+}
+
+// export =, export as namespace, export import,
+// export enum, export namespace, export declare, export abstract class
+func (p *Parser) parseTSExportDeclaration(declaration *ast.ExportStatement) ast.IStmt {
+	switch {
+	case p.is(token.ASSIGN):
+		p.next()
+
+		stmt := &ast.TSExportAssignmentStatement{
+			StmtNode:   declaration.StmtNode,
+			Expression: p.parseAssignmentExpression(),
+		}
+
+		p.optionalSemicolon()
+
+		return stmt
+	case p.isContextualFollowedBy("as", token.IDENTIFIER):
+		// UMD global name is a declaration only
+		p.next()
+		p.next()
+		p.parseIdentifier()
+		p.optionalSemicolon()
+
+		return &ast.TSDeclareStatement{
+			StmtNode: declaration.StmtNode,
+		}
+	case p.is(token.IMPORT):
+		p.next()
+		declaration.Clause = p.parseTSImportEqualsStatement(p.loc())
+
+		return declaration
+	case p.isContextualFollowedBy("abstract", token.CLASS):
+		p.next()
+		declaration.Clause = p.parseExportClassClause()
+
+		return declaration
+	}
+
+	if !p.is(token.IDENTIFIER) && !p.tokenIsKeyword || p.is(token.TYPE_TYPE) {
+		return nil
+	}
+
+	if stmt, ok := p.parseTSStatement().(ast.ExportClause); ok {
+		declaration.Clause = stmt
+
+		return declaration
+	}
+
+	return nil
+}
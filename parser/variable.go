@@ -43,6 +43,7 @@ func (p *Parser) parseVariableDeclaration(declarationList *[]*ast.VariableBindin
 		}
 
 		restoreSymbolFlags()
+		declareBinder(binder, ast.SymbolRefTypeFromToken(kind))
 
 		bnd := &ast.VariableBinding{
 			ExprNode: p.exprNodeAt(loc),
@@ -50,6 +51,10 @@ func (p *Parser) parseVariableDeclaration(declarationList *[]*ast.VariableBindin
 			Binder:   binder,
 		}
 
+		if p.is(token.COLON) {
+			bnd.FlowType = p.parseFlowTypeAnnotation()
+		}
+
 		if p.is(token.ASSIGN) {
 			p.consumeExpected(token.ASSIGN)
 
@@ -84,6 +89,11 @@ func (p *Parser) parseVariableDeclaration(declarationList *[]*ast.VariableBindin
 		*declarationList = append(*declarationList, node)
 	}
 
+	// definite assignment, let a!: T
+	if p.typescript && p.is(token.NOT) {
+		p.next()
+	}
+
 	// feat(type)
 	if p.is(token.COLON) {
 		node.FlowType = p.parseFlowTypeAnnotation()
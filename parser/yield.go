@@ -18,7 +18,9 @@ func (p *Parser) parseYieldExpression() *ast.YieldExpression {
 
 	p.consumeExpected(token.YIELD)
 
-	if !p.consumePossibleSemicolon() {
+	// yield has no argument at the end of the statement or the enclosing expression,
+	// the semicolon is left to the statement
+	if !p.implicitSemicolon && !p.isAny(token.SEMICOLON, token.RIGHT_PARENTHESIS, token.RIGHT_BRACE, token.RIGHT_BRACKET, token.COMMA, token.COLON, token.EOF) {
 		if p.is(token.MULTIPLY) {
 			p.consumeExpected(token.MULTIPLY)
 			exp.Delegate = true
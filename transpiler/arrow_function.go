@@ -5,34 +5,49 @@ import (
 	"yawp/parser/ast"
 )
 
+// ArrowFunctionExpression shares this, arguments and new.target with the enclosing function,
+// lowered to function literal it reaches them through variables declared by that function.
+// Arrow functions are lowered as well when the parameters are, those are read from arguments,
+// and when lowered async arrow functions move non-simple parameters to the generator function
 func (t *Transpiler) ArrowFunctionExpression(af *ast.ArrowFunctionExpression) *ast.ArrowFunctionExpression {
-	if t.options.Target >= options.ES2015 {
-		return t.Walker.ArrowFunctionExpression(af)
-	}
+	movesParameters := t.isAsyncLowered(af.Async, false) && !isSimpleParameterList(&ast.FunctionParameters{List: af.Parameters})
 
-	if af.Async {
-		return t.asyncArrowFunction(af)
-	}
+	if t.supports(options.ArrowFunctions) && (t.supports(options.Parameters) || !readsArguments(af.Parameters)) && !movesParameters {
+		parameters, body := t.lexicalFunction(&ast.FunctionParameters{List: af.Parameters}, af.Body)
+
+		af.Parameters = parameters.List
+		af.Body = body
+
+		if t.isAsyncLowered(af.Async, false) {
+			fl := &ast.FunctionLiteral{Async: true, Parameters: parameters, Body: body}
+			t.lowerFunction(fl, true)
+
+			af.Async = false
+			af.Body = fl.Body
+		}
 
-	functionLiteral := &ast.FunctionLiteral{
-		Node: (ast.Node)(af.ExprNode),
-		Parameters: t.FunctionParameters(&ast.FunctionParameters{
-			List: af.Parameters,
-		}),
+		return af
 	}
 
+	needsReplacement := t.thisScope.NeedsReplacement
 	t.thisScope.NeedsReplacement = true
 	defer func() {
-		t.thisScope.NeedsReplacement = false
+		t.thisScope.NeedsReplacement = needsReplacement
 	}()
 
-	functionLiteral.Body = t.FunctionBody(af.Body)
+	parameters, body := t.lexicalFunction(&ast.FunctionParameters{List: af.Parameters}, af.Body)
 
-	t.Walker.ReplacementExpression = functionLiteral
+	fl := &ast.FunctionLiteral{
+		Node:       (ast.Node)(af.ExprNode),
+		Async:      af.Async,
+		Parameters: parameters,
+		Body:       body,
+	}
 
-	return nil
-}
+	// this and arguments are replaced already
+	t.lowerFunction(fl, false)
 
-func (t *Transpiler) asyncArrowFunction(af *ast.ArrowFunctionExpression) *ast.ArrowFunctionExpression {
-	return t.Walker.ArrowFunctionExpression(af)
+	t.Walker.ReplacementExpression = fl
+
+	return nil
 }
@@ -0,0 +1,303 @@
+package transpiler
+
+import (
+	"yawp/builtins"
+	"yawp/options"
+	"yawp/parser/ast"
+	"yawp/parser/token"
+)
+
+// Async functions are lowered to generator functions before ES2017, run by @asyncToGenerator
+// with awaits replaced by yields:
+//
+// async function f(a) { return await a; }
+// function f(a) { return @asyncToGenerator(function* () { return yield a; }).apply(this, arguments); }
+//
+// Non-simple parameters are moved to the generator function, so errors thrown by default values
+// reject the promise, the function keeps the length with placeholders:
+//
+// async function f(a, b = a) {}
+// function f(_x) { return @asyncToGenerator(function* (a, b = a) {}).apply(this, arguments); }
+//
+// Async generators are lowered before ES2018 the same way, run by @wrapAsyncGenerator
+// with awaits yielded as @awaitAsyncGenerator(a). For ES5 the generator functions
+// are lowered further to state machines, see lowerGenerator
+
+func (t *Transpiler) isAsyncLowered(async bool, generator bool) bool {
+	if generator {
+		return async && !t.supports(options.AsyncGenerators)
+	}
+
+	return async && !t.supports(options.AsyncFunctions)
+}
+
+// lowerFunction runs async and generator transformations needed by the target on transpiled function
+func (t *Transpiler) lowerFunction(fl *ast.FunctionLiteral, arrow bool) {
+	if t.isAsyncLowered(fl.Async, fl.Generator) {
+		t.lowerAsync(fl, arrow)
+	}
+
+	if fl.Generator && !t.supports(options.Generators) {
+		t.lowerGenerator(fl)
+	}
+}
+
+func (t *Transpiler) lowerAsync(fl *ast.FunctionLiteral, arrow bool) {
+	newAwaitRewriter(t, fl.Generator).Statements(fl.Body.List)
+
+	generator := &ast.FunctionLiteral{
+		Generator:  true,
+		Parameters: &ast.FunctionParameters{},
+		Body:       fl.Body,
+	}
+
+	if !isSimpleParameterList(fl.Parameters) {
+		generator.Parameters = fl.Parameters
+		fl.Parameters = t.placeholderParameters(fl.Parameters)
+	}
+
+	helper := builtins.AsyncToGenerator
+
+	if fl.Generator {
+		helper = builtins.WrapAsyncGenerator
+	}
+
+	if !t.supports(options.Generators) {
+		t.lowerGenerator(generator)
+	}
+
+	// arrow functions pass arguments of the enclosing function only when they use them,
+	// there are none at the module level
+	var invocation ast.IExpr
+
+	if arrow && !usesArguments(fl.Body) {
+		invocation = call(createMember(call(t.helper(helper), generator), "call"), &ast.ThisExpression{})
+	} else {
+		invocation = call(createMember(call(t.helper(helper), generator), "apply"), &ast.ThisExpression{}, createId("arguments"))
+	}
+
+	fl.Async = false
+	fl.Generator = false
+	fl.Body = &ast.FunctionBody{List: ast.Statements{&ast.ReturnStatement{Argument: invocation}}}
+}
+
+// placeholderParameters keeps the length of the function, counted up to the first default value or rest
+func (t *Transpiler) placeholderParameters(fp *ast.FunctionParameters) *ast.FunctionParameters {
+	placeholders := &ast.FunctionParameters{}
+
+	for _, parameter := range fp.List {
+		if _, ok := parameter.(*ast.RestParameter); ok || parameter.GetDefaultValue() != nil {
+			break
+		}
+
+		placeholders.List = append(placeholders.List, &ast.IdentifierParameter{Id: createId(t.uniqueName("_x"))})
+	}
+
+	return placeholders
+}
+
+// lowerAsyncMethod moves super property access to the arrow function declared by the method,
+// super is not allowed in the generator function the body is moved to
+func (t *Transpiler) lowerAsyncMethod(m *ast.ClassMethodStatement) {
+	if !t.isAsyncLowered(m.Async, m.Generator) {
+		return
+	}
+
+	fl := &ast.FunctionLiteral{
+		Async:      m.Async,
+		Generator:  m.Generator,
+		Parameters: m.Parameters,
+		Body:       m.Body,
+	}
+
+	r := newSuperPropertyRewriter(t)
+	r.FunctionParameters(m.Parameters)
+	r.Statements(m.Body.List)
+
+	t.lowerFunction(fl, false)
+
+	if r.getter != "" {
+		// var _superprop_get = (_prop) => super[_prop];
+		property := createId("_prop")
+
+		fl.Body.List = append(ast.Statements{
+			&ast.VariableStatement{
+				Kind: token.VAR,
+				List: []*ast.VariableBinding{
+					{
+						Kind:   token.VAR,
+						Binder: &ast.IdentifierBinder{Id: createId(r.getter)},
+						Initializer: &ast.ArrowFunctionExpression{
+							Parameters: []ast.FunctionParameter{&ast.IdentifierParameter{Id: property}},
+							Body: &ast.FunctionBody{List: ast.Statements{
+								&ast.ReturnStatement{Argument: &ast.MemberExpression{
+									Left:  &ast.SuperExpression{},
+									Right: createId(property.Name),
+									Kind:  ast.MKArray,
+								}},
+							}},
+						},
+					},
+				},
+			},
+		}, fl.Body.List...)
+	}
+
+	m.Async = false
+	m.Generator = false
+	m.Parameters = fl.Parameters
+	m.Body = fl.Body
+}
+
+// awaitRewriter replaces awaits of the async function with yields,
+// nested functions are lowered before
+type awaitRewriter struct {
+	ast.Walker
+
+	t         *Transpiler
+	generator bool // rewriting async generator
+}
+
+func newAwaitRewriter(t *Transpiler, generator bool) *awaitRewriter {
+	r := &awaitRewriter{t: t, generator: generator}
+	r.Walker.Visitor = r
+
+	return r
+}
+
+func (r *awaitRewriter) FunctionLiteral(fl *ast.FunctionLiteral) *ast.FunctionLiteral {
+	return fl
+}
+
+func (r *awaitRewriter) ArrowFunctionExpression(af *ast.ArrowFunctionExpression) *ast.ArrowFunctionExpression {
+	return af
+}
+
+func (r *awaitRewriter) ClassExpression(c *ast.ClassExpression) *ast.ClassExpression {
+	return c
+}
+
+func (r *awaitRewriter) AwaitExpression(exp *ast.AwaitExpression) *ast.AwaitExpression {
+	argument := r.Expression(exp.Expression)
+
+	if r.generator {
+		argument = call(r.t.helper(builtins.AwaitAsyncGenerator), argument)
+	}
+
+	r.Walker.ReplacementExpression = &ast.YieldExpression{Argument: argument}
+
+	return nil
+}
+
+// YieldExpression delegates yield* of async generators to async iterators
+func (r *awaitRewriter) YieldExpression(exp *ast.YieldExpression) *ast.YieldExpression {
+	exp = r.Walker.YieldExpression(exp)
+
+	if exp.Delegate {
+		exp.Argument = call(r.t.helper(builtins.AsyncGeneratorDelegate), call(r.t.helper(builtins.AsyncIterator), exp.Argument))
+	}
+
+	return exp
+}
+
+// superPropertyRewriter replaces super.x with _superprop_get("x")
+type superPropertyRewriter struct {
+	ast.Walker
+
+	t      *Transpiler
+	getter string
+}
+
+func newSuperPropertyRewriter(t *Transpiler) *superPropertyRewriter {
+	r := &superPropertyRewriter{t: t}
+	r.Walker.Visitor = r
+
+	return r
+}
+
+func (r *superPropertyRewriter) FunctionLiteral(fl *ast.FunctionLiteral) *ast.FunctionLiteral {
+	return fl
+}
+
+func (r *superPropertyRewriter) ClassExpression(c *ast.ClassExpression) *ast.ClassExpression {
+	c.SuperClass = r.Expression(c.SuperClass)
+
+	return c
+}
+
+func (r *superPropertyRewriter) get(me *ast.MemberExpression) ast.IExpr {
+	if r.getter == "" {
+		r.getter = r.t.uniqueName("_superprop_get")
+	}
+
+	var name ast.IExpr
+
+	if me.Kind == ast.MKArray {
+		name = r.Expression(me.Right)
+	} else {
+		name = createString(me.Right.(*ast.Identifier).Name)
+	}
+
+	return call(createId(r.getter), name)
+}
+
+func (r *superPropertyRewriter) MemberExpression(me *ast.MemberExpression) ast.IExpr {
+	if _, ok := me.Left.(*ast.SuperExpression); ok {
+		return r.get(me)
+	}
+
+	return r.Walker.MemberExpression(me)
+}
+
+// CallExpression keeps this of super.m() calls, _superprop_get("m").call(this)
+func (r *superPropertyRewriter) CallExpression(ce *ast.CallExpression) *ast.CallExpression {
+	if me, ok := ce.Callee.(*ast.MemberExpression); ok {
+		if _, ok := me.Left.(*ast.SuperExpression); ok {
+			for index, argument := range ce.ArgumentList {
+				ce.ArgumentList[index] = r.Expression(argument)
+			}
+
+			r.Walker.ReplacementExpression = r.t.callWith(r.get(me), &ast.ThisExpression{}, ce.ArgumentList)
+
+			return nil
+		}
+	}
+
+	return r.Walker.CallExpression(ce)
+}
+
+// argumentsFinder looks for arguments of the function,
+// arrow functions share them with the enclosing one
+type argumentsFinder struct {
+	ast.Walker
+
+	found bool
+}
+
+func usesArguments(body *ast.FunctionBody) bool {
+	f := &argumentsFinder{}
+	f.Walker.Visitor = f
+	f.FunctionBody(body)
+
+	return f.found
+}
+
+func (f *argumentsFinder) FunctionLiteral(fl *ast.FunctionLiteral) *ast.FunctionLiteral {
+	return fl
+}
+
+func (f *argumentsFinder) ObjectPropertyName(name ast.ObjectPropertyName) ast.ObjectPropertyName {
+	if computed, ok := name.(*ast.ComputedName); ok {
+		return f.ComputedName(computed)
+	}
+
+	return name
+}
+
+func (f *argumentsFinder) Identifier(id *ast.Identifier) *ast.Identifier {
+	if id != nil && id.Name == "arguments" {
+		f.found = true
+	}
+
+	return id
+}
@@ -13,24 +13,37 @@ func (t *Transpiler) pushExtraVariableBinding(vb *ast.VariableBinding) {
 }
 
 func (t *Transpiler) VariableStatement(vs *ast.VariableStatement) ast.IStmt {
-	// resolve variable kind to ref kind early
+	// resolve variable kind to ref kind early, functions
+	// of initializers have their own variables
+	bindingRefKind := t.bindingRefKind
 	t.bindingRefKind = t.resolveTokenToRefKind(vs.Kind)
 	defer func() {
-		t.bindingRefKind = ast.SRUnknown
+		t.bindingRefKind = bindingRefKind
 	}()
 
-	if t.options.Target < options.ES2015 {
+	if !t.supports(options.BlockScoping) {
 		// in ES5 only var it is
 		vs.Kind = token.VAR
+	}
+
+	if !t.supports(options.Destructuring) {
+		list := make([]*ast.VariableBinding, 0, len(vs.List))
 
-		t.extraVariables = make([]*ast.VariableBinding, 0)
+		// the variables a binding introduces come right before it, bindings are evaluated in order
+		for _, vb := range vs.List {
+			t.extraVariables = make([]*ast.VariableBinding, 0)
+			vb = t.VariableBinding(vb)
 
-		for index, vb := range vs.List {
-			vs.List[index] = t.VariableBinding(vb)
-			vs.Kind = token.VAR
+			list = append(append(list, t.extraVariables...), vb)
 		}
 
-		vs.List = append(t.extraVariables, vs.List...)
+		vs.List = list
+
+		return vs
+	}
+
+	for index, vb := range vs.List {
+		vs.List[index] = t.VariableBinding(vb)
 	}
 
 	return vs
@@ -43,17 +56,39 @@ func (t *Transpiler) VariableBinding(vb *ast.VariableBinding) *ast.VariableBindi
 	// should be treated like this:
 	// `a=1; { const b=a; lob(b) }`
 
-	// for ES2015+ we can keep both var kinds and destructuring as it is, yay
+	// bindings of no kind are array pattern assignments and left sides of for in and for of loops
+	var uninitialized *ast.Identifier
+
+	if vb.Kind == 0 {
+		uninitialized = t.patternBlockScopedAssignment(vb.Binder)
+	}
+
+	if binder, ok := vb.Binder.(*ast.IdentifierBinder); ok {
+		t.inferClassName(vb.Initializer, binder.Id.Name)
+	}
+
+	// when destructuring is supported we can keep it as it is, yay
 	// just have to deal with refs and it is
-	if t.options.Target >= options.ES2015 {
-		t.Expression(vb.Initializer)
-		t.PatternBinder(vb.Binder)
+	if t.supports(options.Destructuring) {
+		vb.Initializer = t.Expression(vb.Initializer)
+
+		if uninitialized != nil && vb.Initializer != nil {
+			t.Walker.ReplacementExpression = sequence([]ast.IExpr{vb.Initializer, t.tdz(uninitialized)})
+
+			return nil
+		}
+
+		vb.Binder = t.PatternBinder(vb.Binder)
 
 		return vb
 	}
 
 	vb.Initializer = t.Expression(vb.Initializer)
 
+	return t.es5VariableBinding(vb)
+}
+
+func (t *Transpiler) es5VariableBinding(vb *ast.VariableBinding) *ast.VariableBinding {
 	// we have to transform destructuring patterns into ES5 stuff
 	// so if we have destructuring patter AND initializer is something
 	// that is not identifier  introduce new variable with that initializer
@@ -71,9 +106,18 @@ func (t *Transpiler) VariableBinding(vb *ast.VariableBinding) *ast.VariableBindi
 func (t *Transpiler) forkVariableBinding(vb *ast.VariableBinding) {
 	ghostId := t.refScope.GhostId()
 
+	if !t.options.Minify {
+		// ghost ids are named like mangled ones, those are unique only when all names are mangled
+		ghostId = createId(t.uniqueName("_ref"))
+	}
+
+	if t.assigningPattern {
+		t.functionScope.Temps = append(t.functionScope.Temps, ghostId)
+	}
+
 	t.pushExtraVariableBinding(&ast.VariableBinding{
 		ExprNode: vb.ExprNode.Copy(),
-		Kind: token.VAR,
+		Kind:     token.VAR,
 		Binder: &ast.IdentifierBinder{
 			Id: ghostId,
 		},
@@ -87,6 +131,8 @@ func (t *Transpiler) es5PatternBinder(pb ast.PatternBinder, vb *ast.VariableBind
 	switch b := pb.(type) {
 	case *ast.IdentifierBinder:
 		vb.Binder = t.IdentifierBinder(b)
+	case *ast.ExpressionBinder:
+		vb.Binder = b
 	case *ast.ObjectBinding:
 		return t.es5ObjectBinding(b, vb)
 	case *ast.ObjectPropertyBinder:
@@ -105,6 +151,11 @@ func (t *Transpiler) es5PatternBinder(pb ast.PatternBinder, vb *ast.VariableBind
 }
 
 func (t *Transpiler) es5ObjectBinding(ob *ast.ObjectBinding, vb *ast.VariableBinding) *ast.VariableBinding {
+	// properties are read from the object evaluated once
+	if _, ok := vb.Initializer.(*ast.Identifier); !ok && len(ob.List) > 1 {
+		t.forkVariableBinding(vb)
+	}
+
 	for index, propBinder := range ob.List {
 		nvb := t.es5PatternBinder(propBinder, vb.Copy())
 
@@ -121,6 +172,12 @@ func (t *Transpiler) es5ObjectBinding(ob *ast.ObjectBinding, vb *ast.VariableBin
 }
 
 func (t *Transpiler) es5ArrayBinding(ab *ast.ArrayBinding, vb *ast.VariableBinding) *ast.VariableBinding {
+	if !t.options.AssumeArrays {
+		// iterables are converted to arrays, taking only the items bound unless there is rest
+		vb.Initializer = t.iterableToArray(ab, vb.Initializer)
+		t.forkVariableBinding(vb)
+	}
+
 	for index, propBinder := range ab.List {
 		nvb := t.es5PatternBinder(propBinder, vb.Copy())
 
@@ -137,24 +194,74 @@ func (t *Transpiler) es5ArrayBinding(ab *ast.ArrayBinding, vb *ast.VariableBindi
 }
 
 func (t *Transpiler) es5ObjectPropertyBinder(opb *ast.ObjectPropertyBinder, vb *ast.VariableBinding) *ast.VariableBinding {
-	vb.Initializer = &ast.MemberExpression{
-		Left:  vb.Initializer,
-		Right: t.Expression(opb.PropertyName),
-		Kind:  ast.MKObject,
+	switch name := opb.PropertyName.(type) {
+	case *ast.Identifier:
+		vb.Initializer = createMember(vb.Initializer, name.Name)
+	case *ast.ComputedName:
+		key := t.Expression(name.Expression)
+
+		if _, ok := opb.Binder.(*ast.ExpressionBinder); ok && !isLiteral(key) {
+			// the key is evaluated before the assignment target
+			temp := t.tempId("key")
+			t.pushExtraVariableBinding(&ast.VariableBinding{
+				Kind:        token.VAR,
+				Binder:      &ast.IdentifierBinder{Id: temp},
+				Initializer: key,
+			})
+			key = createId(temp.Name)
+		}
+
+		vb.Initializer = &ast.MemberExpression{Left: vb.Initializer, Right: key, Kind: ast.MKArray}
+	default:
+		vb.Initializer = &ast.MemberExpression{Left: vb.Initializer, Right: name.(ast.IExpr), Kind: ast.MKArray}
 	}
 
-	if opb.DefaultValue != nil {
-		vb.Initializer = &ast.BinaryExpression{
-			Operator: token.LOGICAL_OR,
-			Left:     vb.Initializer,
-			Right:    t.Expression(opb.DefaultValue),
-		}
+	t.es5DefaultValue(opb.DefaultValue, opb.Binder, vb)
+
+	return t.es5PatternBinder(opb.Binder, vb)
+}
+
+// es5DefaultValue replaces undefined values with the default value,
+// those values are kept by variables as they are read once
+func (t *Transpiler) es5DefaultValue(defaultValue ast.IExpr, binder ast.PatternBinder, vb *ast.VariableBinding) {
+	if defaultValue == nil {
+		return
+	}
+
+	t.forkVariableBinding(vb)
+
+	vb.Initializer = &ast.ConditionalExpression{
+		Test: &ast.BinaryExpression{
+			Operator:   token.STRICT_EQUAL,
+			Left:       vb.Initializer,
+			Right:      undefined(),
+			Comparison: true,
+		},
+		Consequent: t.Expression(defaultValue),
+		Alternate:  vb.Initializer,
+	}
 
-		// we shouldn't init initializer twice, so we have to fork now
+	// we shouldn't init initializer twice, so we have to fork now
+	if _, ok := binder.(*ast.IdentifierBinder); !ok {
 		t.forkVariableBinding(vb)
 	}
+}
 
-	return t.es5PatternBinder(opb.Binder, vb)
+func (t *Transpiler) iterableToArray(ab *ast.ArrayBinding, iterable ast.IExpr) ast.IExpr {
+	count := 0
+
+	for _, binder := range ab.List {
+		switch b := binder.(type) {
+		case *ast.ArrayRestBinder:
+			return call(t.helper(builtins.ToConsumableArray), iterable)
+		case *ast.ArrayItemBinder:
+			if b.Index >= count {
+				count = b.Index + 1
+			}
+		}
+	}
+
+	return call(t.helper(builtins.SlicedToArray), iterable, &ast.NumberLiteral{Literal: strconv.Itoa(count)})
 }
 
 func (t *Transpiler) es5ArrayItemBinder(aib *ast.ArrayItemBinder, vb *ast.VariableBinding) *ast.VariableBinding {
@@ -166,32 +273,21 @@ func (t *Transpiler) es5ArrayItemBinder(aib *ast.ArrayItemBinder, vb *ast.Variab
 		Kind: ast.MKArray,
 	}
 
-	if aib.DefaultValue != nil {
-		vb.Initializer = &ast.BinaryExpression{
-			Operator: token.LOGICAL_OR,
-			Left:     vb.Initializer,
-			Right:    t.Expression(aib.DefaultValue),
-		}
-
-		// we shouldn't init initializer twice, so we have to fork now
-		t.forkVariableBinding(vb)
-	}
+	t.es5DefaultValue(aib.DefaultValue, aib.Binder, vb)
 
 	return t.es5PatternBinder(aib.Binder, vb)
 }
 
 func (t *Transpiler) es5ArrayRestBinder(arb *ast.ArrayRestBinder, vb *ast.VariableBinding) *ast.VariableBinding {
-	vb.Initializer = createArraySlice(vb.Initializer, arb.FromIndex)
+	vb.Initializer = t.createArraySlice(vb.Initializer, arb.FromIndex)
 	vb.Binder = t.PatternBinder(arb.Binder)
 
 	return vb
 }
 
 func (t *Transpiler) es5ObjectRestBinder(orb *ast.ObjectRestBinder, vb *ast.VariableBinding) *ast.VariableBinding {
-	t.module.Additions.ObjectOmit = true
-
 	vb.Initializer = &ast.CallExpression{
-		Callee: builtins.ObjectRest,
+		Callee: t.helper(builtins.ObjectRest),
 		ArgumentList: []ast.IExpr{
 			vb.Initializer,
 			&ast.ArrayLiteral{
@@ -218,3 +314,164 @@ func propertiesToStrings(list []ast.ObjectPropertyName) []ast.IExpr {
 
 	return strs
 }
+
+func isPattern(binder ast.PatternBinder) bool {
+	switch binder.(type) {
+	case *ast.ObjectBinding, *ast.ArrayBinding:
+		return true
+	}
+
+	return false
+}
+
+// assignedPattern returns destructuring assignment lowered for ES5 as the binding, nil for other expressions,
+// those are bindings for array patterns and assignments for object ones
+func (t *Transpiler) assignedPattern(exp ast.IExpr) *ast.VariableBinding {
+	if t.supports(options.Destructuring) {
+		return nil
+	}
+
+	switch e := exp.(type) {
+	case *ast.VariableBinding:
+		if isPattern(e.Binder) {
+			return e
+		}
+	case *ast.AssignmentExpression:
+		if binder, ok := e.Left.(ast.PatternBinder); ok && e.Operator == token.ASSIGN && isPattern(binder) {
+			return &ast.VariableBinding{ExprNode: e.ExprNode, Binder: binder, Initializer: e.Right}
+		}
+	}
+
+	return nil
+}
+
+func (t *Transpiler) ExpressionStatement(stmt *ast.ExpressionStatement) ast.IStmt {
+	if vb := t.assignedPattern(stmt.Expression); vb != nil {
+		stmt.Expression = t.patternAssignment(vb, false)
+
+		return stmt
+	}
+
+	return t.Walker.ExpressionStatement(stmt)
+}
+
+// patternAssignment lowers destructuring assignment for ES5 to assignments in order,
+// the value is kept by a variable when it is the result of the expression
+//
+// [a, b] = [b, a]
+//
+// _ref = [b, a], _ref2 = slicedToArray(_ref, 2), a = _ref2[0], b = _ref2[1]
+func (t *Transpiler) patternAssignment(vb *ast.VariableBinding, used bool) ast.IExpr {
+	vb.Initializer = t.Expression(vb.Initializer)
+
+	extraVariables := t.extraVariables
+	assigningPattern := t.assigningPattern
+	bindingRefKind := t.bindingRefKind
+
+	t.extraVariables = make([]*ast.VariableBinding, 0)
+	t.assigningPattern = true
+	t.bindingRefKind = ast.SRUnknown
+
+	defer func() {
+		t.extraVariables = extraVariables
+		t.assigningPattern = assigningPattern
+		t.bindingRefKind = bindingRefKind
+	}()
+
+	var value ast.IExpr
+
+	if used {
+		t.forkVariableBinding(vb)
+		value = vb.Initializer
+	}
+
+	last := t.es5VariableBinding(vb)
+	list := make([]ast.IExpr, 0, len(t.extraVariables)+2)
+
+	for _, binding := range append(t.extraVariables, last) {
+		switch b := binding.Binder.(type) {
+		case *ast.IdentifierBinder:
+			if t.blockScopedAssignment(b.Id) {
+				list = append(list, binding.Initializer, t.tdz(b.Id))
+			} else {
+				list = append(list, assign(b.Id, binding.Initializer))
+			}
+		case *ast.ExpressionBinder:
+			list = append(list, assign(t.Expression(b.Expression), binding.Initializer))
+		}
+	}
+
+	if value != nil {
+		list = append(list, value)
+	}
+
+	return sequence(list)
+}
+
+// forInPattern moves the pattern of the for in loop to the body for ES5,
+// the loop assigns the key to a variable
+func (t *Transpiler) forInPattern(stmt *ast.ForInStatement) {
+	if t.supports(options.Destructuring) {
+		return
+	}
+
+	var binding *ast.VariableBinding
+	var declaration *ast.VariableStatement
+
+	switch left := stmt.Left.(type) {
+	case *ast.VariableStatement:
+		binding = left.List[0]
+		declaration = left
+	case *ast.ExpressionStatement:
+		binding, _ = left.Expression.(*ast.VariableBinding)
+	}
+
+	if binding == nil || !isPattern(binding.Binder) {
+		return
+	}
+
+	key := t.tempId("key")
+	binding.Initializer = createId(key.Name)
+
+	var head ast.IStmt = &ast.ExpressionStatement{Expression: binding}
+
+	if declaration != nil {
+		head = declaration
+	}
+
+	stmt.Left = &ast.ExpressionStatement{Expression: key}
+	stmt.Body = &ast.BlockStatement{List: ast.Statements{head, stmt.Body}}
+}
+
+// CatchStatement declares the pattern of the catch parameter in the body for ES5,
+// the omitted parameter is named for the targets before ES2019
+func (t *Transpiler) CatchStatement(stmt *ast.CatchStatement) ast.IStmt {
+	t.pushRefScope()
+	defer t.popRefScope()
+
+	if !t.supports(options.Destructuring) && isPattern(stmt.Parameter) {
+		parameter := createId(t.uniqueName("_ref"))
+
+		stmt.Body = &ast.BlockStatement{List: ast.Statements{
+			&ast.VariableStatement{
+				Kind: token.LET,
+				List: []*ast.VariableBinding{{
+					Kind:        token.LET,
+					Binder:      stmt.Parameter,
+					Initializer: createId(parameter.Name),
+				}},
+			},
+			stmt.Body,
+		}}
+		stmt.Parameter = &ast.IdentifierBinder{Id: parameter}
+	}
+
+	if !t.supports(options.OptionalCatchBinding) && stmt.Parameter == nil {
+		stmt.Parameter = &ast.IdentifierBinder{Id: createId(t.uniqueName("_unused"))}
+	}
+
+	stmt.Parameter = t.parameterBinder(stmt.Parameter)
+	stmt.Body = t.Statement(stmt.Body)
+
+	return stmt
+}
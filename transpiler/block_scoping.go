@@ -0,0 +1,776 @@
+package transpiler
+
+import (
+	"yawp/builtins"
+	"yawp/options"
+	"yawp/parser/ast"
+	"yawp/parser/token"
+)
+
+// Block scoped variables are lowered for ES5 to var, shadowing ones are renamed.
+// Loops get the body wrapped into the function called per iteration when closures
+// capture the variables declared by the loop, so every iteration has its own:
+//
+// for (let i = 0; i < 3; i++) { fns.push(() => i); if (i == k) break; }
+//
+// var _loop = function (i) { fns.push(function () { return i; }); if (i == k) return "break"; };
+// for (var i = 0; i < 3; i++) { _ret = _loop(i); if (_ret === "break") break; }
+//
+// The body changing the loop variable copies it out before leaving the function:
+//
+// var _loop = function (_i) { fns.push(function () { return _i; }); _i++; i = _i; };
+
+// bindRef binds the declared id, block scoped ids are renamed for ES5
+// when they shadow the ids of the same function
+func (t *Transpiler) bindRef(kind ast.SymbolRefType, name string) *ast.SymbolRef {
+	ref := t.refScope.BindRef(kind, name)
+
+	if (kind == ast.SRLet || kind == ast.SRConst) && !t.supports(options.BlockScoping) && !t.options.Minify &&
+		ref.Name == name && t.refScope.shadowsInFunction(ref) {
+		ref.Name = t.uniqueName("_" + name)
+	}
+
+	return ref
+}
+
+func (t *Transpiler) ForStatement(stmt *ast.ForStatement) ast.IStmt {
+	if lowered := t.loopClosure(stmt, nil); lowered != nil {
+		t.Walker.ReplacementStatement = lowered
+
+		return nil
+	}
+
+	t.pushRefScope()
+	defer t.popRefScope()
+
+	return t.Walker.ForStatement(stmt)
+}
+
+func (t *Transpiler) ForInStatement(stmt *ast.ForInStatement) ast.IStmt {
+	t.forInPattern(stmt)
+
+	if lowered := t.loopClosure(stmt, nil); lowered != nil {
+		t.Walker.ReplacementStatement = lowered
+
+		return nil
+	}
+
+	t.pushRefScope()
+	defer t.popRefScope()
+
+	return t.Walker.ForInStatement(stmt)
+}
+
+func (t *Transpiler) WhileStatement(stmt *ast.WhileStatement) ast.IStmt {
+	if lowered := t.loopClosure(stmt, nil); lowered != nil {
+		t.Walker.ReplacementStatement = lowered
+
+		return nil
+	}
+
+	return t.Walker.WhileStatement(stmt)
+}
+
+func (t *Transpiler) DoWhileStatement(stmt *ast.DoWhileStatement) ast.IStmt {
+	if lowered := t.loopClosure(stmt, nil); lowered != nil {
+		t.Walker.ReplacementStatement = lowered
+
+		return nil
+	}
+
+	return t.Walker.DoWhileStatement(stmt)
+}
+
+// loopClosure transpiles the loop with the body called per iteration,
+// nil is returned for the loops not needing it
+func (t *Transpiler) loopClosure(loop ast.IStmt, label *ast.Identifier) ast.IStmt {
+	if t.supports(options.BlockScoping) {
+		return nil
+	}
+
+	var head *ast.VariableStatement
+	var body *ast.IStmt
+
+	switch l := loop.(type) {
+	case *ast.ForStatement:
+		head, _ = l.Initializer.(*ast.VariableStatement)
+		body = &l.Body
+	case *ast.ForInStatement:
+		head, _ = l.Left.(*ast.VariableStatement)
+		body = &l.Body
+	case *ast.WhileStatement:
+		body = &l.Body
+	case *ast.DoWhileStatement:
+		body = &l.Body
+	default:
+		return nil
+	}
+
+	initializeLets(*body)
+
+	var params []string
+
+	if head != nil && head.Kind != token.VAR {
+		for _, binding := range head.List {
+			params = append(params, binderNames(binding.Binder)...)
+		}
+	}
+
+	names := make(map[string]bool)
+
+	for _, name := range append(params, blockScopedNames(*body)...) {
+		names[name] = true
+	}
+
+	if len(names) == 0 || !capturesNames(*body, names) {
+		return nil
+	}
+
+	// the body is called by await or yield* when suspending the function the loop is in
+	scope := findScope(*body, nil)
+
+	if scope.yields && scope.arguments {
+		t.error(loop.GetLoc(), errLoopArguments)
+
+		return nil
+	}
+
+	vars, ok := hoistedVarNames(*body)
+
+	if !ok {
+		return nil
+	}
+
+	// for loops keep the variables changed by the body for the next iteration
+	var copyOut ast.Statements
+	args := params
+
+	if _, ok := loop.(*ast.ForStatement); ok {
+		args = make([]string, len(params))
+
+		for index, name := range params {
+			args[index] = name
+
+			if changesName(*body, name) {
+				params[index] = t.uniqueName("_" + name)
+				*body = newClassRenamer(name, params[index]).Statement(*body)
+				copyOut = append(copyOut, &ast.ExpressionStatement{Expression: assign(createId(name), createId(params[index]))})
+			}
+		}
+	}
+
+	rewriter := newLoopBodyRewriter(copyOut)
+	fnBody := append(ast.Statements{rewriter.Statement(*body)}, copyOut...)
+
+	parameters := make([]ast.FunctionParameter, len(params))
+
+	for index, name := range params {
+		parameters[index] = &ast.IdentifierParameter{Id: createId(name)}
+	}
+
+	arguments := make([]ast.IExpr, len(args))
+
+	for index, name := range args {
+		arguments[index] = createId(name)
+	}
+
+	fn := createId(t.uniqueName("_loop"))
+	var function ast.IExpr = &ast.ArrowFunctionExpression{
+		Async:      scope.awaits,
+		Parameters: parameters,
+		Body:       &ast.FunctionBody{List: fnBody},
+	}
+	var iteration ast.Statements
+	var result ast.IExpr = call(createId(fn.Name), arguments...)
+
+	switch {
+	case scope.yields:
+		// generators have no arrow functions, this is passed to the body
+		function = &ast.FunctionLiteral{
+			Async:      scope.awaits,
+			Generator:  true,
+			Parameters: &ast.FunctionParameters{List: parameters},
+			Body:       &ast.FunctionBody{List: fnBody},
+		}
+
+		if scope.this {
+			result = t.callWith(createId(fn.Name), &ast.ThisExpression{}, arguments)
+		}
+
+		result = &ast.YieldExpression{Argument: result, Delegate: true}
+	case scope.awaits:
+		result = &ast.AwaitExpression{Expression: result}
+	}
+
+	if len(rewriter.results) == 0 {
+		iteration = ast.Statements{&ast.ExpressionStatement{Expression: result}}
+	} else {
+		ret := t.tempId("ret")
+		iteration = ast.Statements{&ast.ExpressionStatement{Expression: assign(ret, result)}}
+
+		for _, result := range rewriter.results {
+			iteration = append(iteration, result.check(ret.Name))
+		}
+	}
+
+	*body = &ast.BlockStatement{List: iteration}
+
+	declarations := []*ast.VariableBinding{{
+		Kind:        token.VAR,
+		Binder:      &ast.IdentifierBinder{Id: fn},
+		Initializer: function,
+	}}
+
+	// the variables of the body are declared before the function
+	for _, name := range vars {
+		declarations = append([]*ast.VariableBinding{{
+			Kind:   token.VAR,
+			Binder: &ast.IdentifierBinder{Id: createId(name)},
+		}}, declarations...)
+	}
+
+	// the function and the loop share the variables of the loop
+	t.pushRefScope()
+	defer t.popRefScope()
+
+	kind := ast.SRUnknown
+
+	if head != nil {
+		kind = t.resolveTokenToRefKind(head.Kind)
+		head.Kind = token.VAR
+	}
+
+	for _, name := range args {
+		t.bindRef(kind, name)
+	}
+
+	return t.Statement(ast.Statements{
+		&ast.VariableStatement{Kind: token.VAR, List: declarations},
+		labelled(label, loop),
+	})
+}
+
+// loopResult is the value the body function returns to leave the loop
+type loopResult struct {
+	value  string // "break", "break|label" or "continue|label", empty for returns
+	branch *ast.BranchStatement
+}
+
+func (r *loopResult) check(ret string) ast.IStmt {
+	if r.value == "" {
+		// typeof _ret === "object" is the returned { v: value }
+		return &ast.IfStatement{
+			Test: &ast.BinaryExpression{
+				Operator:   token.STRICT_EQUAL,
+				Left:       &ast.UnaryExpression{Operator: token.TYPEOF, Operand: createId(ret)},
+				Right:      createString("object"),
+				Comparison: true,
+			},
+			Consequent: &ast.ReturnStatement{Argument: createMember(createId(ret), "v")},
+		}
+	}
+
+	return &ast.IfStatement{
+		Test: &ast.BinaryExpression{
+			Operator:   token.STRICT_EQUAL,
+			Left:       createId(ret),
+			Right:      createString(r.value),
+			Comparison: true,
+		},
+		Consequent: r.branch,
+	}
+}
+
+// loopBodyRewriter turns statements leaving the loop body into returns of the body function,
+// variables declared by var are assigned as those are declared before the function
+type loopBodyRewriter struct {
+	ast.Walker
+
+	copyOut ast.Statements
+	results []*loopResult
+
+	loops      int // loops nested in the body, continue is theirs
+	breakables int // loops and switches nested in the body, break is theirs
+	labels     map[string]bool
+}
+
+func newLoopBodyRewriter(copyOut ast.Statements) *loopBodyRewriter {
+	r := &loopBodyRewriter{copyOut: copyOut, labels: make(map[string]bool)}
+	r.Walker.Visitor = r
+
+	return r
+}
+
+func (r *loopBodyRewriter) FunctionLiteral(fl *ast.FunctionLiteral) *ast.FunctionLiteral {
+	return fl
+}
+
+func (r *loopBodyRewriter) ArrowFunctionExpression(af *ast.ArrowFunctionExpression) *ast.ArrowFunctionExpression {
+	return af
+}
+
+func (r *loopBodyRewriter) ClassExpression(c *ast.ClassExpression) *ast.ClassExpression {
+	return c
+}
+
+func (r *loopBodyRewriter) ObjectPropertyName(name ast.ObjectPropertyName) ast.ObjectPropertyName {
+	if computed, ok := name.(*ast.ComputedName); ok {
+		return r.ComputedName(computed)
+	}
+
+	return name
+}
+
+func (r *loopBodyRewriter) VariableStatement(vs *ast.VariableStatement) ast.IStmt {
+	if vs.Kind != token.VAR {
+		return r.Walker.VariableStatement(vs)
+	}
+
+	var list []ast.IExpr
+
+	for _, binding := range vs.List {
+		if binding.Initializer != nil {
+			id := binding.Binder.(*ast.IdentifierBinder).Id
+			list = append(list, assign(id, r.Expression(binding.Initializer)))
+		}
+	}
+
+	if len(list) == 0 {
+		return &ast.EmptyStatement{}
+	}
+
+	return &ast.ExpressionStatement{Expression: sequence(list)}
+}
+
+func (r *loopBodyRewriter) ForStatement(stmt *ast.ForStatement) ast.IStmt {
+	if vs, ok := stmt.Initializer.(*ast.VariableStatement); ok && vs.Kind == token.VAR {
+		if _, ok := r.VariableStatement(vs).(*ast.EmptyStatement); ok {
+			stmt.Initializer = nil
+		} else {
+			stmt.Initializer = r.VariableStatement(vs)
+		}
+	}
+
+	return r.loop(func() ast.IStmt { return r.Walker.ForStatement(stmt) })
+}
+
+func (r *loopBodyRewriter) ForInStatement(stmt *ast.ForInStatement) ast.IStmt {
+	stmt.Left = r.iterationLeft(stmt.Left)
+
+	return r.loop(func() ast.IStmt { return r.Walker.ForInStatement(stmt) })
+}
+
+func (r *loopBodyRewriter) ForOfStatement(stmt *ast.ForOfStatement) ast.IStmt {
+	stmt.Left = r.iterationLeft(stmt.Left)
+
+	return r.loop(func() ast.IStmt { return r.Walker.ForOfStatement(stmt) })
+}
+
+// iterationLeft turns var x of for in and for of statements into x
+func (r *loopBodyRewriter) iterationLeft(left ast.IStmt) ast.IStmt {
+	if vs, ok := left.(*ast.VariableStatement); ok && vs.Kind == token.VAR {
+		return &ast.ExpressionStatement{Expression: vs.List[0].Binder.(*ast.IdentifierBinder).Id}
+	}
+
+	return left
+}
+
+func (r *loopBodyRewriter) WhileStatement(stmt *ast.WhileStatement) ast.IStmt {
+	return r.loop(func() ast.IStmt { return r.Walker.WhileStatement(stmt) })
+}
+
+func (r *loopBodyRewriter) DoWhileStatement(stmt *ast.DoWhileStatement) ast.IStmt {
+	return r.loop(func() ast.IStmt { return r.Walker.DoWhileStatement(stmt) })
+}
+
+func (r *loopBodyRewriter) loop(walk func() ast.IStmt) ast.IStmt {
+	r.loops++
+	r.breakables++
+
+	defer func() {
+		r.loops--
+		r.breakables--
+	}()
+
+	return walk()
+}
+
+func (r *loopBodyRewriter) SwitchStatement(stmt *ast.SwitchStatement) ast.IStmt {
+	r.breakables++
+	defer func() { r.breakables-- }()
+
+	return r.Walker.SwitchStatement(stmt)
+}
+
+func (r *loopBodyRewriter) LabelledStatement(stmt *ast.LabelledStatement) ast.IStmt {
+	nested := r.labels[stmt.Label.Name]
+	r.labels[stmt.Label.Name] = true
+
+	defer func() { r.labels[stmt.Label.Name] = nested }()
+
+	return r.Walker.LabelledStatement(stmt)
+}
+
+func (r *loopBodyRewriter) BranchStatement(stmt *ast.BranchStatement) ast.IStmt {
+	var value string
+
+	switch {
+	case stmt.Label != nil && r.labels[stmt.Label.Name]:
+		return stmt
+	case stmt.Label != nil:
+		value = stmt.Token.String() + "|" + stmt.Label.Name
+	case stmt.Token == token.CONTINUE && r.loops == 0:
+		// continue of the loop is leaving the function
+		return r.leave(nil)
+	case stmt.Token == token.BREAK && r.breakables == 0:
+		value = "break"
+	default:
+		return stmt
+	}
+
+	r.result(value, stmt)
+
+	return r.leave(createString(value))
+}
+
+func (r *loopBodyRewriter) ReturnStatement(stmt *ast.ReturnStatement) ast.IStmt {
+	argument := r.Expression(stmt.Argument)
+
+	if argument == nil {
+		argument = undefined()
+	}
+
+	r.result("", nil)
+
+	return r.leave(&ast.ObjectLiteral{Properties: []ast.ObjectProperty{
+		&ast.ObjectPropertyValue{PropertyName: createId("v"), Value: argument},
+	}})
+}
+
+func (r *loopBodyRewriter) result(value string, branch *ast.BranchStatement) {
+	for _, result := range r.results {
+		if result.value == value {
+			return
+		}
+	}
+
+	r.results = append(r.results, &loopResult{value: value, branch: branch})
+}
+
+// leave returns the value copying out the loop variables first
+func (r *loopBodyRewriter) leave(value ast.IExpr) ast.IStmt {
+	ret := &ast.ReturnStatement{Argument: value}
+
+	if len(r.copyOut) == 0 {
+		return ret
+	}
+
+	return &ast.BlockStatement{List: append(append(ast.Statements{}, r.copyOut...), ret)}
+}
+
+// scopeFinder looks for the names the loop body declares or changes and for
+// the closures capturing them, nested functions are visited only when capturing
+type scopeFinder struct {
+	ast.Walker
+
+	names    map[string]bool
+	closures int
+	declared []*ast.Identifier
+	vars     []string
+	patterns bool // var declaring patterns
+	captured bool
+	changed  bool
+
+	awaits, yields bool // await or yield of the function the loop is in
+	functions      int  // nested functions and classes, those have their own this and arguments
+	this           bool
+	arguments      bool
+}
+
+func newScopeFinder(names map[string]bool) *scopeFinder {
+	f := &scopeFinder{names: names}
+	f.Walker.Visitor = f
+
+	return f
+}
+
+func (f *scopeFinder) FunctionLiteral(fl *ast.FunctionLiteral) *ast.FunctionLiteral {
+	f.closures++
+	f.functions++
+	defer func() { f.closures--; f.functions-- }()
+
+	return f.Walker.FunctionLiteral(fl)
+}
+
+func (f *scopeFinder) ArrowFunctionExpression(af *ast.ArrowFunctionExpression) *ast.ArrowFunctionExpression {
+	f.closures++
+	defer func() { f.closures-- }()
+
+	return f.Walker.ArrowFunctionExpression(af)
+}
+
+func (f *scopeFinder) ClassExpression(c *ast.ClassExpression) *ast.ClassExpression {
+	f.closures++
+	f.functions++
+	defer func() { f.closures--; f.functions-- }()
+
+	return f.Walker.ClassExpression(c)
+}
+
+func (f *scopeFinder) ObjectPropertyName(name ast.ObjectPropertyName) ast.ObjectPropertyName {
+	if computed, ok := name.(*ast.ComputedName); ok {
+		return f.ComputedName(computed)
+	}
+
+	return name
+}
+
+func (f *scopeFinder) VariableStatement(vs *ast.VariableStatement) ast.IStmt {
+	if f.closures == 0 {
+		for _, binding := range vs.List {
+			if vs.Kind != token.VAR {
+				f.declared = append(f.declared, binderIds(binding.Binder)...)
+			} else if id, ok := binding.Binder.(*ast.IdentifierBinder); ok {
+				f.vars = append(f.vars, id.Id.Name)
+			} else {
+				f.patterns = true
+			}
+		}
+	}
+
+	return f.Walker.VariableStatement(vs)
+}
+
+func (f *scopeFinder) Identifier(id *ast.Identifier) *ast.Identifier {
+	if id != nil && f.closures > 0 && f.names[id.Name] {
+		f.captured = true
+	}
+
+	if id != nil && f.functions == 0 && id.Name == "arguments" {
+		f.arguments = true
+	}
+
+	return id
+}
+
+func (f *scopeFinder) ThisExpression(te *ast.ThisExpression) *ast.ThisExpression {
+	if f.functions == 0 {
+		f.this = true
+	}
+
+	return te
+}
+
+func (f *scopeFinder) AssignExpression(exp *ast.AssignmentExpression) *ast.AssignmentExpression {
+	if id, ok := exp.Left.(*ast.Identifier); ok && f.names[id.Name] {
+		f.changed = true
+	}
+
+	return f.Walker.AssignExpression(exp)
+}
+
+func (f *scopeFinder) UnaryExpression(exp *ast.UnaryExpression) *ast.UnaryExpression {
+	if id, ok := exp.Operand.(*ast.Identifier); ok && f.names[id.Name] &&
+		(exp.Operator == token.INCREMENT || exp.Operator == token.DECREMENT) {
+		f.changed = true
+	}
+
+	return f.Walker.UnaryExpression(exp)
+}
+
+func (f *scopeFinder) AwaitExpression(exp *ast.AwaitExpression) *ast.AwaitExpression {
+	if f.closures == 0 {
+		f.awaits = true
+	}
+
+	return f.Walker.AwaitExpression(exp)
+}
+
+func (f *scopeFinder) YieldExpression(exp *ast.YieldExpression) *ast.YieldExpression {
+	if f.closures == 0 {
+		f.yields = true
+	}
+
+	return f.Walker.YieldExpression(exp)
+}
+
+func findScope(body ast.IStmt, names map[string]bool) *scopeFinder {
+	f := newScopeFinder(names)
+	f.Statement(body)
+
+	return f
+}
+
+// blockScopedNames returns the names declared by let and const of the body
+func blockScopedNames(body ast.IStmt) []string {
+	var names []string
+
+	for _, id := range findScope(body, nil).declared {
+		names = append(names, id.Name)
+	}
+
+	return names
+}
+
+func capturesNames(body ast.IStmt, names map[string]bool) bool {
+	return findScope(body, names).captured
+}
+
+func changesName(body ast.IStmt, name string) bool {
+	return findScope(body, map[string]bool{name: true}).changed
+}
+
+// hoistedVarNames returns the names declared by var of the body,
+// false is returned for the patterns those can not be assigned yet
+func hoistedVarNames(body ast.IStmt) ([]string, bool) {
+	f := findScope(body, nil)
+
+	return f.vars, !f.patterns
+}
+
+// initializeLets sets let without initializer of the loop body to undefined,
+// var keeps the value of the previous iteration
+type letInitializer struct {
+	ast.Walker
+}
+
+func initializeLets(body ast.IStmt) {
+	i := &letInitializer{}
+	i.Walker.Visitor = i
+	i.Statement(body)
+}
+
+func (i *letInitializer) FunctionLiteral(fl *ast.FunctionLiteral) *ast.FunctionLiteral {
+	return fl
+}
+
+func (i *letInitializer) ArrowFunctionExpression(af *ast.ArrowFunctionExpression) *ast.ArrowFunctionExpression {
+	return af
+}
+
+func (i *letInitializer) ClassExpression(c *ast.ClassExpression) *ast.ClassExpression {
+	return c
+}
+
+func (i *letInitializer) ForInStatement(stmt *ast.ForInStatement) ast.IStmt {
+	stmt.Body = i.Statement(stmt.Body)
+
+	return stmt
+}
+
+func (i *letInitializer) ForOfStatement(stmt *ast.ForOfStatement) ast.IStmt {
+	stmt.Body = i.Statement(stmt.Body)
+
+	return stmt
+}
+
+func (i *letInitializer) Expression(exp ast.IExpr) ast.IExpr {
+	return exp
+}
+
+func (i *letInitializer) VariableStatement(vs *ast.VariableStatement) ast.IStmt {
+	if vs.Kind != token.LET {
+		return vs
+	}
+
+	for _, binding := range vs.List {
+		if binding.Initializer == nil {
+			binding.Initializer = undefined()
+		}
+	}
+
+	return vs
+}
+
+// trackUninitialized starts TDZ checks of let and const the function body declares,
+// the returned function restores checks of the enclosing function
+func (t *Transpiler) trackUninitialized(body ast.IStmt) func() {
+	uninitialized := t.uninitialized
+	t.uninitialized = nil
+
+	if t.options.TDZ && !t.supports(options.BlockScoping) {
+		t.uninitialized = make(map[*ast.SymbolRef]bool)
+
+		for _, id := range findScope(body, nil).declared {
+			if id.Symbol != nil && id.Symbol.Ref != nil {
+				t.uninitialized[id.Symbol.Ref] = true
+			}
+		}
+	}
+
+	return func() {
+		t.uninitialized = uninitialized
+	}
+}
+
+func (t *Transpiler) isUninitialized(id *ast.Identifier) bool {
+	return t.uninitialized != nil && id.Symbol != nil && id.Symbol.Ref != nil && t.uninitialized[id.Symbol.Ref]
+}
+
+// initialize ends TDZ of the declared id
+func (t *Transpiler) initialize(id *ast.Identifier) {
+	if t.uninitialized != nil && id.Symbol != nil {
+		delete(t.uninitialized, id.Symbol.Ref)
+	}
+}
+
+func (t *Transpiler) tdz(id *ast.Identifier) ast.IExpr {
+	return call(t.helper(builtins.TDZ), createString(id.Name))
+}
+
+func (t *Transpiler) AssignExpression(exp *ast.AssignmentExpression) *ast.AssignmentExpression {
+	if id, ok := exp.Left.(*ast.Identifier); ok && t.blockScopedAssignment(id) {
+		// the value is evaluated before the assignment throws, unless it reads the id
+		if exp.Operator == token.ASSIGN {
+			t.Walker.ReplacementExpression = sequence([]ast.IExpr{t.Expression(exp.Right), t.tdz(id)})
+		} else {
+			t.Walker.ReplacementExpression = t.tdz(id)
+		}
+
+		return nil
+	}
+
+	if binder, ok := exp.Left.(ast.PatternBinder); ok && isPattern(binder) {
+		if id := t.patternBlockScopedAssignment(binder); id != nil {
+			t.Walker.ReplacementExpression = sequence([]ast.IExpr{t.Expression(exp.Right), t.tdz(id)})
+
+			return nil
+		}
+	}
+
+	if lowered := t.exponentAssignment(exp); lowered != nil {
+		t.Walker.ReplacementExpression = lowered
+
+		return nil
+	}
+
+	if id, ok := exp.Left.(*ast.Identifier); ok && exp.Operator == token.ASSIGN {
+		t.inferClassName(exp.Right, id.Name)
+	}
+
+	return t.Walker.AssignExpression(exp)
+}
+
+// blockScopedAssignment checks the assigned id, const is reported as those are lowered to var,
+// true is returned for the ids assigned before the declaration
+func (t *Transpiler) blockScopedAssignment(id *ast.Identifier) bool {
+	if !t.supports(options.BlockScoping) && id.Symbol != nil && id.Symbol.Ref != nil && id.Symbol.Ref.Type == ast.SRConst {
+		t.error(id.Loc, errConstAssignment, id.Name)
+	}
+
+	return t.isUninitialized(id)
+}
+
+// patternBlockScopedAssignment checks every id assigned by the pattern,
+// the first id assigned before the declaration is returned
+func (t *Transpiler) patternBlockScopedAssignment(binder ast.PatternBinder) *ast.Identifier {
+	var uninitialized *ast.Identifier
+
+	for _, id := range binderIds(binder) {
+		if t.blockScopedAssignment(id) && uninitialized == nil {
+			uninitialized = id
+		}
+	}
+
+	return uninitialized
+}
@@ -0,0 +1,87 @@
+package transpiler
+
+import (
+	"yawp/options"
+	"yawp/parser/ast"
+)
+
+func (t *Transpiler) ClassStatement(c *ast.ClassStatement) ast.IStmt {
+	if lowering := t.lowerClass(c.Expression); lowering != nil {
+		t.Walker.ReplacementStatement = t.Statement(lowering.statements(c))
+
+		return nil
+	}
+
+	if !t.supports(options.Classes) {
+		t.Walker.ReplacementStatement = t.Statement(t.classES5Declaration(c.Expression))
+
+		return nil
+	}
+
+	return t.Walker.ClassStatement(c)
+}
+
+// ClassExpression is visited directly by class statements and exports,
+// those lower classes themselves, so replacement is set only for expressions
+func (t *Transpiler) ClassExpression(c *ast.ClassExpression) *ast.ClassExpression {
+	for lowering := t.lowerClass(c); lowering != nil; lowering = t.lowerClass(c) {
+		if exp := lowering.expression(); exp != nil {
+			t.Walker.ReplacementExpression = t.Expression(exp)
+
+			return nil
+		}
+	}
+
+	if !t.supports(options.Classes) {
+		t.Walker.ReplacementExpression = t.Expression(t.classES5(c))
+
+		return nil
+	}
+
+	derivedClass := t.derivedClass
+	t.derivedClass = c.SuperClass != nil
+	defer func() {
+		t.derivedClass = derivedClass
+	}()
+
+	return t.Walker.ClassExpression(c)
+}
+
+// lowerClass runs the first of class transformations needed by the target,
+// the lowered class is visited again for the next ones
+func (t *Transpiler) lowerClass(c *ast.ClassExpression) decoration {
+	if t.module.TypeScript {
+		tsClassMembers(c)
+	}
+
+	switch {
+	case isDecorated(c):
+		return t.decorate(c)
+	case !t.supports(options.ClassFields) && hasFields(c):
+		return t.lowerFields(c)
+	case t.module.TypeScript && hasParameterProperties(c) && hasFields(c):
+		// field initializers run after parameter properties are assigned
+		return t.lowerFields(c)
+	}
+
+	// once the fields are lowered, so the assignments go before the initializers
+	if t.module.TypeScript {
+		tsParameterProperties(c)
+	}
+
+	return nil
+}
+
+func (t *Transpiler) ClassMethodStatement(m *ast.ClassMethodStatement) ast.IStmt {
+	m.Name = t.ObjectPropertyName(m.Name)
+	var newTarget func() ast.IExpr
+
+	if isConstructor(m) {
+		newTarget = constructorNewTarget
+	}
+
+	m.Parameters, m.Body = t.function(m.Parameters, m.Body, t.derivedClass && isConstructor(m), newTarget)
+	t.lowerAsyncMethod(m)
+
+	return m
+}
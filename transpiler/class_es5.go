@@ -0,0 +1,335 @@
+package transpiler
+
+import (
+	"yawp/builtins"
+	"yawp/options"
+	"yawp/parser/ast"
+	"yawp/parser/token"
+)
+
+// ES5 classes are constructor functions defined by the function called with the super class:
+//
+// var A = function (_B) {
+//   @inherits(A, _B);
+//   function A() { var _this; @classCallCheck(this, A); _this = _B.call(this) || this; _this.x = 1; return _this; }
+//   Object.defineProperty(A.prototype, 'm', { value: function () { return @superGet(_B.prototype, 'm', this).call(this); }, writable: true, configurable: true });
+//   Object.defineProperty(A.prototype, 'g', { get: function () {}, configurable: true });
+//   Object.defineProperty(A, 's', { value: function () {}, writable: true, configurable: true });
+//   return A;
+// }(B);
+
+type es5Class struct {
+	t     *Transpiler
+	class *ast.ClassExpression
+
+	name      string // constructor function name
+	superName string // parameter holding the super class, empty for base classes
+
+	body        ast.Statements
+	accessors   map[string]*ast.ObjectLiteral
+	constructor *ast.ClassMethodStatement
+}
+
+func (t *Transpiler) classES5Declaration(c *ast.ClassExpression) *ast.VariableStatement {
+	return &ast.VariableStatement{
+		Kind: token.LET,
+		List: []*ast.VariableBinding{
+			{
+				Kind:        token.LET,
+				Binder:      &ast.IdentifierBinder{Id: createId(c.Name.Name)},
+				Initializer: t.classES5(c),
+			},
+		},
+	}
+}
+
+func (t *Transpiler) classES5(c *ast.ClassExpression) ast.IExpr {
+	e := &es5Class{
+		t:         t,
+		class:     c,
+		accessors: make(map[string]*ast.ObjectLiteral),
+	}
+
+	if c.Name != nil {
+		e.name = c.Name.Name
+	} else if name := t.classNames[c]; name != "" && !referencesName(c.Body, name) {
+		// the constructor function would shadow the variable the class refers to
+		e.name = name
+	} else {
+		e.name = t.uniqueName("_class")
+	}
+
+	parameters := &ast.FunctionParameters{}
+	var arguments []ast.IExpr
+
+	if c.SuperClass != nil {
+		hint := "super"
+
+		if id, ok := c.SuperClass.(*ast.Identifier); ok {
+			hint = id.Name
+		}
+
+		e.superName = t.uniqueName("_" + hint)
+		parameters.List = append(parameters.List, &ast.IdentifierParameter{Id: createId(e.superName)})
+		arguments = append(arguments, c.SuperClass)
+
+		e.body = append(e.body, &ast.ExpressionStatement{
+			Expression: call(t.helper(builtins.Inherits), createId(e.name), createId(e.superName)),
+		})
+	}
+
+	prelude := len(e.body)
+
+	// fields and private members are lowered before
+	for _, member := range c.Body.(*ast.BlockStatement).List {
+		switch m := member.(type) {
+		case *ast.ClassMethodStatement:
+			if isConstructor(m) {
+				e.constructor = m
+			} else {
+				e.method(m)
+			}
+		case *ast.ClassAccessorStatement:
+			e.accessor(m)
+		}
+	}
+
+	// constructor is defined before the members referencing it
+	body := make(ast.Statements, 0, len(e.body)+2)
+	body = append(body, e.body[:prelude]...)
+	body = append(body, e.constructorFunction())
+	body = append(body, e.body[prelude:]...)
+	body = append(body, &ast.ReturnStatement{Argument: createId(e.name)})
+
+	return call(&ast.FunctionLiteral{
+		Parameters: parameters,
+		Body:       &ast.FunctionBody{List: body},
+	}, arguments...)
+}
+
+// inferClassName names the anonymous class after the variable, property or assignment target
+// the class is the value of, as the constructor function of ES5 class is not named otherwise
+//
+// const A = class {};
+// const A = function () { function A() {} return A; }();
+func (t *Transpiler) inferClassName(exp ast.IExpr, name string) {
+	c, ok := exp.(*ast.ClassExpression)
+
+	// mangled names may be shadowed by the constructor function
+	if !ok || c.Name != nil || t.supports(options.Classes) || t.options.Minify {
+		return
+	}
+
+	// strict mode functions can not be named by reserved words, eval or arguments
+	if keyword, _ := token.IsKeyword(name); keyword != 0 || name == "eval" || name == "arguments" {
+		return
+	}
+
+	t.classNames[c] = name
+}
+
+func referencesName(stmt ast.IStmt, name string) bool {
+	c := newNameCollector()
+	c.Statement(stmt)
+
+	return c.names[name]
+}
+
+// target is the constructor for static members and its prototype for the rest
+func (e *es5Class) target(static bool) ast.IExpr {
+	if static {
+		return createId(e.name)
+	}
+
+	return createMember(createId(e.name), "prototype")
+}
+
+// property builds target.name or target[name] member
+func property(target ast.IExpr, name ast.ObjectPropertyName) *ast.MemberExpression {
+	switch n := name.(type) {
+	case *ast.Identifier:
+		return createMember(target, n.Name)
+	case *ast.ComputedName:
+		return &ast.MemberExpression{Left: target, Right: n.Expression, Kind: ast.MKArray}
+	}
+
+	return &ast.MemberExpression{Left: target, Right: name, Kind: ast.MKArray}
+}
+
+func (e *es5Class) statement(exp ast.IExpr) {
+	e.body = append(e.body, &ast.ExpressionStatement{Expression: exp})
+}
+
+func (e *es5Class) method(m *ast.ClassMethodStatement) {
+	e.rewrite(m.Body.List, m.Static, "")
+
+	// methods are not enumerable like those of classes
+	e.statement(call(createMember(createId("Object"), "defineProperty"), e.target(m.Static), propertyKey(m.Name), &ast.ObjectLiteral{
+		Properties: []ast.ObjectProperty{
+			&ast.ObjectPropertyValue{
+				PropertyName: createId("value"),
+				Value: &ast.FunctionLiteral{
+					Async:      m.Async,
+					Generator:  m.Generator,
+					Parameters: m.Parameters,
+					Body:       m.Body,
+				},
+			},
+			&ast.ObjectPropertyValue{PropertyName: createId("writable"), Value: &ast.BooleanLiteral{Literal: "true"}},
+			&ast.ObjectPropertyValue{PropertyName: createId("configurable"), Value: &ast.BooleanLiteral{Literal: "true"}},
+		},
+	}))
+}
+
+// accessor defines getter and setter of the same name by the single Object.defineProperty call
+func (e *es5Class) accessor(a *ast.ClassAccessorStatement) {
+	e.rewrite(a.Body.Body.List, a.Static, "")
+
+	function := &ast.ObjectPropertyValue{PropertyName: createId(a.Kind), Value: a.Body}
+
+	var name ast.IExpr
+	key := ""
+
+	switch n := a.Field.(type) {
+	case *ast.Identifier:
+		name = createString(n.Name)
+		key = n.Name
+	case *ast.StringLiteral:
+		name = n
+		key = n.Literal
+	case *ast.NumberLiteral:
+		name = n
+		key = n.Literal
+	case *ast.ComputedName:
+		name = n.Expression
+	}
+
+	if a.Static {
+		key = "static " + key
+	}
+
+	_, computed := a.Field.(*ast.ComputedName)
+
+	if descriptor, ok := e.accessors[key]; ok && !computed {
+		// configurable stays the last one
+		last := len(descriptor.Properties) - 1
+		descriptor.Properties = append(descriptor.Properties[:last], function, descriptor.Properties[last])
+
+		return
+	}
+
+	descriptor := &ast.ObjectLiteral{
+		Properties: []ast.ObjectProperty{
+			function,
+			&ast.ObjectPropertyValue{PropertyName: createId("configurable"), Value: &ast.BooleanLiteral{Literal: "true"}},
+		},
+	}
+
+	if !computed {
+		e.accessors[key] = descriptor
+	}
+
+	e.statement(call(createMember(createId("Object"), "defineProperty"), e.target(a.Static), name, descriptor))
+}
+
+// constructorFunction builds function A() {} with the class call check,
+// in derived classes this is replaced with the value returned by the super class constructor
+func (e *es5Class) constructorFunction() *ast.FunctionLiteral {
+	check := &ast.ExpressionStatement{
+		Expression: call(e.t.helper(builtins.ClassCallCheck), &ast.ThisExpression{}, createId(e.name)),
+	}
+
+	function := &ast.FunctionLiteral{
+		Id:         createId(e.name),
+		Parameters: &ast.FunctionParameters{},
+		Body:       &ast.FunctionBody{},
+	}
+
+	if e.constructor == nil && e.superName != "" {
+		// return _B.apply(this, arguments) || this
+		function.Body.List = ast.Statements{
+			check,
+			&ast.ReturnStatement{
+				Argument: &ast.BinaryExpression{
+					Operator: token.LOGICAL_OR,
+					Left: call(
+						createMember(createId(e.superName), "apply"),
+						&ast.ThisExpression{},
+						createId("arguments"),
+					),
+					Right: &ast.ThisExpression{},
+				},
+			},
+		}
+
+		return function
+	}
+
+	var body ast.Statements
+
+	if e.constructor != nil {
+		function.Parameters = e.constructor.Parameters
+		body = e.constructor.Body.List
+	}
+
+	if e.superName == "" {
+		e.rewrite(body, false, "")
+		function.Body.List = append(ast.Statements{check}, body...)
+
+		return function
+	}
+
+	this := e.t.uniqueName("_this")
+	e.rewrite(body, false, this)
+
+	if len(body) == 0 || !isReturn(body[len(body)-1]) {
+		body = append(body, &ast.ReturnStatement{Argument: createId(this)})
+	}
+
+	function.Body.List = append(ast.Statements{
+		&ast.VariableStatement{
+			Kind: token.VAR,
+			List: []*ast.VariableBinding{
+				{
+					Kind:   token.VAR,
+					Binder: &ast.IdentifierBinder{Id: createId(this)},
+				},
+			},
+		},
+		check,
+	}, body...)
+
+	return function
+}
+
+func isReturn(stmt ast.IStmt) bool {
+	_, ok := stmt.(*ast.ReturnStatement)
+
+	return ok
+}
+
+// rewrite replaces super with the super class and this with the given name
+func (e *es5Class) rewrite(list ast.Statements, static bool, this string) {
+	e.rewriter(static, this).Statements(list)
+}
+
+func (e *es5Class) rewriter(static bool, this string) *superRewriter {
+	return newSuperRewriter(e.t, func() ast.IExpr {
+		return e.superTarget(static)
+	}, e.superName, this)
+}
+
+// superTarget is where super members are looked up,
+// base classes inherit from Object and Function
+func (e *es5Class) superTarget(static bool) ast.IExpr {
+	switch {
+	case e.superName == "" && static:
+		return createMember(createId("Function"), "prototype")
+	case e.superName == "":
+		return createMember(createId("Object"), "prototype")
+	case static:
+		return createId(e.superName)
+	}
+
+	return createMember(createId(e.superName), "prototype")
+}
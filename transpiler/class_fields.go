@@ -0,0 +1,723 @@
+package transpiler
+
+import (
+	"yawp/builtins"
+	"yawp/parser/ast"
+	"yawp/parser/token"
+)
+
+// Class fields are lowered for targets before ES2022. Instance fields are initialized by the
+// constructor right after super() call, static fields are defined once the class is defined.
+// Private members are kept outside of the class, instance ones by WeakMaps and WeakSets:
+//
+// _x = new WeakMap(), _m = new WeakSet(), _m2 = function () {};
+// class A { constructor() { _m.add(this); _x.set(this, { writable: true, value: 1 }); @defineProperty(this, 'y', 2); } }
+// @defineProperty(A, 's', 3);
+
+// kinds of private members
+const (
+	privateField = iota
+	privateMethod
+	privateAccessor
+)
+
+type privateName struct {
+	kind   int
+	static bool
+
+	storage  string // WeakMap or WeakSet of instances, descriptor or function of static members
+	function string // instance method
+	get, set string // accessor functions
+}
+
+type classFields struct {
+	t     *Transpiler
+	class *ast.ClassExpression
+	ref   *ast.Identifier // temp holding anonymous class
+
+	privates map[string]*privateName
+
+	before       []ast.IExpr // private storages and computed keys evaluated before the class
+	brands       []ast.IStmt // private methods and accessors are added to instances before fields
+	initializers []ast.IStmt
+	after        []ast.IExpr // static fields
+}
+
+// hasFields reports whether class has fields or private members to lower
+func hasFields(c *ast.ClassExpression) bool {
+	body, ok := c.Body.(*ast.BlockStatement)
+
+	if !ok {
+		return false
+	}
+
+	for _, member := range body.List {
+		switch m := member.(type) {
+		case *ast.ClassFieldStatement:
+			return true
+		case *ast.ClassMethodStatement:
+			if m.Private {
+				return true
+			}
+		case *ast.ClassAccessorStatement:
+			if m.Private {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// lowerFields removes fields and private members from the class,
+// private names are replaced by the helper calls in the whole class body
+func (t *Transpiler) lowerFields(c *ast.ClassExpression) *classFields {
+	body := c.Body.(*ast.BlockStatement)
+
+	f := &classFields{
+		t:        t,
+		class:    c,
+		privates: make(map[string]*privateName),
+	}
+
+	if c.Name == nil {
+		f.ref = t.tempId("class")
+	}
+
+	for _, member := range body.List {
+		switch m := member.(type) {
+		case *ast.ClassFieldStatement:
+			if m.Private && !t.staticBlocks[m] {
+				f.declare(m.Name, privateField, m.Static)
+			}
+		case *ast.ClassMethodStatement:
+			if m.Private {
+				f.declare(m.Name, privateMethod, m.Static)
+			}
+		case *ast.ClassAccessorStatement:
+			if m.Private {
+				f.declare(m.Field, privateAccessor, m.Static)
+			}
+		}
+	}
+
+	newPrivateRewriter(f).Statements(body.List)
+	f.computedKeys(body.List)
+
+	members := make([]ast.IStmt, 0, len(body.List))
+
+	for _, member := range body.List {
+		switch m := member.(type) {
+		case *ast.ClassFieldStatement:
+			f.field(m)
+
+			continue
+		case *ast.ClassMethodStatement:
+			if m.Private {
+				f.method(m)
+
+				continue
+			}
+		case *ast.ClassAccessorStatement:
+			if m.Private {
+				f.accessor(m)
+
+				continue
+			}
+		}
+
+		members = append(members, member)
+	}
+
+	f.describeAccessors()
+
+	if initializers := append(f.brands, f.initializers...); len(initializers) > 0 {
+		members = addToConstructor(members, c.SuperClass != nil, initializers...)
+	}
+
+	body.List = members
+
+	return f
+}
+
+func (f *classFields) statements(stmt ast.IStmt) ast.Statements {
+	lowered := make(ast.Statements, 0, 2+len(f.after))
+
+	if len(f.before) > 0 {
+		lowered = append(lowered, &ast.ExpressionStatement{Expression: sequence(f.before)})
+	}
+
+	lowered = append(lowered, stmt)
+
+	for _, exp := range f.after {
+		lowered = append(lowered, &ast.ExpressionStatement{Expression: exp})
+	}
+
+	return lowered
+}
+
+// expression builds (_x = new WeakMap(), _class = class {}, _class.s = 1, _class),
+// class name is not bound outside of class expression so moved code references the temp
+func (f *classFields) expression() ast.IExpr {
+	if f.ref == nil {
+		f.ref = f.t.tempId(f.class.Name.Name)
+
+		renamer := newClassRenamer(f.class.Name.Name, f.ref.Name)
+
+		for index, exp := range f.before {
+			f.before[index] = renamer.Expression(exp)
+		}
+
+		for index, exp := range f.after {
+			f.after[index] = renamer.Expression(exp)
+		}
+	}
+
+	list := append(f.before, assign(createId(f.ref.Name), f.class))
+	list = append(list, f.after...)
+
+	return sequence(append(list, createId(f.ref.Name)))
+}
+
+// target is the class or its prototype for instance members
+func (f *classFields) target(proto bool) ast.IExpr {
+	name := ""
+
+	if f.ref != nil {
+		name = f.ref.Name
+	} else {
+		name = f.class.Name.Name
+	}
+
+	if proto {
+		return createMember(createId(name), "prototype")
+	}
+
+	return createId(name)
+}
+
+// superTarget is Object.getPrototypeOf(A.prototype) for instance members
+func (f *classFields) superTarget(static bool) ast.IExpr {
+	return call(createMember(createId("Object"), "getPrototypeOf"), f.target(!static))
+}
+
+func (f *classFields) rewriter(static bool, this string) *superRewriter {
+	return newSuperRewriter(f.t, func() ast.IExpr {
+		return f.superTarget(static)
+	}, "", this)
+}
+
+func (f *classFields) declare(name ast.ObjectPropertyName, kind int, static bool) {
+	key := name.(*ast.Identifier).Name
+
+	if p, ok := f.privates[key]; ok {
+		// getter and setter share the name
+		p.kind = privateAccessor
+
+		return
+	}
+
+	p := &privateName{
+		kind:    kind,
+		static:  static,
+		storage: f.t.tempId(key).Name,
+	}
+
+	f.privates[key] = p
+
+	if static {
+		return
+	}
+
+	if kind == privateMethod {
+		p.function = f.t.tempId(key).Name
+	}
+
+	switch kind {
+	case privateField, privateAccessor:
+		f.before = append(f.before, assign(createId(p.storage), &ast.NewExpression{Callee: createId("WeakMap")}))
+	case privateMethod:
+		f.before = append(f.before, assign(createId(p.storage), &ast.NewExpression{Callee: createId("WeakSet")}))
+		f.brands = append(f.brands, &ast.ExpressionStatement{
+			Expression: call(createMember(createId(p.storage), "add"), &ast.ThisExpression{}),
+		})
+	}
+}
+
+// computedKeys saves computed keys to temps as the class evaluates them only once, those of methods
+// and accessors too when keys of fields are moved before the class, so the keys are evaluated in order
+func (f *classFields) computedKeys(members ast.Statements) {
+	fields := false
+
+	for _, member := range members {
+		if field, ok := member.(*ast.ClassFieldStatement); ok {
+			if _, ok := field.Name.(*ast.ComputedName); ok {
+				fields = true
+			}
+		}
+	}
+
+	if !fields {
+		return
+	}
+
+	for _, member := range members {
+		switch m := member.(type) {
+		case *ast.ClassFieldStatement:
+			m.Name = f.computedKey(m.Name)
+		case *ast.ClassMethodStatement:
+			m.Name = f.computedKey(m.Name)
+		case *ast.ClassAccessorStatement:
+			m.Field = f.computedKey(m.Field)
+		}
+	}
+}
+
+func (f *classFields) computedKey(name ast.ObjectPropertyName) ast.ObjectPropertyName {
+	computed, ok := name.(*ast.ComputedName)
+
+	if !ok {
+		return name
+	}
+
+	key := f.t.tempId("key")
+	f.before = append(f.before, assign(key, computed.Expression))
+
+	return &ast.ComputedName{Expression: createId(key.Name)}
+}
+
+// field moves initializer to the constructor or after the class
+func (f *classFields) field(field *ast.ClassFieldStatement) {
+	value := field.Initializer
+
+	if value == nil {
+		value = &ast.UnaryExpression{
+			Operator: token.VOID,
+			Operand:  &ast.NumberLiteral{Literal: "0"},
+		}
+	}
+
+	if field.Static {
+		// this is the class itself
+		value = f.rewriter(true, f.target(false).(*ast.Identifier).Name).Expression(value)
+	}
+
+	if f.t.staticBlocks[field] {
+		f.after = append(f.after, value)
+
+		return
+	}
+
+	name := field.Name
+
+	if field.Private {
+		p := f.privates[name.(*ast.Identifier).Name]
+
+		descriptor := &ast.ObjectLiteral{
+			Properties: []ast.ObjectProperty{
+				&ast.ObjectPropertyValue{PropertyName: createId("writable"), Value: &ast.BooleanLiteral{Literal: "true"}},
+				&ast.ObjectPropertyValue{PropertyName: createId("value"), Value: value},
+			},
+		}
+
+		if field.Static {
+			f.after = append(f.after, assign(createId(p.storage), descriptor))
+		} else {
+			f.initializers = append(f.initializers, &ast.ExpressionStatement{
+				Expression: call(createMember(createId(p.storage), "set"), &ast.ThisExpression{}, descriptor),
+			})
+		}
+
+		return
+	}
+
+	var target ast.IExpr = &ast.ThisExpression{}
+
+	if field.Static {
+		target = f.target(false)
+	}
+
+	// fields are defined, assignment calls setters and fails on read only properties like name of functions
+	var initializer ast.IExpr
+
+	if f.t.options.SetPublicClassFields {
+		initializer = assign(property(target, name), value)
+	} else {
+		initializer = call(f.t.helper(builtins.DefineProperty), target, propertyKey(name), value)
+	}
+
+	if field.Static {
+		f.after = append(f.after, initializer)
+
+		return
+	}
+
+	f.initializers = append(f.initializers, &ast.ExpressionStatement{Expression: initializer})
+}
+
+func (f *classFields) method(m *ast.ClassMethodStatement) {
+	p := f.privates[m.Name.(*ast.Identifier).Name]
+
+	f.rewriter(m.Static, "").Statements(m.Body.List)
+
+	function := &ast.FunctionLiteral{
+		Async:      m.Async,
+		Generator:  m.Generator,
+		Parameters: m.Parameters,
+		Body:       m.Body,
+	}
+
+	if m.Static {
+		f.before = append(f.before, assign(createId(p.storage), function))
+
+		return
+	}
+
+	f.before = append(f.before, assign(createId(p.function), function))
+}
+
+func (f *classFields) accessor(a *ast.ClassAccessorStatement) {
+	name := a.Field.(*ast.Identifier).Name
+	p := f.privates[name]
+
+	f.rewriter(a.Static, "").Statements(a.Body.Body.List)
+
+	function := f.t.tempId(a.Kind + "_" + name)
+	f.before = append(f.before, assign(function, a.Body))
+
+	if a.Kind == "get" {
+		p.get = function.Name
+	} else {
+		p.set = function.Name
+	}
+}
+
+// describeAccessors defines getter and setter pairs once their functions are defined
+func (f *classFields) describeAccessors() {
+	for _, member := range f.class.Body.(*ast.BlockStatement).List {
+		a, ok := member.(*ast.ClassAccessorStatement)
+
+		if !ok || !a.Private {
+			continue
+		}
+
+		p := f.privates[a.Field.(*ast.Identifier).Name]
+
+		if p.get == "" && p.set == "" {
+			// described by the other one of the pair
+			continue
+		}
+
+		descriptor := &ast.ObjectLiteral{}
+
+		if p.get != "" {
+			descriptor.Properties = append(descriptor.Properties, &ast.ObjectPropertyValue{
+				PropertyName: createId("get"),
+				Value:        createId(p.get),
+			})
+		}
+
+		if p.set != "" {
+			descriptor.Properties = append(descriptor.Properties, &ast.ObjectPropertyValue{
+				PropertyName: createId("set"),
+				Value:        createId(p.set),
+			})
+		}
+
+		p.get, p.set = "", ""
+
+		if p.static {
+			f.before = append(f.before, assign(createId(p.storage), descriptor))
+		} else {
+			f.brands = append(f.brands, &ast.ExpressionStatement{
+				Expression: call(createMember(createId(p.storage), "set"), &ast.ThisExpression{}, descriptor),
+			})
+		}
+	}
+}
+
+// privateRewriter replaces a.#x with the helper calls reaching private members of the class,
+// names declared again by nested classes are left to them
+type privateRewriter struct {
+	ast.Walker
+
+	f        *classFields
+	privates map[string]*privateName
+}
+
+func newPrivateRewriter(f *classFields) *privateRewriter {
+	r := &privateRewriter{
+		f:        f,
+		privates: f.privates,
+	}
+
+	r.Walker.Visitor = r
+
+	return r
+}
+
+func (r *privateRewriter) ClassExpression(c *ast.ClassExpression) *ast.ClassExpression {
+	outer := r.privates
+	r.privates = make(map[string]*privateName, len(outer))
+
+	for name, p := range outer {
+		r.privates[name] = p
+	}
+
+	for name := range classPrivateNames(c.Body.(*ast.BlockStatement).List) {
+		delete(r.privates, name)
+	}
+
+	defer func() {
+		r.privates = outer
+	}()
+
+	return r.Walker.ClassExpression(c)
+}
+
+// private returns the private name of a.#x member declared by the class
+func (r *privateRewriter) private(exp ast.IExpr) (*ast.MemberExpression, *privateName) {
+	me, ok := exp.(*ast.MemberExpression)
+
+	if !ok || me.Kind != ast.MKPrivate {
+		return nil, nil
+	}
+
+	p, ok := r.privates[me.Right.(*ast.Identifier).Name]
+
+	if !ok {
+		return nil, nil
+	}
+
+	return me, p
+}
+
+func (r *privateRewriter) MemberExpression(me *ast.MemberExpression) ast.IExpr {
+	member, p := r.private(me)
+
+	if p == nil {
+		return r.Walker.MemberExpression(me)
+	}
+
+	r.Walker.ReplacementExpression = r.get(r.Expression(member.Left), p)
+
+	return nil
+}
+
+// AssignExpression replaces a.#x = v with the setter call,
+// compound assignments read the value first
+func (r *privateRewriter) AssignExpression(a *ast.AssignmentExpression) *ast.AssignmentExpression {
+	member, p := r.private(a.Left)
+
+	if p == nil {
+		return r.Walker.AssignExpression(a)
+	}
+
+	object := r.Expression(member.Left)
+	value := r.Expression(a.Right)
+
+	if a.Operator != token.ASSIGN {
+		operator := a.Operator
+
+		if operator == token.EXPONENTIATION_ASSIGN {
+			operator = token.EXPONENTIATION
+		}
+
+		var reference ast.IExpr
+		object, reference = r.reference(object)
+
+		value = &ast.BinaryExpression{
+			Operator: operator,
+			Left:     r.get(reference, p),
+			Right:    value,
+		}
+	}
+
+	r.Walker.ReplacementExpression = r.set(object, p, value)
+
+	return nil
+}
+
+// ExpressionBinder replaces a.#x target of destructuring with the value property set on the field
+func (r *privateRewriter) ExpressionBinder(b *ast.ExpressionBinder) *ast.ExpressionBinder {
+	if target := r.target(b.Expression); target != nil {
+		b.Expression = target
+
+		return b
+	}
+
+	return r.Walker.ExpressionBinder(b)
+}
+
+func (r *privateRewriter) ForInStatement(stmt *ast.ForInStatement) ast.IStmt {
+	r.loopTarget(stmt.Left)
+
+	return r.Walker.ForInStatement(stmt)
+}
+
+func (r *privateRewriter) ForOfStatement(stmt *ast.ForOfStatement) ast.IStmt {
+	r.loopTarget(stmt.Left)
+
+	return r.Walker.ForOfStatement(stmt)
+}
+
+// loopTarget replaces a.#x assigned by for (a.#x of list)
+func (r *privateRewriter) loopTarget(left ast.IStmt) {
+	stmt, ok := left.(*ast.ExpressionStatement)
+
+	if !ok {
+		return
+	}
+
+	if target := r.target(stmt.Expression); target != nil {
+		stmt.Expression = target
+	}
+}
+
+// target builds @classPrivateFieldDestructureSet(a, _x).value for a.#x assigned by destructuring,
+// nil for other targets
+func (r *privateRewriter) target(exp ast.IExpr) ast.IExpr {
+	member, p := r.private(exp)
+
+	if p == nil {
+		return nil
+	}
+
+	object := r.Expression(member.Left)
+
+	if p.static {
+		return createMember(call(r.f.t.helper(builtins.ClassStaticPrivateFieldDestructureSet), object, r.f.target(false), createId(p.storage)), "value")
+	}
+
+	return createMember(call(r.f.t.helper(builtins.ClassPrivateFieldDestructureSet), object, createId(p.storage)), "value")
+}
+
+// UnaryExpression replaces ++a.#x with the setter call, postfix ones return the old value
+// saved to temp: (@classPrivateFieldSet(a, _x, (_tmp = +@classPrivateFieldGet(a, _x)) + 1), _tmp)
+func (r *privateRewriter) UnaryExpression(u *ast.UnaryExpression) *ast.UnaryExpression {
+	member, p := r.private(u.Operand)
+
+	if p == nil || (u.Operator != token.INCREMENT && u.Operator != token.DECREMENT) {
+		return r.Walker.UnaryExpression(u)
+	}
+
+	object, reference := r.reference(r.Expression(member.Left))
+
+	operator := token.PLUS
+
+	if u.Operator == token.DECREMENT {
+		operator = token.MINUS
+	}
+
+	old := ast.IExpr(&ast.UnaryExpression{Operator: token.PLUS, Operand: r.get(reference, p)})
+	var temp *ast.Identifier
+
+	if u.Postfix {
+		temp = r.f.t.tempId("tmp")
+		old = assign(temp, old)
+	}
+
+	exp := ast.IExpr(r.set(object, p, &ast.BinaryExpression{
+		Operator: operator,
+		Left:     old,
+		Right:    &ast.NumberLiteral{Literal: "1"},
+	}))
+
+	if temp != nil {
+		exp = &ast.SequenceExpression{Sequence: []ast.IExpr{exp, createId(temp.Name)}}
+	}
+
+	r.Walker.ReplacementExpression = exp
+
+	return nil
+}
+
+// CallExpression calls private methods with the object they are read from
+func (r *privateRewriter) CallExpression(ce *ast.CallExpression) *ast.CallExpression {
+	member, p := r.private(ce.Callee)
+
+	if p == nil {
+		return r.Walker.CallExpression(ce)
+	}
+
+	for index, argument := range ce.ArgumentList {
+		ce.ArgumentList[index] = r.Expression(argument)
+	}
+
+	object, reference := r.reference(r.Expression(member.Left))
+
+	r.Walker.ReplacementExpression = r.f.t.callWith(r.get(object, p), reference, ce.ArgumentList)
+
+	return nil
+}
+
+// reference saves object to temp when it is read twice, this and identifiers are used as is
+func (r *privateRewriter) reference(object ast.IExpr) (ast.IExpr, ast.IExpr) {
+	switch o := object.(type) {
+	case *ast.ThisExpression:
+		return o, &ast.ThisExpression{}
+	case *ast.Identifier:
+		return o, createId(o.Name)
+	}
+
+	temp := r.f.t.tempId("obj")
+
+	return assign(temp, object), createId(temp.Name)
+}
+
+func (r *privateRewriter) get(object ast.IExpr, p *privateName) ast.IExpr {
+	switch {
+	case p.static && p.kind == privateMethod:
+		return call(r.f.t.helper(builtins.ClassStaticPrivateMethodGet), object, r.f.target(false), createId(p.storage))
+	case p.static:
+		return call(r.f.t.helper(builtins.ClassStaticPrivateFieldGet), object, r.f.target(false), createId(p.storage))
+	case p.kind == privateMethod:
+		return call(r.f.t.helper(builtins.ClassPrivateMethodGet), object, createId(p.storage), createId(p.function))
+	}
+
+	return call(r.f.t.helper(builtins.ClassPrivateFieldGet), object, createId(p.storage))
+}
+
+func (r *privateRewriter) set(object ast.IExpr, p *privateName, value ast.IExpr) ast.IExpr {
+	if p.static {
+		return call(r.f.t.helper(builtins.ClassStaticPrivateFieldSet), object, r.f.target(false), createId(p.storage), value)
+	}
+
+	return call(r.f.t.helper(builtins.ClassPrivateFieldSet), object, createId(p.storage), value)
+}
+
+// classRenamer replaces references to the class name with the given one,
+// property names are left as they are
+type classRenamer struct {
+	ast.Walker
+
+	from, to string
+}
+
+func newClassRenamer(from string, to string) *classRenamer {
+	r := &classRenamer{from: from, to: to}
+	r.Walker.Visitor = r
+
+	return r
+}
+
+func (r *classRenamer) Identifier(id *ast.Identifier) *ast.Identifier {
+	if id != nil && id.Name == r.from {
+		id.Name = r.to
+
+		// resolved references are printed by the ref name
+		if id.LegacyRef != nil && id.LegacyRef.Name == r.from {
+			id.LegacyRef = nil
+		}
+	}
+
+	return id
+}
+
+func (r *classRenamer) ObjectPropertyName(name ast.ObjectPropertyName) ast.ObjectPropertyName {
+	if computed, ok := name.(*ast.ComputedName); ok {
+		return r.ComputedName(computed)
+	}
+
+	return name
+}
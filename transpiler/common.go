@@ -4,11 +4,12 @@ import (
 	"strconv"
 	"yawp/builtins"
 	"yawp/parser/ast"
+	"yawp/parser/token"
 )
 
-func createArraySlice(array ast.IExpr, index int) *ast.CallExpression {
+func (t *Transpiler) createArraySlice(array ast.IExpr, index int) *ast.CallExpression {
 	return &ast.CallExpression{
-		Callee: builtins.SlicedArrayRest,
+		Callee: t.helper(builtins.SlicedArrayRest),
 		ArgumentList: []ast.IExpr{
 			array,
 			&ast.NumberLiteral{
@@ -17,3 +18,80 @@ func createArraySlice(array ast.IExpr, index int) *ast.CallExpression {
 		},
 	}
 }
+
+func createId(name string) *ast.Identifier {
+	return &ast.Identifier{Name: name}
+}
+
+func createString(value string) *ast.StringLiteral {
+	return &ast.StringLiteral{
+		Literal: value,
+		Raw:     true,
+	}
+}
+
+func createMember(left ast.IExpr, name string) *ast.MemberExpression {
+	return &ast.MemberExpression{
+		Left:  left,
+		Right: createId(name),
+		Kind:  ast.MKObject,
+	}
+}
+
+func createIndex(left ast.IExpr, name string) *ast.MemberExpression {
+	return &ast.MemberExpression{
+		Left:  left,
+		Right: createString(name),
+		Kind:  ast.MKArray,
+	}
+}
+
+func assign(left ast.IExpr, right ast.IExpr) *ast.AssignmentExpression {
+	return &ast.AssignmentExpression{
+		Operator: token.ASSIGN,
+		Left:     left,
+		Right:    right,
+	}
+}
+
+func call(callee ast.IExpr, arguments ...ast.IExpr) *ast.CallExpression {
+	return &ast.CallExpression{
+		Callee:       callee,
+		ArgumentList: arguments,
+	}
+}
+
+// prepend inserts the statements at the start of the module or the function body,
+// but after the directives like 'use client', those have to come first
+func prepend(list []ast.IStmt, statements ...ast.IStmt) []ast.IStmt {
+	directives := 0
+
+	for directives < len(list) && isDirective(list[directives]) {
+		directives++
+	}
+
+	result := make([]ast.IStmt, 0, len(list)+len(statements))
+	result = append(result, list[:directives]...)
+	result = append(result, statements...)
+
+	return append(result, list[directives:]...)
+}
+
+func isDirective(stmt ast.IStmt) bool {
+	if s, ok := stmt.(*ast.ExpressionStatement); ok {
+		str, ok := s.Expression.(*ast.StringLiteral)
+
+		return ok && !str.Raw
+	}
+
+	return false
+}
+
+// sequence builds comma separated expressions, single one is returned as is
+func sequence(list []ast.IExpr) ast.IExpr {
+	if len(list) == 1 {
+		return list[0]
+	}
+
+	return &ast.SequenceExpression{Sequence: list}
+}
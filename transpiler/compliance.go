@@ -0,0 +1,71 @@
+package transpiler
+
+import (
+	"strings"
+	"yawp/options"
+	"yawp/parser/ast"
+	"yawp/parser/file"
+)
+
+// complianceChecker looks for the syntax of the transpiled module the target does not support,
+// that is what the transpiler can not or does not lower, e.g. lookbehind of RegExp for ES5.
+// BigInt literals are not reported, the transpiler fails on those
+type complianceChecker struct {
+	ast.Walker
+
+	t *Transpiler
+}
+
+// checkCompliance warns about every feature left in the module the target does not support
+func (t *Transpiler) checkCompliance(module *ast.Module) {
+	c := &complianceChecker{t: t}
+	c.Walker.Visitor = c
+	module.Visit(c)
+}
+
+func (c *complianceChecker) report(loc *file.Loc, feature options.Feature) {
+	if !c.t.supports(feature) {
+		c.t.warn(loc, warnUnsupported, feature)
+	}
+}
+
+func (c *complianceChecker) NewTargetExpression(exp *ast.NewTargetExpression) *ast.NewTargetExpression {
+	c.report(exp.Loc, options.NewTarget)
+
+	return exp
+}
+
+func (c *complianceChecker) ImportCall(exp *ast.ImportCall) *ast.ImportCall {
+	c.report(exp.Loc, options.DynamicImport)
+
+	return c.Walker.ImportCall(exp)
+}
+
+// RegExpLiteral reports the syntax of the pattern lowering does not replace,
+// the literals with flags the target does not support are created at runtime already
+func (c *complianceChecker) RegExpLiteral(r *ast.RegExpLiteral) *ast.RegExpLiteral {
+	if strings.Contains(r.Flags, "d") {
+		c.report(r.Loc, options.RegExpMatchIndices)
+	}
+
+	lookbehind, property := false, false
+
+	scanRegExp(r.Pattern, func(at int, token string, class bool) {
+		switch {
+		case token == "(" && !class:
+			lookbehind = lookbehind || strings.HasPrefix(r.Pattern[at:], "(?<=") || strings.HasPrefix(r.Pattern[at:], "(?<!")
+		case token == `\p`, token == `\P`:
+			property = property || strings.Contains(r.Flags, "u")
+		}
+	})
+
+	if lookbehind {
+		c.report(r.Loc, options.LookbehindRegExp)
+	}
+
+	if property {
+		c.report(r.Loc, options.UnicodePropertyRegExp)
+	}
+
+	return r
+}
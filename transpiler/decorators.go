@@ -0,0 +1,617 @@
+package transpiler
+
+import (
+	"strconv"
+	"yawp/builtins"
+	"yawp/parser/ast"
+	"yawp/parser/token"
+)
+
+// Decorators follow the 2022-03 proposal and are applied by the @applyDecs helper.
+// It is called by the first static field of the class, so it runs once methods are defined
+// and before static fields are initialized, private members are reached through closures
+// created inside of the class body:
+//
+// class A { static #_ = (_decs = @applyDecs(this, [[[dec], 2, 'm']]), _initProto = _decs[0]); m() {} }
+
+// kinds of decorated members as @applyDecs expects them, static ones have decStatic added
+const (
+	decField = iota
+	decAccessor
+	decMethod
+	decGetter
+	decSetter
+	decStatic
+)
+
+type classDecoration struct {
+	t     *Transpiler
+	class *ast.ClassExpression
+
+	entries []ast.IExpr       // members descriptions passed to @applyDecs
+	results []*ast.Identifier // temps receiving values returned by @applyDecs
+	hoisted []ast.IExpr       // decorators evaluated before the class definition
+
+	privateNames map[string]bool
+	hasProto     bool
+
+	decorated *ast.Identifier // the class returned by class decorators
+	initClass *ast.Identifier // runs initializers added by class decorators
+}
+
+// isDecorated reports whether class has decorators or auto accessors to lower
+func isDecorated(c *ast.ClassExpression) bool {
+	if len(c.Decorators) > 0 {
+		return true
+	}
+
+	body, ok := c.Body.(*ast.BlockStatement)
+
+	if !ok {
+		return false
+	}
+
+	for _, member := range body.List {
+		switch m := member.(type) {
+		case *ast.ClassFieldStatement:
+			if m.Accessor || len(m.Decorators) > 0 {
+				return true
+			}
+		case *ast.ClassAccessorStatement:
+			if len(m.Decorators) > 0 {
+				return true
+			}
+		case *ast.ClassMethodStatement:
+			if len(m.Decorators) > 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// decorateClass rewrites the class body in place,
+// decorators that have to be evaluated before the class are left in hoisted
+// and the class replacement is left to the caller
+func (t *Transpiler) decorateClass(c *ast.ClassExpression) *classDecoration {
+	body := c.Body.(*ast.BlockStatement)
+
+	d := &classDecoration{
+		t:            t,
+		class:        c,
+		privateNames: classPrivateNames(body.List),
+	}
+
+	// class decorators are evaluated before the ones of members
+	var classDecorators ast.IExpr
+
+	if len(c.Decorators) > 0 {
+		classDecorators = d.decorators(c.Decorators)
+		c.Decorators = nil
+	}
+
+	members := make([]ast.IStmt, 0, len(body.List))
+
+	for _, member := range body.List {
+		switch m := member.(type) {
+		case *ast.ClassFieldStatement:
+			if m.Accessor {
+				members = append(members, d.autoAccessor(m)...)
+
+				continue
+			}
+
+			if len(m.Decorators) > 0 {
+				d.field(m)
+			}
+		case *ast.ClassMethodStatement:
+			if len(m.Decorators) > 0 {
+				member = d.method(m)
+			}
+		case *ast.ClassAccessorStatement:
+			if len(m.Decorators) > 0 {
+				d.getterOrSetter(m)
+			}
+		}
+
+		members = append(members, member)
+	}
+
+	var initProto *ast.Identifier
+
+	if d.hasProto {
+		initProto = d.result("initProto")
+	}
+
+	if classDecorators != nil {
+		name := "class"
+
+		if c.Name != nil {
+			name = c.Name.Name
+		}
+
+		d.decorated = d.result(name)
+		d.initClass = d.result("initClass")
+	}
+
+	if initProto != nil {
+		members = d.initializeProto(members, initProto)
+	}
+
+	if len(d.entries) > 0 || classDecorators != nil {
+		members = append([]ast.IStmt{d.apply(classDecorators)}, members...)
+	}
+
+	body.List = members
+
+	return d
+}
+
+// statements surround class statement with evaluation of hoisted decorators
+// and rebind its name to the decorated class
+func (d *classDecoration) statements(stmt ast.IStmt) ast.Statements {
+	lowered := make(ast.Statements, 0, 3)
+
+	if len(d.hoisted) > 0 {
+		lowered = append(lowered, &ast.ExpressionStatement{
+			Expression: sequence(d.hoisted),
+		})
+	}
+
+	lowered = append(lowered, stmt)
+
+	if d.decorated != nil {
+		lowered = append(lowered, &ast.ExpressionStatement{
+			Expression: &ast.SequenceExpression{
+				Sequence: []ast.IExpr{
+					assign(createId(d.class.Name.Name), createId(d.decorated.Name)),
+					call(createId(d.initClass.Name)),
+				},
+			},
+		})
+	}
+
+	return lowered
+}
+
+// expression is the class expression replacement,
+// nil when class was lowered in place
+func (d *classDecoration) expression() ast.IExpr {
+	if len(d.hoisted) == 0 && d.decorated == nil {
+		return nil
+	}
+
+	list := append(d.hoisted, d.class)
+
+	if d.decorated != nil {
+		list = append(list, call(createId(d.initClass.Name)), createId(d.decorated.Name))
+	}
+
+	return sequence(list)
+}
+
+// decorators are applied when class is defined, so all of them
+// but plain identifiers are evaluated before the class with the outer this
+func (d *classDecoration) decorators(decorators []ast.IExpr) *ast.ArrayLiteral {
+	list := make([]ast.IExpr, 0, len(decorators))
+
+	for _, decorator := range decorators {
+		if _, ok := decorator.(*ast.Identifier); !ok {
+			temp := d.t.tempId("dec")
+			d.hoisted = append(d.hoisted, assign(temp, decorator))
+			decorator = createId(temp.Name)
+		}
+
+		list = append(list, decorator)
+	}
+
+	return &ast.ArrayLiteral{List: list}
+}
+
+func (d *classDecoration) result(hint string) *ast.Identifier {
+	temp := d.t.tempId(hint)
+	d.results = append(d.results, temp)
+
+	return temp
+}
+
+func (d *classDecoration) entry(decorators []ast.IExpr, kind int, static bool, name ast.IExpr, access ...ast.IExpr) {
+	if static {
+		kind += decStatic
+	} else if kind != decField {
+		d.hasProto = true
+	}
+
+	list := []ast.IExpr{
+		d.decorators(decorators),
+		&ast.NumberLiteral{Literal: strconv.Itoa(kind)},
+		name,
+	}
+
+	d.entries = append(d.entries, &ast.ArrayLiteral{
+		List: append(list, access...),
+	})
+}
+
+// name is the member name passed to decorators, computed keys are saved
+// to temps as the class evaluates them only once
+func (d *classDecoration) name(name ast.ObjectPropertyName, private bool) ast.IExpr {
+	switch n := name.(type) {
+	case *ast.Identifier:
+		if private {
+			return createString("#" + n.Name)
+		}
+
+		return createString(n.Name)
+	case *ast.StringLiteral:
+		return &ast.StringLiteral{Literal: n.Literal, Raw: n.Raw}
+	case *ast.NumberLiteral:
+		return &ast.NumberLiteral{Literal: n.Literal}
+	case *ast.ComputedName:
+		key := d.t.tempId("key")
+		n.Expression = assign(key, n.Expression)
+
+		return createId(key.Name)
+	}
+
+	return nil
+}
+
+func (d *classDecoration) field(f *ast.ClassFieldStatement) {
+	decorators := f.Decorators
+	f.Decorators = nil
+
+	var access []ast.IExpr
+
+	if f.Private {
+		access = privateAccess(f.Name.(*ast.Identifier).Name)
+	}
+
+	d.entry(decorators, decField, f.Static, d.name(f.Name, f.Private), access...)
+
+	f.Initializer = initialize(d.result(memberHint("init", f.Name)), f.Initializer)
+}
+
+func (d *classDecoration) method(m *ast.ClassMethodStatement) ast.IStmt {
+	decorators := m.Decorators
+	m.Decorators = nil
+
+	if !m.Private {
+		d.entry(decorators, decMethod, m.Static, d.name(m.Name, false))
+
+		return m
+	}
+
+	// private method is replaced with a getter of the decorated function
+	method := &ast.FunctionLiteral{
+		Async:      m.Async,
+		Generator:  m.Generator,
+		Parameters: m.Parameters,
+		Body:       m.Body,
+	}
+
+	d.entry(decorators, decMethod, m.Static, d.name(m.Name, true), method)
+
+	return &ast.ClassAccessorStatement{
+		StmtNode: m.StmtNode,
+		Field:    m.Name,
+		Static:   m.Static,
+		Private:  true,
+		Kind:     "get",
+		Body:     getter(createId(d.result(memberHint("call", m.Name)).Name)),
+	}
+}
+
+func (d *classDecoration) getterOrSetter(a *ast.ClassAccessorStatement) {
+	decorators := a.Decorators
+	a.Decorators = nil
+
+	kind := decGetter
+
+	if a.Kind == "set" {
+		kind = decSetter
+	}
+
+	if !a.Private {
+		d.entry(decorators, kind, a.Static, d.name(a.Field, false))
+
+		return
+	}
+
+	// private ones call decorated function with the instance
+	d.entry(decorators, kind, a.Static, d.name(a.Field, true), a.Body)
+
+	decorated := d.result(memberHint("call", a.Field))
+
+	if kind == decGetter {
+		a.Body = getter(call(createId(decorated.Name), &ast.ThisExpression{}))
+	} else {
+		a.Body = setter(call(createId(decorated.Name), &ast.ThisExpression{}, createId("v")))
+	}
+}
+
+// autoAccessor turns accessor x = 1 into the getter and setter
+// of a private field, #_x = 1; get x() { return this.#_x } set x(v) { this.#_x = v }
+func (d *classDecoration) autoAccessor(f *ast.ClassFieldStatement) []ast.IStmt {
+	decorators := f.Decorators
+	storage := d.privateName(memberHint("", f.Name))
+
+	field := &ast.ClassFieldStatement{
+		StmtNode:    f.StmtNode,
+		Name:        createId(storage),
+		Static:      f.Static,
+		Private:     true,
+		Initializer: f.Initializer,
+	}
+
+	name := d.name(f.Name, f.Private)
+	setterName := f.Name
+
+	if _, ok := f.Name.(*ast.ComputedName); ok {
+		setterName = &ast.ComputedName{Expression: name}
+	}
+
+	get := ast.IExpr(privateMember(storage))
+	set := ast.IExpr(assign(privateMember(storage), createId("v")))
+
+	if len(decorators) > 0 {
+		var access []ast.IExpr
+
+		if f.Private {
+			access = privateAccess(storage)
+		}
+
+		d.entry(decorators, decAccessor, f.Static, name, access...)
+
+		field.Initializer = initialize(d.result(memberHint("init", f.Name)), f.Initializer)
+
+		if f.Private {
+			get = call(createId(d.result(memberHint("get", f.Name)).Name), &ast.ThisExpression{})
+			set = call(createId(d.result(memberHint("set", f.Name)).Name), &ast.ThisExpression{}, createId("v"))
+		}
+	}
+
+	return []ast.IStmt{
+		field,
+		&ast.ClassAccessorStatement{
+			Field:   f.Name,
+			Static:  f.Static,
+			Private: f.Private,
+			Kind:    "get",
+			Body:    getter(get),
+		},
+		&ast.ClassAccessorStatement{
+			Field:   setterName,
+			Static:  f.Static,
+			Private: f.Private,
+			Kind:    "set",
+			Body:    setter(set),
+		},
+	}
+}
+
+// initializeProto runs initializers added by decorators of methods
+// before the instance fields are initialized
+func (d *classDecoration) initializeProto(members []ast.IStmt, initProto *ast.Identifier) []ast.IStmt {
+	initialization := call(createId(initProto.Name), &ast.ThisExpression{})
+
+	for _, member := range members {
+		if f, ok := member.(*ast.ClassFieldStatement); ok && !f.Static {
+			value := f.Initializer
+
+			if value == nil {
+				value = &ast.UnaryExpression{
+					Operator: token.VOID,
+					Operand:  &ast.NumberLiteral{Literal: "0"},
+				}
+			}
+
+			f.Initializer = sequence([]ast.IExpr{initialization, value})
+
+			return members
+		}
+	}
+
+	return addToConstructor(members, d.class.SuperClass != nil, &ast.ExpressionStatement{Expression: initialization})
+}
+
+// addToConstructor inserts statements at the start of the constructor or right after super() call,
+// constructor is created when class has none
+func addToConstructor(members []ast.IStmt, derived bool, stmts ...ast.IStmt) []ast.IStmt {
+	for _, member := range members {
+		if m, ok := member.(*ast.ClassMethodStatement); ok && isConstructor(m) {
+			list := m.Body.List
+			position := 0
+
+			for index, stmt := range list {
+				if isSuperCall(stmt) {
+					position = index + 1
+					break
+				}
+			}
+
+			body := make(ast.Statements, 0, len(list)+len(stmts))
+			body = append(body, list[:position]...)
+			body = append(body, stmts...)
+			m.Body.List = append(body, list[position:]...)
+
+			return members
+		}
+	}
+
+	body := ast.Statements(stmts)
+
+	if derived {
+		body = append(ast.Statements{
+			&ast.ExpressionStatement{
+				Expression: &ast.CallExpression{
+					Callee: &ast.SuperExpression{},
+					ArgumentList: []ast.IExpr{
+						&ast.SpreadExpression{Value: createId("arguments")},
+					},
+				},
+			},
+		}, body...)
+	}
+
+	return append([]ast.IStmt{
+		&ast.ClassMethodStatement{
+			Name:       createId("constructor"),
+			Parameters: &ast.FunctionParameters{},
+			Body:       &ast.FunctionBody{List: body},
+		},
+	}, members...)
+}
+
+// apply builds static #_ = (_decs = @applyDecs(this, [...], [...]), _init_x = _decs[0], ...),
+// the field stands for a static block, lowered fields run just the initializer once the class is defined
+func (d *classDecoration) apply(classDecorators ast.IExpr) ast.IStmt {
+	decs := d.t.tempId("decs")
+
+	arguments := []ast.IExpr{
+		&ast.ThisExpression{},
+		&ast.ArrayLiteral{List: d.entries},
+	}
+
+	if classDecorators != nil {
+		arguments = append(arguments, classDecorators)
+	}
+
+	list := []ast.IExpr{
+		assign(decs, call(d.t.helper(builtins.ApplyDecorators), arguments...)),
+	}
+
+	for index, result := range d.results {
+		list = append(list, assign(createId(result.Name), &ast.MemberExpression{
+			Left:  createId(decs.Name),
+			Right: &ast.NumberLiteral{Literal: strconv.Itoa(index)},
+			Kind:  ast.MKArray,
+		}))
+	}
+
+	field := &ast.ClassFieldStatement{
+		Name:        createId(d.privateName("")),
+		Static:      true,
+		Private:     true,
+		Initializer: sequence(list),
+	}
+
+	d.t.staticBlocks[field] = true
+
+	return field
+}
+
+func (d *classDecoration) privateName(hint string) string {
+	base := "_" + hint
+	name := base
+
+	for index := 2; d.privateNames[name]; index++ {
+		name = base + strconv.Itoa(index)
+	}
+
+	d.privateNames[name] = true
+
+	return name
+}
+
+func classPrivateNames(members []ast.IStmt) map[string]bool {
+	names := make(map[string]bool)
+
+	for _, member := range members {
+		var name ast.ObjectPropertyName
+
+		switch m := member.(type) {
+		case *ast.ClassFieldStatement:
+			if m.Private {
+				name = m.Name
+			}
+		case *ast.ClassMethodStatement:
+			if m.Private {
+				name = m.Name
+			}
+		case *ast.ClassAccessorStatement:
+			if m.Private {
+				name = m.Field
+			}
+		}
+
+		if id, ok := name.(*ast.Identifier); ok {
+			names[id.Name] = true
+		}
+	}
+
+	return names
+}
+
+func memberHint(prefix string, name ast.ObjectPropertyName) string {
+	id, ok := name.(*ast.Identifier)
+
+	switch {
+	case !ok:
+		return prefix
+	case prefix == "":
+		return id.Name
+	}
+
+	return prefix + "_" + id.Name
+}
+
+// privateAccess builds function() { return this.#x } and function(v) { this.#x = v }
+func privateAccess(name string) []ast.IExpr {
+	return []ast.IExpr{
+		getter(privateMember(name)),
+		setter(assign(privateMember(name), createId("v"))),
+	}
+}
+
+func privateMember(name string) *ast.MemberExpression {
+	return &ast.MemberExpression{
+		Left:  &ast.ThisExpression{},
+		Right: createId(name),
+		Kind:  ast.MKPrivate,
+	}
+}
+
+// initialize builds _init_x(this, value)
+func initialize(init *ast.Identifier, value ast.IExpr) ast.IExpr {
+	arguments := []ast.IExpr{&ast.ThisExpression{}}
+
+	if value != nil {
+		arguments = append(arguments, value)
+	}
+
+	return call(createId(init.Name), arguments...)
+}
+
+func getter(value ast.IExpr) *ast.FunctionLiteral {
+	return &ast.FunctionLiteral{
+		Parameters: &ast.FunctionParameters{},
+		Body: &ast.FunctionBody{
+			List: ast.Statements{
+				&ast.ReturnStatement{Argument: value},
+			},
+		},
+	}
+}
+
+func setter(exp ast.IExpr) *ast.FunctionLiteral {
+	return &ast.FunctionLiteral{
+		Parameters: &ast.FunctionParameters{
+			List: []ast.FunctionParameter{
+				&ast.IdentifierParameter{Id: createId("v")},
+			},
+		},
+		Body: &ast.FunctionBody{
+			List: ast.Statements{
+				&ast.ExpressionStatement{Expression: exp},
+			},
+		},
+	}
+}
+
+func isConstructor(m *ast.ClassMethodStatement) bool {
+	name, ok := m.Name.(*ast.Identifier)
+
+	return ok && name.Name == "constructor" && !m.Static && !m.Private
+}
@@ -0,0 +1,82 @@
+package transpiler
+
+import (
+	"fmt"
+	"sort"
+	"yawp/parser/file"
+)
+
+const (
+	errConstAssignment  = "Assignment to constant variable %q"
+	errBigInt           = "BigInt literal %s is not supported by the target"
+	errPrivateDecorated = "Private %s can not be decorated in legacy mode"
+	errLoopArguments    = "Loop with closures capturing its variables can not read arguments of the generator"
+
+	warnRegExp      = "RegExp %s is not supported by the target, it is created at runtime"
+	warnUnsupported = "%s is not supported by the target and can not be lowered"
+)
+
+// CompileError reports the code that can not be transpiled for the target
+type CompileError struct {
+	File  string // source file name, set for warnings as those are reported for many files at once
+	Loc   *file.Loc
+	error error
+}
+
+func (ce *CompileError) Error() string {
+	if ce.File != "" {
+		return fmt.Sprintf(
+			"%s:%d:%d %s",
+			ce.File,
+			ce.Loc.Line,
+			ce.Loc.Col,
+			ce.error,
+		)
+	}
+
+	return fmt.Sprintf(
+		"%d:%d %s",
+		ce.Loc.Line,
+		ce.Loc.Col,
+		ce.error,
+	)
+}
+
+// error keeps the first error, the transpiler goes on so there is the output anyway
+func (t *Transpiler) error(loc *file.Loc, msg string, msgValues ...interface{}) {
+	if t.err != nil {
+		return
+	}
+
+	t.err = &CompileError{
+		Loc:   loc.Copy(),
+		error: fmt.Errorf(msg, msgValues...),
+	}
+}
+
+// warn reports the code transpiled for the target, that still needs the newer runtime
+func (t *Transpiler) warn(loc *file.Loc, msg string, msgValues ...interface{}) {
+	t.warnings = append(t.warnings, &CompileError{
+		File:  t.filename(),
+		Loc:   loc.Copy(),
+		error: fmt.Errorf(msg, msgValues...),
+	})
+}
+
+// filename is the name of the source file, empty when the source is not read from file
+func (t *Transpiler) filename() string {
+	if t.module.File == nil {
+		return ""
+	}
+
+	return t.module.File.Name()
+}
+
+// sortWarnings orders the warnings by the location of the code, those of the same code keep the order
+func sortWarnings(warnings []error) {
+	sort.SliceStable(warnings, func(i, j int) bool {
+		a, b := warnings[i].(*CompileError).Loc, warnings[j].(*CompileError).Loc
+
+		return a.Line < b.Line || a.Line == b.Line && a.Col < b.Col
+	})
+}
@@ -0,0 +1,78 @@
+package transpiler
+
+import (
+	"yawp/options"
+	"yawp/parser/ast"
+	"yawp/parser/token"
+)
+
+// Exponentiation is lowered before ES2016 to Math.pow, the objects and the keys
+// of the assigned members are evaluated once:
+//
+// a ** b is Math.pow(a, b)
+// a **= b is a = Math.pow(a, b)
+// a.b[c()] **= d is (_obj = a.b)[_key = c()] = Math.pow(_obj[_key], d)
+
+func (t *Transpiler) BinaryExpression(be *ast.BinaryExpression) *ast.BinaryExpression {
+	if be.Operator != token.EXPONENTIATION || t.supports(options.Exponentiation) {
+		return t.Walker.BinaryExpression(be)
+	}
+
+	t.Walker.ReplacementExpression = t.Expression(pow(be.Left, be.Right))
+
+	return nil
+}
+
+// exponentAssignment returns the lowered **= or nil when it is kept for the target
+func (t *Transpiler) exponentAssignment(exp *ast.AssignmentExpression) ast.IExpr {
+	if exp.Operator != token.EXPONENTIATION_ASSIGN || t.supports(options.Exponentiation) {
+		return nil
+	}
+
+	var target, value ast.IExpr
+
+	switch left := exp.Left.(type) {
+	case *ast.Identifier:
+		target, value = left, createId(left.Name)
+	case *ast.MemberExpression:
+		object, objectRef := t.reference(left.Left, "obj")
+
+		if left.Kind == ast.MKArray {
+			key, keyRef := t.reference(left.Right, "key")
+			target = &ast.MemberExpression{Left: object, Right: key, Kind: ast.MKArray}
+			value = &ast.MemberExpression{Left: objectRef, Right: keyRef, Kind: ast.MKArray}
+		} else {
+			target = &ast.MemberExpression{Left: object, Right: left.Right, Kind: left.Kind}
+			value = &ast.MemberExpression{Left: objectRef, Right: createId(left.Right.(*ast.Identifier).Name), Kind: left.Kind}
+		}
+	default:
+		return nil
+	}
+
+	return t.Expression(assign(target, pow(value, exp.Right)))
+}
+
+// reference saves the expression to temp when it is read twice,
+// identifiers, this, super and literals are used as is
+func (t *Transpiler) reference(exp ast.IExpr, hint string) (ast.IExpr, ast.IExpr) {
+	switch e := exp.(type) {
+	case *ast.Identifier:
+		return e, createId(e.Name)
+	case *ast.ThisExpression:
+		return e, &ast.ThisExpression{}
+	case *ast.SuperExpression:
+		return e, &ast.SuperExpression{}
+	case *ast.StringLiteral:
+		return e, &ast.StringLiteral{Literal: e.Literal, Raw: e.Raw}
+	case *ast.NumberLiteral:
+		return e, &ast.NumberLiteral{Literal: e.Literal}
+	}
+
+	temp := t.tempId(hint)
+
+	return assign(temp, exp), createId(temp.Name)
+}
+
+func pow(base ast.IExpr, exponent ast.IExpr) ast.IExpr {
+	return call(createMember(createId("Math"), "pow"), base, exponent)
+}
@@ -0,0 +1,56 @@
+package transpiler
+
+import (
+	"yawp/parser/ast"
+	"yawp/parser/token"
+)
+
+func (t *Transpiler) FlowEnumStatement(stmt *ast.FlowEnumStatement) *ast.FlowEnumStatement {
+	t.Walker.ReplacementStatement = t.Statement(flowEnum(stmt))
+
+	return nil
+}
+
+// flowEnum lowers enum E { A, B = 'b' } to const E = Object.freeze({ A: 'A', B: 'b' })
+func flowEnum(stmt *ast.FlowEnumStatement) *ast.VariableStatement {
+	members := make([]ast.ObjectProperty, 0, len(stmt.Members))
+
+	for _, member := range stmt.Members {
+		var value ast.IExpr
+
+		switch {
+		case member.Initializer != nil:
+			value = member.Initializer
+		case stmt.Type == "symbol":
+			value = &ast.CallExpression{
+				Callee:       createId("Symbol"),
+				ArgumentList: []ast.IExpr{createString(member.Name)},
+			}
+		default:
+			// string enums default to member names
+			value = createString(member.Name)
+		}
+
+		members = append(members, &ast.ObjectPropertyValue{
+			PropertyName: createId(member.Name),
+			Value:        value,
+		})
+	}
+
+	return &ast.VariableStatement{
+		StmtNode: stmt.StmtNode,
+		Kind:     token.CONST,
+		List: []*ast.VariableBinding{
+			{
+				Kind:   token.CONST,
+				Binder: &ast.IdentifierBinder{Id: createId(stmt.Name.Name)},
+				Initializer: &ast.CallExpression{
+					Callee: createMember(createId("Object"), "freeze"),
+					ArgumentList: []ast.IExpr{
+						&ast.ObjectLiteral{Properties: members},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,827 @@
+package transpiler
+
+import (
+	"fmt"
+	"strings"
+	"yawp/builtins"
+	"yawp/parser/ast"
+	"yawp/parser/file"
+	"yawp/parser/token"
+)
+
+// Flow annotations of functions are checked at runtime when options.FlowChecks is set,
+// the parameters on entry and the returned values on exit, undefined as well when the end
+// of the body is reached, the checks are inlined:
+//
+// function f(a: ?string): number { return a.length }
+// function f(a) { a == null || typeof a === 'string' || _flowTypeError('argument a', '?string', a, 'a.js:1:16'); var _result; return _result = a.length, typeof _result === 'number' || _flowTypeError('return value', 'number', _result, 'a.js:1:25'), _result }
+//
+// Type aliases and interfaces of the module are expanded, the classes of the module,
+// imported values and builtins like Date are checked by instanceof. Generic parameters
+// are checked by their bounds, imported types and types of other modules are not checked,
+// neither are the values yielded by generators. Async functions check the values promises
+// returned are resolved with. Destructured parameters are checked before those are destructured,
+// unless default values of the parameters following read them
+
+// flowValue builds the expression of the checked value, each check gets its own copy
+type flowValue func() ast.IExpr
+
+// flowScope are the checks of the type parameters, those of the enclosing functions
+// check the bounds, the ones of expanded aliases check the type arguments
+type flowScope map[string]func(value flowValue) ast.IExpr
+
+// flowFunction is the function the returned values of which are checked
+type flowFunction struct {
+	returnType ast.FlowType
+	result     *ast.Identifier // keeps the returned value, declared once the function returns
+	async      bool            // the returned value is checked once it is resolved
+}
+
+// flowInstances are global constructors the values of the types named alike are instances of
+var flowInstances = map[string]bool{
+	"Date": true, "RegExp": true, "Error": true, "Map": true, "Set": true, "WeakMap": true, "WeakSet": true,
+	"ArrayBuffer": true, "DataView": true, "Int8Array": true, "Uint8Array": true, "Uint8ClampedArray": true,
+	"Int16Array": true, "Uint16Array": true, "Int32Array": true, "Uint32Array": true,
+	"Float32Array": true, "Float64Array": true,
+}
+
+type flowChecker struct {
+	ast.Walker
+
+	t *Transpiler
+
+	aliases    map[string]*ast.FlowTypeStatement
+	interfaces map[string]*ast.FlowInterfaceStatement
+	classes    map[string]bool // classes and imported values of the module
+	expanding  map[string]bool // aliases and interfaces recursion stops at
+
+	scope    flowScope
+	function *flowFunction
+	values   []string // parameters of the callbacks checking elements, one per nesting level
+	depth    int
+}
+
+// checkFlowTypes inserts the checks of Flow annotations into the functions of the module,
+// those are transpiled as the module code is then
+func (t *Transpiler) checkFlowTypes(module *ast.Module) {
+	c := &flowChecker{
+		t:          t,
+		aliases:    make(map[string]*ast.FlowTypeStatement),
+		interfaces: make(map[string]*ast.FlowInterfaceStatement),
+		classes:    make(map[string]bool),
+		expanding:  make(map[string]bool),
+	}
+	c.Walker.Visitor = c
+
+	for _, stmt := range module.Body {
+		c.declare(stmt)
+	}
+
+	module.Visit(c)
+}
+
+// declare collects the types of the module statement
+func (c *flowChecker) declare(stmt ast.IStmt) {
+	switch s := stmt.(type) {
+	case *ast.FlowTypeStatement:
+		c.aliases[s.Name.Name] = s
+	case *ast.FlowInterfaceStatement:
+		c.interfaces[s.Name.Name] = s
+	case *ast.ClassStatement:
+		if s.Expression.Name != nil {
+			c.classes[s.Expression.Name.Name] = true
+		}
+	case *ast.ImportStatement:
+		if s.Kind != ast.IKValue {
+			return
+		}
+
+		for _, clause := range s.Imports {
+			if !clause.Namespace && clause.LocalIdentifier != nil {
+				c.classes[clause.LocalIdentifier.Name] = true
+			}
+		}
+	case *ast.ExportStatement:
+		switch clause := s.Clause.(type) {
+		case *ast.FlowTypeStatement:
+			c.declare(clause)
+		case *ast.FlowInterfaceStatement:
+			c.declare(clause)
+		case *ast.ExportClassClause:
+			c.declare(&ast.ClassStatement{Expression: clause.ClassExpression})
+		}
+	}
+}
+
+func (c *flowChecker) FunctionLiteral(fl *ast.FunctionLiteral) *ast.FunctionLiteral {
+	if fl.Body == nil {
+		return fl
+	}
+
+	leave := c.enter(fl.TypeParameters, returnType(fl.ReturnType, fl.Async, fl.Generator), fl.Async)
+	defer leave()
+
+	fl = c.Walker.FunctionLiteral(fl)
+	fl.Body.List = c.prologue(fl.Parameters.List, c.epilogue(fl.Body.List))
+
+	return fl
+}
+
+func (c *flowChecker) ArrowFunctionExpression(af *ast.ArrowFunctionExpression) *ast.ArrowFunctionExpression {
+	leave := c.enter(af.TypeParameters, returnType(af.ReturnType, af.Async, false), af.Async)
+	defer leave()
+
+	af = c.Walker.ArrowFunctionExpression(af)
+	af.Body.List = c.prologue(af.Parameters, c.epilogue(af.Body.List))
+
+	return af
+}
+
+func (c *flowChecker) ClassMethodStatement(m *ast.ClassMethodStatement) ast.IStmt {
+	if m.Body == nil {
+		return m
+	}
+
+	leave := c.enter(m.TypeParameters, returnType(m.ReturnType, m.Async, m.Generator), m.Async)
+	defer leave()
+
+	c.Walker.ClassMethodStatement(m)
+	m.Body.List = c.prologue(m.Parameters.List, c.epilogue(m.Body.List))
+
+	return m
+}
+
+func (c *flowChecker) ReturnStatement(stmt *ast.ReturnStatement) ast.IStmt {
+	c.Walker.ReturnStatement(stmt)
+
+	f := c.function
+
+	if f == nil || f.returnType == nil {
+		return stmt
+	}
+
+	// the variable is declared only when some check reads it
+	value := func() ast.IExpr {
+		if f.result == nil {
+			f.result = createId(c.t.uniqueName("_result"))
+		}
+
+		return createId(f.result.Name)
+	}
+
+	check := c.check(f.returnType, value, c.scope)
+
+	if check == nil {
+		return stmt
+	}
+
+	argument := stmt.Argument
+
+	if argument == nil {
+		argument = undefined()
+	}
+
+	if f.async {
+		// return Promise.resolve(x).then(function (_result) { return check || _flowTypeError(...), _result; })
+		stmt.Argument = call(
+			createMember(call(createMember(createId("Promise"), "resolve"), argument), "then"),
+			&ast.FunctionLiteral{
+				Parameters: &ast.FunctionParameters{List: []ast.FunctionParameter{&ast.IdentifierParameter{Id: createId(f.result.Name)}}},
+				Body: &ast.FunctionBody{List: ast.Statements{&ast.ReturnStatement{
+					Argument: &ast.SequenceExpression{Sequence: []ast.IExpr{
+						c.assertion("return value", f.returnType, value, check),
+						value(),
+					}},
+				}}},
+			},
+		)
+
+		return stmt
+	}
+
+	stmt.Argument = &ast.SequenceExpression{Sequence: []ast.IExpr{
+		assign(createId(f.result.Name), argument),
+		c.assertion("return value", f.returnType, value, check),
+		value(),
+	}}
+
+	return stmt
+}
+
+// epilogue checks undefined the function returns when the end of the body is reached
+func (c *flowChecker) epilogue(list []ast.IStmt) []ast.IStmt {
+	f := c.function
+
+	if f.returnType == nil || (len(list) > 0 && !completesNormally(list[len(list)-1])) {
+		return list
+	}
+
+	check := c.check(f.returnType, undefined, c.scope)
+
+	if check == nil {
+		return list
+	}
+
+	return append(list, &ast.ExpressionStatement{Expression: c.assertion("return value", f.returnType, undefined, check)})
+}
+
+// completesNormally tells whether the execution may continue past the statement,
+// the statements ending by return or throw on every branch do not
+func completesNormally(stmt ast.IStmt) bool {
+	switch s := stmt.(type) {
+	case *ast.ReturnStatement, *ast.ThrowStatement:
+		return false
+	case *ast.BlockStatement:
+		return len(s.List) == 0 || completesNormally(s.List[len(s.List)-1])
+	case *ast.IfStatement:
+		return s.Alternate == nil || completesNormally(s.Consequent) || completesNormally(s.Alternate)
+	case *ast.TryStatement:
+		if s.Finally != nil && !completesNormally(s.Finally) {
+			return false
+		}
+
+		if s.Catch != nil && completesNormally(s.Catch.(*ast.CatchStatement).Body) {
+			return true
+		}
+
+		return completesNormally(s.Body)
+	}
+
+	return true
+}
+
+// returnType is the type of the value the function returns, that is what promise is resolved with
+// for async functions, values returned by generators are not checked, nor are those of void functions
+func returnType(flowType ast.FlowType, async bool, generator bool) ast.FlowType {
+	if generator {
+		return nil
+	}
+
+	if async {
+		if promise, ok := flowType.(*ast.FlowGenericType); ok && promise.Name.Name == "Promise" && len(promise.TypeArguments) == 1 {
+			return promise.TypeArguments[0]
+		}
+
+		return nil
+	}
+
+	if primitive, ok := flowType.(*ast.FlowPrimitiveType); ok && primitive.Kind == token.VOID {
+		return nil
+	}
+
+	return flowType
+}
+
+// enter starts checking the function, the returned func restores the enclosing one
+func (c *flowChecker) enter(typeParameters []*ast.FlowTypeParameter, returnType ast.FlowType, async bool) func() {
+	function, scope := c.function, c.scope
+
+	c.function = &flowFunction{returnType: returnType, async: async}
+	c.scope = c.typeParameters(typeParameters, nil, scope)
+
+	return func() {
+		c.function, c.scope = function, scope
+	}
+}
+
+// typeParameters adds the parameters to the scope, those are checked by the arguments
+// or the bounds when the arguments are missing
+func (c *flowChecker) typeParameters(parameters []*ast.FlowTypeParameter, arguments []ast.FlowType, outer flowScope) flowScope {
+	if len(parameters) == 0 {
+		return outer
+	}
+
+	scope := make(flowScope, len(outer)+len(parameters))
+
+	for name, check := range outer {
+		scope[name] = check
+	}
+
+	for index, parameter := range parameters {
+		flowType := parameter.Boundary
+
+		switch {
+		case index < len(arguments):
+			flowType = arguments[index]
+		case parameter.DefaultValue != nil && arguments != nil:
+			flowType = parameter.DefaultValue
+		}
+
+		scope[parameter.Name.Name] = func(value flowValue) ast.IExpr {
+			if flowType == nil {
+				return nil
+			}
+
+			return c.check(flowType, value, outer)
+		}
+	}
+
+	return scope
+}
+
+// prologue prepends the checks of the parameters and the declaration of the returned value,
+// destructured parameters are replaced with ids, those are destructured once checked
+//
+// function f({a}: T) {}
+//
+// function f(_ref) { check || _flowTypeError(...); var {a} = _ref; }
+func (c *flowChecker) prologue(parameters []ast.FunctionParameter, list []ast.IStmt) []ast.IStmt {
+	var statements []ast.IStmt
+	var destructured []*ast.VariableBinding
+
+	for index, parameter := range parameters {
+		var id *ast.Identifier
+		var flowType ast.FlowType
+		var pattern *ast.PatternParameter
+		optional := false
+
+		switch p := parameter.(type) {
+		case *ast.IdentifierParameter:
+			id, flowType, optional = p.Id, p.FlowType, p.FlowTypeOptional && p.DefaultValue == nil
+		case *ast.RestParameter:
+			if binder, ok := p.Binder.(*ast.IdentifierBinder); ok {
+				id, flowType = binder.Id, p.FlowType
+			}
+		case *ast.PatternParameter:
+			// default values of the parameters following see the destructured ones
+			if !hasDefaultValues(&ast.FunctionParameters{List: parameters[index+1:]}) {
+				id, flowType, pattern = createId(c.t.uniqueName("_ref")), p.FlowType, p
+			}
+		}
+
+		if id == nil || flowType == nil {
+			continue
+		}
+
+		name := id.Name
+		value := func() ast.IExpr {
+			return createId(name)
+		}
+
+		check := c.check(flowType, value, c.scope)
+
+		if check == nil {
+			continue
+		}
+
+		if optional {
+			check = or(strictEqual(value(), undefined()), check)
+		}
+
+		argument := "argument " + name
+
+		if pattern != nil {
+			argument = fmt.Sprintf("argument %d", index+1)
+			parameters[index] = &ast.IdentifierParameter{Id: id, DefaultValue: pattern.DefaultValue}
+			destructured = append(destructured, &ast.VariableBinding{
+				Kind:        token.VAR,
+				Binder:      pattern.Binder,
+				Initializer: createId(name),
+			})
+		}
+
+		statements = append(statements, &ast.ExpressionStatement{
+			Expression: c.assertion(argument, flowType, value, check),
+		})
+	}
+
+	if len(destructured) > 0 {
+		statements = append(statements, &ast.VariableStatement{Kind: token.VAR, List: destructured})
+	}
+
+	if c.function.result != nil && !c.function.async {
+		statements = append(statements, &ast.VariableStatement{
+			Kind: token.VAR,
+			List: []*ast.VariableBinding{{Kind: token.VAR, Binder: &ast.IdentifierBinder{Id: c.function.result}}},
+		})
+	}
+
+	return prepend(list, statements...)
+}
+
+// assertion throws TypeError telling the annotation violated unless the check passes
+func (c *flowChecker) assertion(name string, flowType ast.FlowType, value flowValue, check ast.IExpr) ast.IExpr {
+	loc := flowTypeLoc(flowType)
+	location := c.t.filename()
+
+	if loc != nil {
+		location = fmt.Sprintf("%s:%d:%d", location, loc.Line, loc.Col)
+	}
+
+	return or(check, call(c.t.helper(builtins.FlowTypeError), createString(name), createString(flowTypeString(flowType)), value(), createString(location)))
+}
+
+// check builds the expression telling the value is of the type, nil is returned
+// for the types not checked, like any, mixed or generic parameters
+func (c *flowChecker) check(flowType ast.FlowType, value flowValue, scope flowScope) ast.IExpr {
+	switch f := flowType.(type) {
+	case *ast.FlowPrimitiveType:
+		switch f.Kind {
+		case token.TYPE_STRING:
+			return typeOf(value, "string")
+		case token.TYPE_NUMBER:
+			return typeOf(value, "number")
+		case token.TYPE_BOOLEAN:
+			return typeOf(value, "boolean")
+		case token.VOID:
+			return strictEqual(value(), undefined())
+		case token.NULL:
+			return strictEqual(value(), &ast.NullLiteral{Literal: "null"})
+		}
+	case *ast.FlowTrueType:
+		return strictEqual(value(), &ast.BooleanLiteral{Literal: "true"})
+	case *ast.FlowFalseType:
+		return strictEqual(value(), &ast.BooleanLiteral{Literal: "false"})
+	case *ast.FlowStringLiteralType:
+		return strictEqual(value(), &ast.StringLiteral{Literal: f.String})
+	case *ast.FlowNumberLiteralType:
+		return strictEqual(value(), &ast.NumberLiteral{Literal: fmt.Sprint(f.Number)})
+	case *ast.FlowOptionalType:
+		if check := c.check(f.FlowType, value, scope); check != nil {
+			return or(&ast.BinaryExpression{Operator: token.EQUAL, Left: value(), Right: &ast.NullLiteral{Literal: "null"}, Comparison: true}, check)
+		}
+	case *ast.FlowUnionType:
+		var union ast.IExpr
+
+		for _, t := range f.Types {
+			check := c.check(t, value, scope)
+
+			// any of the types not checked lets all the values pass
+			if check == nil {
+				return nil
+			}
+
+			union = or(union, check)
+		}
+
+		return union
+	case *ast.FlowIntersectionType:
+		var intersection ast.IExpr
+
+		for _, t := range f.Types {
+			intersection = and(intersection, c.check(t, value, scope))
+		}
+
+		return intersection
+	case *ast.FlowArrayType:
+		return c.array(f.ElementType, value, scope)
+	case *ast.FlowTupleType:
+		checks := and(isArray(value), strictEqual(createMember(value(), "length"), &ast.NumberLiteral{Literal: fmt.Sprint(len(f.Elements))}))
+
+		for index, element := range f.Elements {
+			index := index
+
+			checks = and(checks, c.check(element, func() ast.IExpr {
+				return &ast.MemberExpression{Left: value(), Right: &ast.NumberLiteral{Literal: fmt.Sprint(index)}, Kind: ast.MKArray}
+			}, scope))
+		}
+
+		return checks
+	case *ast.FlowInexactObject:
+		return c.object(f.Properties, value, scope)
+	case *ast.FlowExactObject:
+		return c.object(f.Properties, value, scope)
+	case *ast.FlowFunctionType:
+		return typeOf(value, "function")
+	case *ast.FlowIdentifier:
+		return c.named(f, nil, value, scope)
+	case *ast.FlowGenericType:
+		return c.named(f.Name, f.TypeArguments, value, scope)
+	}
+
+	return nil
+}
+
+// named checks the type referred by the name, type parameters go first,
+// then aliases and interfaces of the module, classes and builtins
+func (c *flowChecker) named(name *ast.FlowIdentifier, arguments []ast.FlowType, value flowValue, scope flowScope) ast.IExpr {
+	if name.Qualification != nil {
+		return nil
+	}
+
+	if check, ok := scope[name.Name]; ok {
+		return check(value)
+	}
+
+	if c.expanding[name.Name] {
+		return nil
+	}
+
+	if alias, ok := c.aliases[name.Name]; ok {
+		c.expanding[name.Name] = true
+		defer delete(c.expanding, name.Name)
+
+		return c.check(alias.Type, value, c.typeParameters(alias.TypeParameters, typeArguments(arguments), scope))
+	}
+
+	if i, ok := c.interfaces[name.Name]; ok {
+		c.expanding[name.Name] = true
+		defer delete(c.expanding, name.Name)
+
+		list := make([]ast.FlowObjectProperty, 0, len(i.Body))
+
+		for _, stmt := range i.Body {
+			if property, ok := stmt.(ast.FlowObjectProperty); ok {
+				list = append(list, property)
+			}
+		}
+
+		return c.object(list, value, c.typeParameters(i.TypeParameters, typeArguments(arguments), scope))
+	}
+
+	if c.classes[name.Name] || flowInstances[name.Name] {
+		return &ast.BinaryExpression{Operator: token.INSTANCEOF, Left: value(), Right: createId(name.Name), Comparison: true}
+	}
+
+	switch name.Name {
+	case "Array", "$ReadOnlyArray":
+		if len(arguments) == 1 {
+			return c.array(arguments[0], value, scope)
+		}
+
+		return isArray(value)
+	case "$Exact", "$ReadOnly":
+		if len(arguments) == 1 {
+			return c.check(arguments[0], value, scope)
+		}
+	case "Promise":
+		// thenables are accepted as promises are resolved with those
+		return and(&ast.BinaryExpression{Operator: token.NOT_EQUAL, Left: value(), Right: &ast.NullLiteral{Literal: "null"}, Comparison: true}, typeOf(func() ast.IExpr {
+			return createMember(value(), "then")
+		}, "function"))
+	case "Function", "Class":
+		return typeOf(value, "function")
+	case "Object":
+		return and(strictNotEqual(value(), &ast.NullLiteral{Literal: "null"}), or(typeOf(value, "object"), typeOf(value, "function")))
+	case "symbol", "bigint":
+		return typeOf(value, name.Name)
+	}
+
+	return nil
+}
+
+// typeArguments are the arguments of the generic type, an empty list when those are not given
+// so the defaults of the parameters are used
+func typeArguments(arguments []ast.FlowType) []ast.FlowType {
+	if arguments == nil {
+		return []ast.FlowType{}
+	}
+
+	return arguments
+}
+
+// array checks the value is an array with elements of the type, those are checked
+// by the callback of every
+func (c *flowChecker) array(element ast.FlowType, value flowValue, scope flowScope) ast.IExpr {
+	callback := c.callback(func(item flowValue) ast.IExpr {
+		return c.check(element, item, scope)
+	})
+
+	if callback == nil {
+		return isArray(value)
+	}
+
+	return and(isArray(value), call(createMember(value(), "every"), callback))
+}
+
+// object checks the value is an object with properties of the types, the indexers check
+// the values of all the keys, methods and spreads are not checked
+func (c *flowChecker) object(list []ast.FlowObjectProperty, value flowValue, scope flowScope) ast.IExpr {
+	checks := and(strictNotEqual(value(), &ast.NullLiteral{Literal: "null"}), typeOf(value, "object"))
+
+	for _, property := range list {
+		switch p := property.(type) {
+		case *ast.FlowNamedObjectProperty:
+			if p.Static {
+				continue
+			}
+
+			name := p.Name
+			member := func() ast.IExpr {
+				if matchIdentifier.MatchString(name) {
+					return createMember(value(), name)
+				}
+
+				return &ast.MemberExpression{Left: value(), Right: createString(name), Kind: ast.MKArray}
+			}
+
+			check := c.check(p.Value, member, scope)
+
+			if check != nil && p.Optional {
+				check = or(strictEqual(member(), undefined()), check)
+			}
+
+			checks = and(checks, check)
+		case *ast.FlowIndexerObjectProperty:
+			callback := c.callback(func(key flowValue) ast.IExpr {
+				return c.check(p.Value, func() ast.IExpr {
+					return &ast.MemberExpression{Left: value(), Right: key(), Kind: ast.MKArray}
+				}, scope)
+			})
+
+			if callback != nil {
+				keys := call(createMember(createId("Object"), "keys"), value())
+				checks = and(checks, call(createMember(keys, "every"), callback))
+			}
+		}
+	}
+
+	return checks
+}
+
+// callback builds the function checking the parameter, parameters of the nested ones are named apart,
+// nil is returned when there is nothing to check
+func (c *flowChecker) callback(check func(item flowValue) ast.IExpr) ast.IExpr {
+	if c.depth == len(c.values) {
+		c.values = append(c.values, c.t.uniqueName("_value"))
+	}
+
+	name := c.values[c.depth]
+
+	c.depth++
+	body := check(func() ast.IExpr {
+		return createId(name)
+	})
+	c.depth--
+
+	if body == nil {
+		return nil
+	}
+
+	return &ast.FunctionLiteral{
+		Parameters: &ast.FunctionParameters{List: []ast.FunctionParameter{&ast.IdentifierParameter{Id: createId(name)}}},
+		Body:       &ast.FunctionBody{List: ast.Statements{&ast.ReturnStatement{Argument: body}}},
+	}
+}
+
+func typeOf(value flowValue, name string) ast.IExpr {
+	return strictEqual(&ast.UnaryExpression{Operator: token.TYPEOF, Operand: value()}, createString(name))
+}
+
+func isArray(value flowValue) ast.IExpr {
+	return call(createMember(createId("Array"), "isArray"), value())
+}
+
+func strictEqual(left ast.IExpr, right ast.IExpr) ast.IExpr {
+	return &ast.BinaryExpression{Operator: token.STRICT_EQUAL, Left: left, Right: right, Comparison: true}
+}
+
+func strictNotEqual(left ast.IExpr, right ast.IExpr) ast.IExpr {
+	return &ast.BinaryExpression{Operator: token.STRICT_NOT_EQUAL, Left: left, Right: right, Comparison: true}
+}
+
+// or joins the checks by ||, nil ones are skipped
+func or(left ast.IExpr, right ast.IExpr) ast.IExpr {
+	return logical(token.LOGICAL_OR, left, right)
+}
+
+// and joins the checks by &&, nil ones are skipped
+func and(left ast.IExpr, right ast.IExpr) ast.IExpr {
+	return logical(token.LOGICAL_AND, left, right)
+}
+
+func logical(operator token.Token, left ast.IExpr, right ast.IExpr) ast.IExpr {
+	switch {
+	case left == nil:
+		return right
+	case right == nil:
+		return left
+	}
+
+	return &ast.BinaryExpression{Operator: operator, Left: left, Right: right}
+}
+
+// flowTypeLoc is the location of the annotation, generic types start with the name
+func flowTypeLoc(flowType ast.FlowType) *file.Loc {
+	if generic, ok := flowType.(*ast.FlowGenericType); ok {
+		return generic.Name.GetLoc()
+	}
+
+	return flowType.GetLoc()
+}
+
+// flowTypeString writes the type for the error messages as it is annotated
+func flowTypeString(flowType ast.FlowType) string {
+	switch f := flowType.(type) {
+	case *ast.FlowPrimitiveType:
+		if f.Kind == token.NULL {
+			return "null"
+		}
+
+		return f.Kind.String()
+	case *ast.FlowTrueType:
+		return "true"
+	case *ast.FlowFalseType:
+		return "false"
+	case *ast.FlowStringLiteralType:
+		return f.String
+	case *ast.FlowNumberLiteralType:
+		return fmt.Sprint(f.Number)
+	case *ast.FlowExistentialType:
+		return "*"
+	case *ast.FlowIdentifier:
+		if f.Qualification != nil {
+			return flowTypeString(f.Qualification) + "." + f.Name
+		}
+
+		return f.Name
+	case *ast.FlowGenericType:
+		return flowTypeString(f.Name) + "<" + flowTypesString(f.TypeArguments, ", ") + ">"
+	case *ast.FlowTypeOfType:
+		return "typeof " + flowTypeString(f.Identifier)
+	case *ast.FlowOptionalType:
+		return "?" + flowTypeElementString(f.FlowType)
+	case *ast.FlowUnionType:
+		return flowTypesString(f.Types, " | ")
+	case *ast.FlowIntersectionType:
+		return flowTypesString(f.Types, " & ")
+	case *ast.FlowArrayType:
+		return flowTypeElementString(f.ElementType) + "[]"
+	case *ast.FlowTupleType:
+		return "[" + flowTypesString(f.Elements, ", ") + "]"
+	case *ast.FlowIndexedAccessType:
+		return flowTypeString(f.ObjectType) + "[" + flowTypeString(f.IndexType) + "]"
+	case *ast.FlowInexactObject:
+		return "{" + flowPropertiesString(f.Properties) + "}"
+	case *ast.FlowExactObject:
+		return "{|" + flowPropertiesString(f.Properties) + "|}"
+	case *ast.FlowFunctionType:
+		parameters := make([]string, 0, len(f.Parameters))
+
+		for _, p := range f.Parameters {
+			parameter := flowTypeString(p.Type)
+
+			if p.Identifier != nil {
+				optional := ""
+
+				if p.Optional {
+					optional = "?"
+				}
+
+				parameter = p.Identifier.Name + optional + ": " + parameter
+			}
+
+			if p.Rest {
+				parameter = "..." + parameter
+			}
+
+			parameters = append(parameters, parameter)
+		}
+
+		return "(" + strings.Join(parameters, ", ") + ") => " + flowTypeString(f.ReturnType)
+	}
+
+	return "unknown"
+}
+
+// flowTypeElementString parenthesizes unions, intersections and functions of array elements
+// and optional types
+func flowTypeElementString(flowType ast.FlowType) string {
+	switch flowType.(type) {
+	case *ast.FlowUnionType, *ast.FlowIntersectionType, *ast.FlowFunctionType:
+		return "(" + flowTypeString(flowType) + ")"
+	}
+
+	return flowTypeString(flowType)
+}
+
+func flowTypesString(list []ast.FlowType, separator string) string {
+	types := make([]string, 0, len(list))
+
+	for _, flowType := range list {
+		types = append(types, flowTypeString(flowType))
+	}
+
+	return strings.Join(types, separator)
+}
+
+func flowPropertiesString(list []ast.FlowObjectProperty) string {
+	properties := make([]string, 0, len(list))
+
+	for _, property := range list {
+		switch p := property.(type) {
+		case *ast.FlowNamedObjectProperty:
+			optional := ""
+
+			if p.Optional {
+				optional = "?"
+			}
+
+			properties = append(properties, p.Name+optional+": "+flowTypeString(p.Value))
+		case *ast.FlowIndexerObjectProperty:
+			key := flowTypeString(p.KeyType)
+
+			if p.KeyName != "" {
+				key = p.KeyName + ": " + key
+			}
+
+			properties = append(properties, "["+key+"]: "+flowTypeString(p.Value))
+		case *ast.FlowSpreadObjectProperty:
+			properties = append(properties, "..."+flowTypeString(p.FlowType))
+		case *ast.FlowInexactSpecifierProperty:
+			properties = append(properties, "...")
+		}
+	}
+
+	return strings.Join(properties, ", ")
+}
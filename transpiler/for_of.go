@@ -0,0 +1,213 @@
+package transpiler
+
+import (
+	"yawp/builtins"
+	"yawp/options"
+	"yawp/parser/ast"
+	"yawp/parser/token"
+)
+
+// ForOfStatement lowers for of loops for ES5 and for await loops before ES2018 to loops
+// calling the iterator the same way, for await loops await the results of @asyncIterator:
+//
+//	_iteratorAbruptCompletion = false, _didIteratorError = false;
+//	try {
+//	  for (_iterator = @getIterator(b); _iteratorAbruptCompletion = !(_step = _iterator.next()).done; _iteratorAbruptCompletion = false) {
+//	    a = _step.value; ...
+//	  }
+//	} catch (_err) { _didIteratorError = true, _iteratorError = _err; } finally {
+//	  try { if (_iteratorAbruptCompletion && _iterator.return != null) _iterator.return(); }
+//	  finally { if (_didIteratorError) throw _iteratorError; }
+//	}
+//
+// With AssumeArrays option for of loops are indexing the arrays instead:
+//
+//	for (_i = 0, _arr = b; _i < _arr.length; _i++) { a = _arr[_i]; ... }
+func (t *Transpiler) ForOfStatement(stmt *ast.ForOfStatement) ast.IStmt {
+	lowered := t.lowerForOf(stmt, nil)
+
+	if lowered == nil {
+		return t.Walker.ForOfStatement(stmt)
+	}
+
+	t.Walker.ReplacementStatement = t.Statement(lowered)
+
+	return nil
+}
+
+// LabelledStatement moves labels of the lowered loops to the loops they are lowered to
+func (t *Transpiler) LabelledStatement(stmt *ast.LabelledStatement) ast.IStmt {
+	if loop, ok := stmt.Statement.(*ast.ForOfStatement); ok {
+		if lowered := t.lowerForOf(loop, stmt.Label); lowered != nil {
+			t.Walker.ReplacementStatement = t.Statement(lowered)
+
+			return nil
+		}
+	}
+
+	if lowered := t.loopClosure(stmt.Statement, stmt.Label); lowered != nil {
+		t.Walker.ReplacementStatement = lowered
+
+		return nil
+	}
+
+	return t.Walker.LabelledStatement(stmt)
+}
+
+// lowerForOf returns nil for the loops kept for the target
+func (t *Transpiler) lowerForOf(stmt *ast.ForOfStatement, label *ast.Identifier) ast.IStmt {
+	switch {
+	case stmt.Await && !t.supports(options.AsyncGenerators):
+		return t.iteratorLoop(stmt, label, t.helper(builtins.AsyncIterator))
+	case !stmt.Await && !t.supports(options.ForOf) && t.options.AssumeArrays:
+		return t.arrayLoop(stmt, label)
+	case !stmt.Await && !t.supports(options.ForOf):
+		return t.iteratorLoop(stmt, label, t.helper(builtins.GetIterator))
+	}
+
+	return nil
+}
+
+// labelled keeps the label of the lowered loop
+func labelled(label *ast.Identifier, loop ast.IStmt) ast.IStmt {
+	if label == nil {
+		return loop
+	}
+
+	return &ast.LabelledStatement{Label: label, Statement: loop}
+}
+
+// forOfLeft builds the statement assigning the value to the left side of for of loop
+func forOfLeft(left ast.IStmt, value ast.IExpr) ast.IStmt {
+	switch left := left.(type) {
+	case *ast.VariableStatement:
+		left.List[0].Initializer = value
+
+		return left
+	case *ast.ExpressionStatement:
+		switch binding := left.Expression.(type) {
+		case *ast.VariableBinding:
+			if id, ok := binding.Binder.(*ast.IdentifierBinder); ok {
+				return &ast.ExpressionStatement{Expression: assign(id.Id, value)}
+			}
+
+			binding.Initializer = value
+
+			return left
+		default:
+			return &ast.ExpressionStatement{Expression: assign(left.Expression, value)}
+		}
+	}
+
+	panic("Unexpected left side of for of loop")
+}
+
+func (t *Transpiler) arrayLoop(stmt *ast.ForOfStatement, label *ast.Identifier) ast.IStmt {
+	index := t.tempId("i")
+	array := t.tempId("arr")
+
+	return labelled(label, &ast.ForStatement{
+		Initializer: &ast.ExpressionStatement{Expression: sequence([]ast.IExpr{
+			assign(index, &ast.NumberLiteral{Literal: "0"}),
+			assign(array, stmt.Right),
+		})},
+		Test: &ast.BinaryExpression{
+			Operator:   token.LESS,
+			Left:       createId(index.Name),
+			Right:      createMember(createId(array.Name), "length"),
+			Comparison: true,
+		},
+		Update: &ast.UnaryExpression{Operator: token.INCREMENT, Operand: createId(index.Name), Postfix: true},
+		Body: &ast.BlockStatement{List: ast.Statements{
+			forOfLeft(stmt.Left, &ast.MemberExpression{Left: createId(array.Name), Right: createId(index.Name), Kind: ast.MKArray}),
+			stmt.Body,
+		}},
+	})
+}
+
+func (t *Transpiler) iteratorLoop(stmt *ast.ForOfStatement, label *ast.Identifier, helper *ast.Identifier) ast.IStmt {
+	abrupt := t.tempId("iteratorAbruptCompletion")
+	didError := t.tempId("didIteratorError")
+	iteratorError := t.tempId("iteratorError")
+	iterator := t.tempId("iterator")
+	step := t.tempId("step")
+	err := createId(t.uniqueName("_err"))
+
+	// results of async iterators are awaited
+	await := func(exp ast.IExpr) ast.IExpr {
+		if stmt.Await {
+			return &ast.AwaitExpression{Expression: exp}
+		}
+
+		return exp
+	}
+
+	falseLiteral := func() ast.IExpr {
+		return &ast.BooleanLiteral{Literal: "false"}
+	}
+
+	loop := &ast.ForStatement{
+		Initializer: &ast.ExpressionStatement{
+			Expression: assign(iterator, call(helper, stmt.Right)),
+		},
+		Test: assign(createId(abrupt.Name), &ast.UnaryExpression{
+			Operator: token.NOT,
+			Operand: createMember(assign(createId(step.Name), await(
+				call(createMember(createId(iterator.Name), "next")),
+			)), "done"),
+		}),
+		Update: assign(createId(abrupt.Name), falseLiteral()),
+		Body: &ast.BlockStatement{List: ast.Statements{
+			forOfLeft(stmt.Left, createMember(createId(step.Name), "value")),
+			stmt.Body,
+		}},
+	}
+
+	returnMethod := createMember(createId(iterator.Name), "return")
+
+	return ast.Statements{
+		&ast.ExpressionStatement{Expression: sequence([]ast.IExpr{
+			assign(abrupt, falseLiteral()),
+			assign(didError, falseLiteral()),
+		})},
+		&ast.TryStatement{
+			Body: &ast.BlockStatement{List: ast.Statements{labelled(label, loop)}},
+			Catch: &ast.CatchStatement{
+				Parameter: &ast.IdentifierBinder{Id: err},
+				Body: &ast.BlockStatement{List: ast.Statements{
+					&ast.ExpressionStatement{Expression: sequence([]ast.IExpr{
+						assign(createId(didError.Name), &ast.BooleanLiteral{Literal: "true"}),
+						assign(iteratorError, createId(err.Name)),
+					})},
+				}},
+			},
+			Finally: &ast.BlockStatement{List: ast.Statements{
+				&ast.TryStatement{
+					Body: &ast.BlockStatement{List: ast.Statements{
+						&ast.IfStatement{
+							Test: &ast.BinaryExpression{
+								Operator: token.LOGICAL_AND,
+								Left:     createId(abrupt.Name),
+								Right: &ast.BinaryExpression{
+									Operator:   token.NOT_EQUAL,
+									Left:       returnMethod,
+									Right:      &ast.NullLiteral{Literal: "null"},
+									Comparison: true,
+								},
+							},
+							Consequent: &ast.ExpressionStatement{Expression: await(
+								call(createMember(createId(iterator.Name), "return")),
+							)},
+						},
+					}},
+					Finally: &ast.BlockStatement{List: ast.Statements{
+						&ast.IfStatement{
+							Test:       createId(didError.Name),
+							Consequent: &ast.ThrowStatement{Argument: createId(iteratorError.Name)},
+						},
+					}},
+				},
+			}},
+		},
+	}
+}
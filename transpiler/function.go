@@ -2,6 +2,7 @@ package transpiler
 
 import (
 	"yawp/builtins"
+	"yawp/options"
 	"yawp/parser/ast"
 	"yawp/parser/token"
 )
@@ -9,6 +10,11 @@ import (
 type FunctionScope struct {
 	ExtraVariables []*ast.VariableBinding
 	ParameterIndex int
+
+	// parameters from the first default value or rest on are declared in the body for ES5
+	LoweredParameters bool
+
+	Temps []*ast.Identifier
 }
 
 func (t *Transpiler) pushExtraVariableToFunctionScope(vb *ast.VariableBinding) {
@@ -19,44 +25,61 @@ func (t *Transpiler) pushExtraVariableToFunctionScope(vb *ast.VariableBinding) {
 	t.functionScope.ExtraVariables = append(t.functionScope.ExtraVariables, vb)
 }
 
-func (t *Transpiler) unshiftExtraVariableToFunctionScope(vb *ast.VariableBinding) {
-	if t.functionScope == nil {
-		panic("Attempt to push extra variable to nil function scope")
+func (t *Transpiler) FunctionLiteral(fl *ast.FunctionLiteral) *ast.FunctionLiteral {
+	if fl.Id != nil {
+		fl.Id.LegacyRef = t.resolvedRef(fl.Id, t.refScope.BindRef(ast.SRFn, fl.Id.Name))
 	}
 
-	t.functionScope.ExtraVariables = append([]*ast.VariableBinding{vb}, t.functionScope.ExtraVariables...)
+	fl.Parameters, fl.Body = t.function(fl.Parameters, fl.Body, false, t.lowerNewTarget(fl))
+
+	t.lowerFunction(fl, false)
+
+	return fl
 }
 
-func (t *Transpiler) FunctionLiteral(fl *ast.FunctionLiteral) *ast.FunctionLiteral {
-	if fl.Id != nil {
-		fl.Id.LegacyRef = t.refScope.BindRef(ast.SRFn, fl.Id.Name)
+// function transpiles parameters and body in their own scope,
+// shared by function literals and class methods
+func (t *Transpiler) function(fp *ast.FunctionParameters, body *ast.FunctionBody, derivedConstructor bool, newTarget func() ast.IExpr) (*ast.FunctionParameters, *ast.FunctionBody) {
+	t.pushThisScope()
+	defer t.popThisScope()
+
+	t.thisScope.DerivedConstructor = derivedConstructor
+	t.thisScope.NewTarget = newTarget
+
+	fp, body = t.lexicalFunction(fp, body)
+
+	if declaration := t.getThisDeclaration(); declaration != nil {
+		body.List = append(ast.Statements{declaration}, body.List...)
 	}
 
+	return fp, body
+}
+
+// lexicalFunction transpiles function keeping this of the enclosing one, see ArrowFunctionExpression
+func (t *Transpiler) lexicalFunction(fp *ast.FunctionParameters, body *ast.FunctionBody) (*ast.FunctionParameters, *ast.FunctionBody) {
 	// SymbolRef scope starts from arguments
-	t.pushRefScope()
+	t.pushRefScope().function = true
 	defer t.popRefScope()
 
 	popFunctionScope := t.pushFunctionScope()
 	defer popFunctionScope()
 
-	fl.Parameters = t.FunctionParameters(fl.Parameters)
+	defer t.trackUninitialized(ast.Statements(body.List))()
 
-	body := fl.Body
+	t.separateParameterScope(fp, body)
+	fp = t.FunctionParameters(fp)
 
 	if len(t.functionScope.ExtraVariables) > 0 {
-		body.List = append(ast.Statements{
-			&ast.VariableStatement{
-				Kind: token.VAR,
-				List: t.functionScope.ExtraVariables,
-			},
-		}, body.List)
-	} else {
-		body = fl.Body
+		body.List = prepend(body.List, &ast.VariableStatement{
+			Kind: token.VAR,
+			List: t.functionScope.ExtraVariables,
+		})
 	}
 
-	fl.Body = t.FunctionBody(body)
+	body = t.FunctionBody(body)
+	body.List = t.declareTemps(body.List)
 
-	return fl
+	return fp, body
 }
 
 func (t *Transpiler) FunctionParameters(fp *ast.FunctionParameters) *ast.FunctionParameters {
@@ -82,35 +105,32 @@ func (t *Transpiler) FunctionParameters(fp *ast.FunctionParameters) *ast.Functio
 }
 
 func (t *Transpiler) IdentifierParameter(ip *ast.IdentifierParameter) ast.FunctionParameter {
-	ip.Id.LegacyRef = t.refScope.BindRef(ast.SRFnParam, ip.Id.Name)
+	if t.supports(options.Parameters) || (ip.DefaultValue == nil && !t.functionScope.LoweredParameters) {
+		ip.Id.LegacyRef = t.resolvedRef(ip.Id, t.refScope.BindRef(ast.SRFnParam, ip.Id.Name))
+		ip.DefaultValue = t.Expression(ip.DefaultValue)
 
-	if ip.DefaultValue != nil {
-		// Unshift as it must come before other bindings
-		t.unshiftExtraVariableToFunctionScope(&ast.VariableBinding{
-			Kind: token.VAR,
-			Binder: &ast.IdentifierBinder{
-				Id: ip.Id,
-			},
-			Initializer: &ast.BinaryExpression{
-				Operator:   token.LOGICAL_OR,
-				Left:       ip.Id,
-				Right:      t.Expression(ip.DefaultValue),
-				Comparison: false,
-			},
-		})
-
-		ip.DefaultValue = nil
+		return ip
 	}
 
-	return ip
+	t.lowerParameter(&ast.IdentifierBinder{Id: ip.Id}, ip.DefaultValue)
+
+	return nil
 }
 
 func (t *Transpiler) RestParameter(rp *ast.RestParameter) ast.FunctionParameter {
+	if t.supports(options.Parameters) {
+		rp.Binder = t.parameterBinder(rp.Binder)
+
+		return rp
+	}
+
+	t.functionScope.LoweredParameters = true
+
 	// Pushing variable declaration into function body
 	t.pushExtraVariableToFunctionScope(&ast.VariableBinding{
 		Kind:        token.VAR,
 		Binder:      rp.Binder,
-		Initializer: createArraySlice(builtins.Arguments, t.functionScope.ParameterIndex),
+		Initializer: t.createArraySlice(builtins.Arguments, t.functionScope.ParameterIndex),
 	})
 
 	// Since we don't have this arg in fact now, remove it
@@ -118,19 +138,29 @@ func (t *Transpiler) RestParameter(rp *ast.RestParameter) ast.FunctionParameter
 }
 
 func (t *Transpiler) PatternParameter(pp *ast.PatternParameter) ast.FunctionParameter {
+	if t.supports(options.Parameters) {
+		pp.Binder = t.parameterBinder(pp.Binder)
+		pp.DefaultValue = t.Expression(pp.DefaultValue)
+
+		return pp
+	}
+
+	if pp.DefaultValue != nil || t.functionScope.LoweredParameters {
+		t.lowerParameter(pp.Binder, pp.DefaultValue)
+
+		return nil
+	}
+
 	// Pattern parameter become a simple id parameter
 	// and we're pushing pattern binding to the function body
-	paramId := t.refScope.GhostId()
-	paramId.LegacyRef.Type = ast.SRFnParam
+	paramId := createId(t.uniqueName("_ref"))
+	paramId.LegacyRef = t.refScope.BindRef(ast.SRFnParam, paramId.Name)
 
 	t.pushExtraVariableToFunctionScope(&ast.VariableBinding{
 		Kind:        token.VAR,
 		Binder:      pp.Binder,
-		Initializer: paramId,
+		Initializer: createId(paramId.Name),
 	})
 
-	return t.IdentifierParameter(&ast.IdentifierParameter{
-		Id:           paramId,
-		DefaultValue: pp.DefaultValue,
-	})
+	return &ast.IdentifierParameter{Id: paramId}
 }
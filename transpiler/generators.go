@@ -0,0 +1,944 @@
+package transpiler
+
+import (
+	"strconv"
+	"yawp/builtins"
+	"yawp/parser/ast"
+	"yawp/parser/token"
+)
+
+// Generator functions are lowered for ES5 to state machines run by @wrapGenerator, after regenerator.
+// Variables are hoisted to the generator function, so they keep values between steps,
+// and the body is split into cases at every location code can resume at or jump to:
+//
+// function g() { var x; return @wrapGenerator(function (_context) {
+//   while (1) switch (_context.prev = _context.next) {
+//   case 0: _context.next = 1; return 1;
+//   case 1: x = _context.sent;
+//   case 2: case "end": return _context.stop();
+//   }
+// }, this, []); }
+//
+// Statements without yields and jumps out of them are kept as they are,
+// expressions are split at yields with the values computed before saved to context temps.
+
+type generatorEmitter struct {
+	t       *Transpiler
+	context string // state machine parameter
+	args    string // arguments of the generator function, empty until used
+
+	cases    []*ast.CaseStatement
+	temps    int
+	tryLocs  []ast.IExpr
+	leaps    []*leapEntry
+	hoisted  []*ast.Identifier
+	declared map[string]bool
+
+	functions ast.Statements // function declarations hoisted with variables
+}
+
+// kinds of statements break and continue jump out of
+const (
+	leapLoop = iota
+	leapSwitch
+	leapLabel
+)
+
+type leapEntry struct {
+	kind        int
+	label       string
+	breakLoc    *ast.NumberLiteral
+	continueLoc *ast.NumberLiteral
+}
+
+func (t *Transpiler) lowerGenerator(fl *ast.FunctionLiteral) {
+	e := &generatorEmitter{
+		t:        t,
+		context:  t.uniqueName("_context"),
+		declared: make(map[string]bool),
+	}
+
+	body := newGeneratorHoister(e).Statements(fl.Body.List)
+
+	e.mark(e.loc())
+	e.explodeStatements(body)
+
+	end := e.loc()
+	e.mark(end)
+	e.cases = append(e.cases, &ast.CaseStatement{Test: createString("end")})
+	e.emit(&ast.ReturnStatement{Argument: call(e.property("stop"))})
+
+	var list ast.Statements
+
+	if e.args != "" {
+		e.hoisted = append(e.hoisted, createId(e.args))
+	}
+
+	if len(e.hoisted) > 0 {
+		declaration := &ast.VariableStatement{Kind: token.VAR}
+
+		for _, id := range e.hoisted {
+			binding := &ast.VariableBinding{Kind: token.VAR, Binder: &ast.IdentifierBinder{Id: id}}
+
+			if id.Name == e.args {
+				binding.Initializer = createId("arguments")
+			}
+
+			declaration.List = append(declaration.List, binding)
+		}
+
+		list = append(list, declaration)
+	}
+
+	list = append(list, e.functions...)
+
+	// while (1) switch (_context.prev = _context.next) {}
+	machine := &ast.WhileStatement{
+		Test: &ast.NumberLiteral{Literal: "1"},
+		Body: &ast.SwitchStatement{
+			Discriminant: assign(e.property("prev"), e.property("next")),
+			Default:      -1,
+			Body:         casesList(e.cases),
+		},
+	}
+
+	list = append(list, &ast.ReturnStatement{
+		Argument: call(t.helper(builtins.WrapGenerator), &ast.FunctionLiteral{
+			Parameters: &ast.FunctionParameters{
+				List: []ast.FunctionParameter{&ast.IdentifierParameter{Id: createId(e.context)}},
+			},
+			Body: &ast.FunctionBody{List: ast.Statements{machine}},
+		}, &ast.ThisExpression{}, &ast.ArrayLiteral{List: e.tryLocs}),
+	})
+
+	fl.Generator = false
+	fl.Body.List = list
+}
+
+func casesList(cases []*ast.CaseStatement) []ast.IStmt {
+	list := make([]ast.IStmt, len(cases))
+
+	for index, c := range cases {
+		list[index] = c
+	}
+
+	return list
+}
+
+func (e *generatorEmitter) property(name string) *ast.MemberExpression {
+	return createMember(createId(e.context), name)
+}
+
+// loc is the case number yet to be marked
+func (e *generatorEmitter) loc() *ast.NumberLiteral {
+	return &ast.NumberLiteral{Literal: "-1"}
+}
+
+// mark starts the new case at the given location
+func (e *generatorEmitter) mark(loc *ast.NumberLiteral) *ast.NumberLiteral {
+	loc.Literal = strconv.Itoa(len(e.cases))
+	e.cases = append(e.cases, &ast.CaseStatement{Test: &ast.NumberLiteral{Literal: loc.Literal}})
+
+	return loc
+}
+
+func (e *generatorEmitter) emit(stmt ast.IStmt) {
+	current := e.cases[len(e.cases)-1]
+	current.Consequent = append(current.Consequent, stmt)
+}
+
+func (e *generatorEmitter) emitAssign(left ast.IExpr, right ast.IExpr) {
+	e.emit(&ast.ExpressionStatement{Expression: assign(left, right)})
+}
+
+func (e *generatorEmitter) temp() string {
+	name := "t" + strconv.Itoa(e.temps)
+	e.temps++
+
+	return name
+}
+
+// jump builds _context.next = loc; break;
+func (e *generatorEmitter) jump(loc *ast.NumberLiteral) ast.Statements {
+	return ast.Statements{
+		&ast.ExpressionStatement{Expression: assign(e.property("next"), loc)},
+		&ast.BranchStatement{Token: token.BREAK},
+	}
+}
+
+func (e *generatorEmitter) emitJump(loc *ast.NumberLiteral) {
+	for _, stmt := range e.jump(loc) {
+		e.emit(stmt)
+	}
+}
+
+func (e *generatorEmitter) emitJumpIf(test ast.IExpr, loc *ast.NumberLiteral) {
+	e.emit(&ast.IfStatement{Test: test, Consequent: &ast.BlockStatement{List: e.jump(loc)}})
+}
+
+func (e *generatorEmitter) emitJumpIfNot(test ast.IExpr, loc *ast.NumberLiteral) {
+	e.emitJumpIf(&ast.UnaryExpression{Operator: token.NOT, Operand: test}, loc)
+}
+
+// emitAbrupt leaves the state machine for the runtime to run finally blocks on the way
+func (e *generatorEmitter) emitAbrupt(kind string, argument ast.IExpr) {
+	arguments := []ast.IExpr{createString(kind)}
+
+	if argument != nil {
+		arguments = append(arguments, argument)
+	}
+
+	e.emit(&ast.ReturnStatement{Argument: call(e.property("abrupt"), arguments...)})
+}
+
+func (e *generatorEmitter) withLeap(entry *leapEntry, explode func()) {
+	e.leaps = append(e.leaps, entry)
+	explode()
+	e.leaps = e.leaps[:len(e.leaps)-1]
+}
+
+func (e *generatorEmitter) breakLoc(label *ast.Identifier) *ast.NumberLiteral {
+	for index := len(e.leaps) - 1; index >= 0; index-- {
+		entry := e.leaps[index]
+
+		if label == nil && entry.kind != leapLabel || label != nil && entry.label == label.Name {
+			return entry.breakLoc
+		}
+	}
+
+	panic("Illegal break statement in generator function")
+}
+
+func (e *generatorEmitter) continueLoc(label *ast.Identifier) *ast.NumberLiteral {
+	for index := len(e.leaps) - 1; index >= 0; index-- {
+		entry := e.leaps[index]
+
+		if entry.kind == leapLoop && (label == nil || entry.label == label.Name) {
+			return entry.continueLoc
+		}
+	}
+
+	panic("Illegal continue statement in generator function")
+}
+
+func (e *generatorEmitter) explodeStatements(list ast.Statements) {
+	for _, stmt := range list {
+		e.explodeStatement(stmt, "")
+	}
+}
+
+// explodeStatement splits the statement into cases, label is the one of the loop statement
+func (e *generatorEmitter) explodeStatement(stmt ast.IStmt, label string) {
+	if !containsLeap(stmt) {
+		if _, ok := stmt.(*ast.EmptyStatement); !ok {
+			e.emit(stmt)
+		}
+
+		return
+	}
+
+	switch s := stmt.(type) {
+	case ast.Statements:
+		e.explodeStatements(s)
+	case *ast.BlockStatement:
+		e.explodeStatements(s.List)
+	case *ast.ExpressionStatement:
+		e.explodeExpression(s.Expression, true)
+	case *ast.LabelledStatement:
+		switch s.Statement.(type) {
+		case *ast.WhileStatement, *ast.DoWhileStatement, *ast.ForStatement, *ast.ForInStatement, *ast.ForOfStatement:
+			e.explodeStatement(s.Statement, s.Label.Name)
+
+			return
+		}
+
+		after := e.loc()
+
+		e.withLeap(&leapEntry{kind: leapLabel, label: s.Label.Name, breakLoc: after}, func() {
+			e.explodeStatement(s.Statement, "")
+		})
+
+		e.mark(after)
+	case *ast.IfStatement:
+		elseLoc, after := e.loc(), e.loc()
+
+		if s.Alternate == nil {
+			elseLoc = after
+		}
+
+		e.emitJumpIfNot(e.explodeExpression(s.Test, false), elseLoc)
+		e.explodeStatement(s.Consequent, "")
+
+		if s.Alternate != nil {
+			e.emitJump(after)
+			e.mark(elseLoc)
+			e.explodeStatement(s.Alternate, "")
+		}
+
+		e.mark(after)
+	case *ast.WhileStatement:
+		before, after := e.loc(), e.loc()
+
+		e.mark(before)
+		e.emitJumpIfNot(e.explodeExpression(s.Test, false), after)
+		e.explodeLoop(s.Body, label, after, before)
+		e.emitJump(before)
+		e.mark(after)
+	case *ast.DoWhileStatement:
+		first, test, after := e.loc(), e.loc(), e.loc()
+
+		e.mark(first)
+		e.explodeLoop(s.Body, label, after, test)
+		e.mark(test)
+		e.emitJumpIf(e.explodeExpression(s.Test, false), first)
+		e.mark(after)
+	case *ast.ForStatement:
+		before, update, after := e.loc(), e.loc(), e.loc()
+
+		if s.Initializer != nil {
+			e.explodeStatement(s.Initializer, "")
+		}
+
+		e.mark(before)
+
+		if s.Test != nil {
+			e.emitJumpIfNot(e.explodeExpression(s.Test, false), after)
+		}
+
+		e.explodeLoop(s.Body, label, after, update)
+		e.mark(update)
+
+		if s.Update != nil {
+			e.explodeExpression(s.Update, true)
+		}
+
+		e.emitJump(before)
+		e.mark(after)
+	case *ast.ForInStatement:
+		// keys are iterated by the function returning { value, done }
+		next := e.temp()
+		e.emitAssign(e.property(next), call(e.property("keys"), e.explodeExpression(s.Right, false)))
+		e.explodeIteration(s.Left, call(e.property(next)), s.Body, label)
+	case *ast.ForOfStatement:
+		iterator := e.temp()
+		e.emitAssign(e.property(iterator), call(e.property("values"), e.explodeExpression(s.Right, false)))
+		e.explodeIteration(s.Left, call(createMember(e.property(iterator), "next")), s.Body, label)
+	case *ast.BranchStatement:
+		if s.Token == token.BREAK {
+			e.emitAbrupt("break", e.breakLoc(s.Label))
+		} else {
+			e.emitAbrupt("continue", e.continueLoc(s.Label))
+		}
+	case *ast.ReturnStatement:
+		var argument ast.IExpr
+
+		if s.Argument != nil {
+			argument = e.explodeExpression(s.Argument, false)
+		}
+
+		e.emitAbrupt("return", argument)
+	case *ast.ThrowStatement:
+		e.emit(&ast.ThrowStatement{Argument: e.explodeExpression(s.Argument, false)})
+	case *ast.SwitchStatement:
+		e.explodeSwitch(s)
+	case *ast.TryStatement:
+		e.explodeTry(s)
+	default:
+		panic("Unsupported statement in generator function")
+	}
+}
+
+func (e *generatorEmitter) explodeLoop(body ast.IStmt, label string, breakLoc *ast.NumberLiteral, continueLoc *ast.NumberLiteral) {
+	e.withLeap(&leapEntry{kind: leapLoop, label: label, breakLoc: breakLoc, continueLoc: continueLoc}, func() {
+		e.explodeStatement(body, "")
+	})
+}
+
+// explodeIteration loops while (_context.t1 = next()).done is false
+// assigning the value to the left side of for in and for of statements
+func (e *generatorEmitter) explodeIteration(left ast.IStmt, next ast.IExpr, body ast.IStmt, label string) {
+	before, after := e.loc(), e.loc()
+	step := e.temp()
+
+	e.mark(before)
+	e.emitJumpIf(createMember(assign(e.property(step), next), "done"), after)
+
+	if target, ok := left.(*ast.ExpressionStatement); ok {
+		e.emitAssign(target.Expression, createMember(e.property(step), "value"))
+	}
+
+	e.explodeLoop(body, label, after, before)
+	e.emitJump(before)
+	e.mark(after)
+}
+
+// explodeSwitch compares discriminant with the tests in order and jumps to the matching case,
+// default case is the last one to jump to
+func (e *generatorEmitter) explodeSwitch(s *ast.SwitchStatement) {
+	discriminant := e.temp()
+	e.emitAssign(e.property(discriminant), e.explodeExpression(s.Discriminant, false))
+
+	after := e.loc()
+	locs := make([]*ast.NumberLiteral, len(s.Body))
+
+	for index, c := range s.Body {
+		locs[index] = e.loc()
+
+		if test := c.(*ast.CaseStatement).Test; test != nil {
+			e.emitJumpIf(&ast.BinaryExpression{
+				Operator:   token.STRICT_EQUAL,
+				Left:       e.property(discriminant),
+				Right:      e.explodeExpression(test, false),
+				Comparison: true,
+			}, locs[index])
+		}
+	}
+
+	if s.Default != -1 {
+		e.emitJump(locs[s.Default])
+	} else {
+		e.emitJump(after)
+	}
+
+	e.withLeap(&leapEntry{kind: leapSwitch, breakLoc: after}, func() {
+		for index, c := range s.Body {
+			e.mark(locs[index])
+			e.explodeStatements(c.(*ast.CaseStatement).Consequent)
+		}
+	})
+
+	e.mark(after)
+}
+
+// explodeTry registers [tryLoc, catchLoc, finallyLoc, afterLoc] for the runtime to dispatch
+// exceptions and abrupt completions, context.prev is updated as cases are entered by fallthrough
+func (e *generatorEmitter) explodeTry(s *ast.TryStatement) {
+	after := e.loc()
+	tryLoc := e.mark(e.loc())
+
+	var catchLoc, finallyLoc ast.IExpr = &ast.NullLiteral{Literal: "null"}, &ast.NullLiteral{Literal: "null"}
+	var afterLoc ast.IExpr = &ast.NullLiteral{Literal: "null"}
+
+	catchStatement, _ := s.Catch.(*ast.CatchStatement)
+	var catchAt, finallyAt *ast.NumberLiteral
+
+	if catchStatement != nil {
+		catchAt = e.loc()
+		catchLoc = catchAt
+	}
+
+	if s.Finally != nil {
+		finallyAt = e.loc()
+		finallyLoc = finallyAt
+		afterLoc = after
+	}
+
+	e.tryLocs = append(e.tryLocs, &ast.ArrayLiteral{List: []ast.IExpr{tryLoc, catchLoc, finallyLoc, afterLoc}})
+	e.emitAssign(e.property("prev"), tryLoc)
+
+	e.explodeStatement(s.Body, "")
+
+	if catchAt != nil {
+		if finallyAt != nil {
+			e.emitJump(finallyAt)
+		} else {
+			e.emitJump(after)
+		}
+
+		e.emitAssign(e.property("prev"), e.mark(catchAt))
+
+		exception := call(e.property("catch"), tryLoc)
+
+		if catchStatement.Parameter == nil {
+			e.emit(&ast.ExpressionStatement{Expression: exception})
+		} else {
+			binder, ok := catchStatement.Parameter.(*ast.IdentifierBinder)
+
+			if !ok {
+				panic("Unsupported catch parameter in generator function")
+			}
+
+			// catch parameter is hoisted with the name unique to the function,
+			// mangled names are unique already
+			parameter := func() *ast.Identifier {
+				return &ast.Identifier{Name: binder.Id.Name, LegacyRef: binder.Id.LegacyRef}
+			}
+
+			if ref := binder.Id.LegacyRef; ref == nil || ref.Name == binder.Id.Name {
+				name := e.t.uniqueName("_" + binder.Id.Name)
+				newClassRenamer(binder.Id.Name, name).Statement(catchStatement.Body)
+
+				parameter = func() *ast.Identifier {
+					return createId(name)
+				}
+			}
+
+			e.hoist(parameter())
+			e.emitAssign(parameter(), exception)
+		}
+
+		e.explodeStatement(catchStatement.Body, "")
+	}
+
+	if finallyAt != nil {
+		e.emitAssign(e.property("prev"), e.mark(finallyAt))
+		e.explodeStatement(s.Finally, "")
+		e.emit(&ast.ReturnStatement{Argument: call(e.property("finish"), finallyAt)})
+	}
+
+	e.mark(after)
+}
+
+// explodeExpression emits the code computing values before yields and returns
+// the expression giving the result, the one without yields is returned as is
+func (e *generatorEmitter) explodeExpression(exp ast.IExpr, ignoreResult bool) ast.IExpr {
+	finish := func(exp ast.IExpr) ast.IExpr {
+		if ignoreResult {
+			e.emit(&ast.ExpressionStatement{Expression: exp})
+
+			return nil
+		}
+
+		return exp
+	}
+
+	if !containsLeap(exp) {
+		return finish(exp)
+	}
+
+	// children evaluated before yields are saved to temps
+	hasLeapingChildren := true
+
+	explodeViaTemp := func(temp string, child ast.IExpr, ignoreChildResult bool) ast.IExpr {
+		result := e.explodeExpression(child, ignoreChildResult)
+
+		if ignoreChildResult {
+			return nil
+		}
+
+		if temp != "" || hasLeapingChildren && !isLiteral(result) {
+			if temp == "" {
+				temp = e.temp()
+			}
+
+			e.emitAssign(e.property(temp), result)
+			result = e.property(temp)
+		}
+
+		return result
+	}
+
+	switch x := exp.(type) {
+	case *ast.YieldExpression:
+		after := e.loc()
+
+		var argument ast.IExpr
+
+		if x.Argument != nil {
+			argument = e.explodeExpression(x.Argument, false)
+		}
+
+		if x.Delegate {
+			e.emit(&ast.ReturnStatement{Argument: call(e.property("delegateYield"), argument, after)})
+			e.mark(after)
+
+			return finish(e.property("result"))
+		}
+
+		e.emitAssign(e.property("next"), after)
+		e.emit(&ast.ReturnStatement{Argument: argument})
+		e.mark(after)
+
+		return finish(e.property("sent"))
+	case *ast.MemberExpression:
+		object := explodeViaTemp("", x.Left, false)
+		property := x.Right
+
+		if x.Kind == ast.MKArray {
+			property = explodeViaTemp("", x.Right, false)
+		}
+
+		return finish(&ast.MemberExpression{Left: object, Right: property, Kind: x.Kind})
+	case *ast.CallExpression:
+		return finish(e.explodeCall(x, explodeViaTemp))
+	case *ast.NewExpression:
+		callee := explodeViaTemp("", x.Callee, false)
+
+		return finish(&ast.NewExpression{Callee: callee, ArgumentList: e.explodeArguments(x.ArgumentList, explodeViaTemp)})
+	case *ast.ArrayLiteral:
+		return finish(&ast.ArrayLiteral{List: e.explodeArguments(x.List, explodeViaTemp)})
+	case *ast.ObjectLiteral:
+		properties := make([]ast.ObjectProperty, 0, len(x.Properties))
+
+		for _, property := range x.Properties {
+			switch p := property.(type) {
+			case *ast.ObjectPropertyValue:
+				name := p.PropertyName
+
+				if computed, ok := name.(*ast.ComputedName); ok {
+					name = &ast.ComputedName{Expression: explodeViaTemp("", computed.Expression, false)}
+				}
+
+				properties = append(properties, &ast.ObjectPropertyValue{PropertyName: name, Value: explodeViaTemp("", p.Value, false)})
+			case *ast.ObjectSpread:
+				properties = append(properties, &ast.ObjectSpread{Expression: explodeViaTemp("", p.Expression, false)})
+			default:
+				properties = append(properties, property)
+			}
+		}
+
+		return finish(&ast.ObjectLiteral{Properties: properties})
+	case *ast.SequenceExpression:
+		last := len(x.Sequence) - 1
+
+		for _, item := range x.Sequence[:last] {
+			e.explodeExpression(item, true)
+		}
+
+		return e.explodeExpression(x.Sequence[last], ignoreResult)
+	case *ast.BinaryExpression:
+		if x.Operator == token.LOGICAL_AND || x.Operator == token.LOGICAL_OR {
+			return e.explodeLogical(x.Left, x.Right, func(left ast.IExpr) ast.IExpr {
+				if x.Operator == token.LOGICAL_AND {
+					return &ast.UnaryExpression{Operator: token.NOT, Operand: left}
+				}
+
+				return left
+			}, ignoreResult)
+		}
+
+		return finish(&ast.BinaryExpression{
+			Operator:   x.Operator,
+			Left:       explodeViaTemp("", x.Left, false),
+			Right:      explodeViaTemp("", x.Right, false),
+			Comparison: x.Comparison,
+		})
+	case *ast.CoalesceExpression:
+		return e.explodeLogical(x.Head, x.Consequent, func(left ast.IExpr) ast.IExpr {
+			return &ast.BinaryExpression{
+				Operator:   token.NOT_EQUAL,
+				Left:       left,
+				Right:      &ast.NullLiteral{Literal: "null"},
+				Comparison: true,
+			}
+		}, ignoreResult)
+	case *ast.ConditionalExpression:
+		elseLoc, after := e.loc(), e.loc()
+		result := ""
+
+		if !ignoreResult {
+			result = e.temp()
+		}
+
+		e.emitJumpIfNot(e.explodeExpression(x.Test, false), elseLoc)
+		explodeViaTemp(result, x.Consequent, ignoreResult)
+		e.emitJump(after)
+		e.mark(elseLoc)
+		explodeViaTemp(result, x.Alternate, ignoreResult)
+		e.mark(after)
+
+		if ignoreResult {
+			return nil
+		}
+
+		return e.property(result)
+	case *ast.UnaryExpression:
+		return finish(&ast.UnaryExpression{
+			Operator: x.Operator,
+			Operand:  e.explodeExpression(x.Operand, false),
+			Postfix:  x.Postfix,
+		})
+	case *ast.AssignmentExpression:
+		left := e.explodeExpression(x.Left, false)
+
+		if x.Operator == token.ASSIGN {
+			return finish(assign(left, e.explodeExpression(x.Right, false)))
+		}
+
+		// the value is read before the right side yields
+		temp := e.temp()
+		e.emitAssign(e.property(temp), left)
+
+		operator := x.Operator
+
+		if operator == token.EXPONENTIATION_ASSIGN {
+			operator = token.EXPONENTIATION
+		}
+
+		return finish(assign(left, &ast.BinaryExpression{
+			Operator: operator,
+			Left:     e.property(temp),
+			Right:    e.explodeExpression(x.Right, false),
+		}))
+	case *ast.SpreadExpression:
+		return finish(&ast.SpreadExpression{Value: e.explodeExpression(x.Value, false)})
+	case *ast.ArraySpread:
+		return finish(&ast.ArraySpread{Expression: e.explodeExpression(x.Expression, false)})
+	}
+
+	panic("Unsupported expression in generator function")
+}
+
+// explodeLogical jumps over the right side when jump(left) is true, the result is the value of the side evaluated last
+func (e *generatorEmitter) explodeLogical(left ast.IExpr, right ast.IExpr, jump func(ast.IExpr) ast.IExpr, ignoreResult bool) ast.IExpr {
+	after := e.loc()
+	result := e.temp()
+
+	e.emitAssign(e.property(result), e.explodeExpression(left, false))
+	e.emitJumpIf(jump(e.property(result)), after)
+
+	if ignoreResult {
+		e.explodeExpression(right, true)
+	} else {
+		e.emitAssign(e.property(result), e.explodeExpression(right, false))
+	}
+
+	e.mark(after)
+
+	if ignoreResult {
+		return nil
+	}
+
+	return e.property(result)
+}
+
+// explodeCall keeps this of the method calls, a.b(yield) is called as _context.t0.b.call(_context.t0, _context.sent)
+func (e *generatorEmitter) explodeCall(ce *ast.CallExpression, explodeViaTemp func(string, ast.IExpr, bool) ast.IExpr) ast.IExpr {
+	hasLeapingArguments := false
+
+	for _, argument := range ce.ArgumentList {
+		if containsLeap(argument) {
+			hasLeapingArguments = true
+		}
+	}
+
+	member, isMember := ce.Callee.(*ast.MemberExpression)
+
+	if !hasLeapingArguments {
+		callee := e.explodeExpression(ce.Callee, false)
+
+		return &ast.CallExpression{Callee: callee, ArgumentList: ce.ArgumentList}
+	}
+
+	if isMember {
+		object := explodeViaTemp(e.temp(), member.Left, false)
+		property := member.Right
+
+		if member.Kind == ast.MKArray {
+			property = explodeViaTemp("", member.Right, false)
+		}
+
+		fn := &ast.MemberExpression{Left: object, Right: property, Kind: member.Kind}
+
+		return e.t.callWith(fn, copyProperty(object), e.explodeArguments(ce.ArgumentList, explodeViaTemp))
+	}
+
+	callee := explodeViaTemp("", ce.Callee, false)
+
+	if _, ok := callee.(*ast.MemberExpression); ok {
+		// temps are called without this
+		callee = &ast.SequenceExpression{Sequence: []ast.IExpr{&ast.NumberLiteral{Literal: "0"}, callee}}
+	}
+
+	return &ast.CallExpression{Callee: callee, ArgumentList: e.explodeArguments(ce.ArgumentList, explodeViaTemp)}
+}
+
+func (e *generatorEmitter) explodeArguments(list []ast.IExpr, explodeViaTemp func(string, ast.IExpr, bool) ast.IExpr) []ast.IExpr {
+	exploded := make([]ast.IExpr, 0, len(list))
+
+	for _, item := range list {
+		switch i := item.(type) {
+		case nil:
+			exploded = append(exploded, nil)
+		case *ast.SpreadExpression:
+			exploded = append(exploded, &ast.SpreadExpression{Value: explodeViaTemp("", i.Value, false)})
+		case *ast.ArraySpread:
+			exploded = append(exploded, &ast.ArraySpread{Expression: explodeViaTemp("", i.Expression, false)})
+		default:
+			exploded = append(exploded, explodeViaTemp("", item, false))
+		}
+	}
+
+	return exploded
+}
+
+func copyProperty(exp ast.IExpr) ast.IExpr {
+	if me, ok := exp.(*ast.MemberExpression); ok {
+		return &ast.MemberExpression{Left: copyProperty(me.Left), Right: me.Right, Kind: me.Kind}
+	}
+
+	return exp
+}
+
+func isLiteral(exp ast.IExpr) bool {
+	switch exp.(type) {
+	case *ast.NumberLiteral, *ast.StringLiteral, *ast.BooleanLiteral, *ast.NullLiteral:
+		return true
+	}
+
+	return false
+}
+
+func (e *generatorEmitter) hoist(id *ast.Identifier) {
+	name := id.Name
+
+	if id.LegacyRef != nil {
+		name = id.LegacyRef.Name
+	}
+
+	if !e.declared[name] {
+		e.declared[name] = true
+		e.hoisted = append(e.hoisted, id)
+	}
+}
+
+// leapFinder looks for yields and statements leaving the code they are in,
+// nested functions are not visited
+type leapFinder struct {
+	ast.Walker
+
+	found bool
+}
+
+func containsLeap(node interface{}) bool {
+	f := &leapFinder{}
+	f.Walker.Visitor = f
+
+	switch n := node.(type) {
+	case ast.IStmt:
+		f.Statement(n)
+	case ast.IExpr:
+		f.Expression(n)
+	}
+
+	return f.found
+}
+
+func (f *leapFinder) FunctionLiteral(fl *ast.FunctionLiteral) *ast.FunctionLiteral {
+	return fl
+}
+
+func (f *leapFinder) ArrowFunctionExpression(af *ast.ArrowFunctionExpression) *ast.ArrowFunctionExpression {
+	return af
+}
+
+func (f *leapFinder) ClassExpression(c *ast.ClassExpression) *ast.ClassExpression {
+	return c
+}
+
+func (f *leapFinder) YieldExpression(y *ast.YieldExpression) *ast.YieldExpression {
+	f.found = true
+
+	return y
+}
+
+func (f *leapFinder) ReturnStatement(stmt *ast.ReturnStatement) ast.IStmt {
+	f.found = true
+
+	return stmt
+}
+
+func (f *leapFinder) BranchStatement(stmt *ast.BranchStatement) ast.IStmt {
+	f.found = true
+
+	return stmt
+}
+
+// generatorHoister turns variable declarations into assignments and moves function declarations
+// out of the generator body, arguments are replaced with the ones of the generator function
+type generatorHoister struct {
+	ast.Walker
+
+	e *generatorEmitter
+}
+
+func newGeneratorHoister(e *generatorEmitter) *generatorHoister {
+	h := &generatorHoister{e: e}
+	h.Walker.Visitor = h
+
+	return h
+}
+
+func (h *generatorHoister) Statement(stmt ast.IStmt) ast.IStmt {
+	switch s := stmt.(type) {
+	case *ast.FunctionLiteral:
+		h.e.functions = append(h.e.functions, s)
+
+		return &ast.EmptyStatement{}
+	case *ast.VariableStatement:
+		var list []ast.IExpr
+
+		for _, binding := range s.List {
+			id := binding.Binder.(*ast.IdentifierBinder).Id
+			h.e.hoist(id)
+
+			if binding.Initializer != nil {
+				list = append(list, assign(id, h.Expression(binding.Initializer)))
+			}
+		}
+
+		if len(list) == 0 {
+			return &ast.EmptyStatement{}
+		}
+
+		return &ast.ExpressionStatement{Expression: sequence(list)}
+	}
+
+	return h.Walker.Statement(stmt)
+}
+
+func (h *generatorHoister) ForInStatement(stmt *ast.ForInStatement) ast.IStmt {
+	stmt.Left = h.iterationLeft(stmt.Left)
+	stmt.Right = h.Expression(stmt.Right)
+	stmt.Body = h.Statement(stmt.Body)
+
+	return stmt
+}
+
+func (h *generatorHoister) ForOfStatement(stmt *ast.ForOfStatement) ast.IStmt {
+	stmt.Left = h.iterationLeft(stmt.Left)
+	stmt.Right = h.Expression(stmt.Right)
+	stmt.Body = h.Statement(stmt.Body)
+
+	return stmt
+}
+
+// iterationLeft turns var x of for in and for of statements into x
+func (h *generatorHoister) iterationLeft(left ast.IStmt) ast.IStmt {
+	if vs, ok := left.(*ast.VariableStatement); ok {
+		id := vs.List[0].Binder.(*ast.IdentifierBinder).Id
+		h.e.hoist(id)
+
+		return &ast.ExpressionStatement{Expression: id}
+	}
+
+	return h.Walker.Statement(left)
+}
+
+func (h *generatorHoister) FunctionLiteral(fl *ast.FunctionLiteral) *ast.FunctionLiteral {
+	return fl
+}
+
+func (h *generatorHoister) ClassExpression(c *ast.ClassExpression) *ast.ClassExpression {
+	return c
+}
+
+func (h *generatorHoister) ObjectPropertyName(name ast.ObjectPropertyName) ast.ObjectPropertyName {
+	if computed, ok := name.(*ast.ComputedName); ok {
+		return h.ComputedName(computed)
+	}
+
+	return name
+}
+
+func (h *generatorHoister) Identifier(id *ast.Identifier) *ast.Identifier {
+	if id == nil || id.Name != "arguments" {
+		return id
+	}
+
+	if h.e.args == "" {
+		h.e.args = h.e.t.uniqueName("_args")
+	}
+
+	return createId(h.e.args)
+}
@@ -0,0 +1,102 @@
+package transpiler
+
+import (
+	"yawp/builtins"
+	"yawp/parser"
+	"yawp/parser/ast"
+)
+
+// Runtime helpers get the ref of the module on the first call, see ast.ModuleAdditions.
+// The module declares the helpers it calls once at the top, transpiled as its own code,
+// or imports them from the runtime module when there is one:
+//
+// import {classCallCheck as _classCallCheck} from 'yawp/runtime';
+
+// helper returns the id calling the runtime helper
+func (t *Transpiler) helper(h *builtins.Helper) *ast.Identifier {
+	index := 0
+
+	for index < len(t.helpers) && t.helpers[index] != h {
+		index++
+	}
+
+	if index == len(t.helpers) {
+		t.helpers = append(t.helpers, h)
+		t.module.Additions.Helpers = append(t.module.Additions.Helpers, &ast.Identifier{
+			LegacyRef: t.runtimeRef(h.Name),
+			Name:      h.Name,
+		})
+	}
+
+	ref := t.module.Additions.Helpers[index].LegacyRef
+
+	return &ast.Identifier{LegacyRef: ref, Name: ref.Name}
+}
+
+// runtimeRef is the ref of the function the module gets from the runtime, mangled
+// by the transpiler, so the generator prints it as is
+func (t *Transpiler) runtimeRef(name string) *ast.SymbolRef {
+	if t.options.Minify {
+		name = t.ids.Next()
+	} else {
+		name = t.uniqueName("_" + name)
+	}
+
+	return &ast.SymbolRef{Name: name, Type: ast.SRBuiltin, Mangled: true}
+}
+
+// declareHelpers prepends the declarations or the import of the helpers called by the module
+func (t *Transpiler) declareHelpers(list []ast.IStmt) []ast.IStmt {
+	if len(t.helpers) == 0 {
+		return list
+	}
+
+	if t.options.Runtime != "" {
+		return prepend(list, importStatement(t.options.Runtime, t.module.Additions.Helpers))
+	}
+
+	declarations := make([]ast.IStmt, 0, len(t.helpers))
+
+	for index, h := range t.helpers {
+		declarations = append(declarations, t.helperDeclaration(h, t.module.Additions.Helpers[index].LegacyRef))
+	}
+
+	return prepend(list, declarations...)
+}
+
+// helperDeclaration parses the source of the helper and transpiles it as the module code,
+// so it is minified the same way, the function is named by the ref the module calls it by
+func (t *Transpiler) helperDeclaration(h *builtins.Helper, ref *ast.SymbolRef) ast.IStmt {
+	module, err := parser.ParseModule("", h.Source)
+
+	if err != nil {
+		panic(err)
+	}
+
+	function := module.Body[0].(*ast.FunctionLiteral)
+	function.Id = nil
+
+	t.Statement(function)
+	function.Id = &ast.Identifier{LegacyRef: ref, Name: ref.Name}
+
+	return function
+}
+
+// importStatement imports the names of the ids from the module as their refs
+func importStatement(from string, ids []*ast.Identifier) *ast.ImportStatement {
+	imports := make([]*ast.ImportClause, 0, len(ids))
+
+	for _, id := range ids {
+		imports = append(imports, &ast.ImportClause{
+			ModuleIdentifier: createId(id.Name),
+			LocalIdentifier:  &ast.Identifier{LegacyRef: id.LegacyRef, Name: id.LegacyRef.Name},
+		})
+	}
+
+	return &ast.ImportStatement{
+		Kind:           ast.IKValue,
+		Imports:        imports,
+		From:           "'" + from + "'",
+		HasNamedClause: true,
+	}
+}
@@ -5,14 +5,56 @@ import "yawp/parser/ast"
 func (t *Transpiler) IdentifierBinder(vb *ast.IdentifierBinder) *ast.IdentifierBinder {
 	if t.bindingRefKind != ast.SRUnknown {
 		// Binding yet unknown id
-		vb.Id.LegacyRef = t.refScope.BindRef(t.bindingRefKind, vb.Id.Name)
+		vb.Id.LegacyRef = t.resolvedRef(vb.Id, t.bindRef(t.bindingRefKind, vb.Id.Name))
+		t.initialize(vb.Id)
+	} else {
+		// assigned id, e.g. for (a in b) and [a] = b
+		vb.Id = t.Identifier(vb.Id)
 	}
 
 	return vb
 }
 
 func (t *Transpiler) Identifier(id *ast.Identifier) *ast.Identifier {
+	if id == nil {
+		return nil
+	}
+
+	// runtime helpers are named by the module, see helper
+	if id.LegacyRef != nil && id.LegacyRef.Type == ast.SRBuiltin {
+		return id
+	}
+
+	// lowered arrow functions use arguments of the enclosing function
+	if id.Name == "arguments" && t.thisScope.NeedsReplacement {
+		return t.getArgumentsReplacement()
+	}
+
+	defined := t.refScope.GetRef(id.Name) != nil
 	id.LegacyRef = t.refScope.UseRef(id.Name)
 
+	if !defined && id.Symbol != nil && id.Symbol.Ref != nil {
+		t.unresolved[id.Symbol.Ref] = append(t.unresolved[id.Symbol.Ref], &unresolvedRef{ref: id.LegacyRef, scope: t.refScope})
+	}
+
 	return id
 }
+
+// resolvedRef gives the name of the declaration to the refs of the ids used before it,
+// e.g. by the functions and initializers referencing it, those are the same variable by the parser
+func (t *Transpiler) resolvedRef(id *ast.Identifier, ref *ast.SymbolRef) *ast.SymbolRef {
+	if id.Symbol == nil || id.Symbol.Ref == nil {
+		return ref
+	}
+
+	for _, used := range t.unresolved[id.Symbol.Ref] {
+		// parameter defaults are resolved to the body by the parser, its declarations are renamed apart
+		if used.ref != ref && used.ref.Name == id.Name && used.scope.within(t.refScope) {
+			used.ref.Name = ref.Name
+		}
+	}
+
+	delete(t.unresolved, id.Symbol.Ref)
+
+	return ref
+}
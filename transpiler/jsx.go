@@ -0,0 +1,400 @@
+package transpiler
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+	"yawp/options"
+	"yawp/parser/ast"
+	"yawp/parser/file"
+)
+
+// JSX elements are lowered to calls creating them, the classic runtime calls the pragma
+// with children as arguments:
+//
+// <div className="a" {...p}>Hi {name}</div>
+// React.createElement('div', {className: 'a', ...p}, 'Hi ', name)
+//
+// The automatic one calls the functions imported from jsx-runtime of the import source,
+// children are passed in props, jsxs gets more of them, and the key goes apart:
+//
+// import {jsxs as _jsxs} from 'react/jsx-runtime';
+// _jsxs('div', {className: 'a', ...p, children: ['Hi ', name]});
+//
+// The key following spread attributes can not be told apart, createElement of the import source
+// is called then. Entities of texts and strings are decoded, lines of texts are trimmed.
+//
+// The development runtime gets the location of the element and this as well:
+//
+// _jsxDEV('div', {children: 'Hi'}, void 0, false, {fileName: 'a.jsx', lineNumber: 1, columnNumber: 5}, this);
+
+var jsxEntity = regexp.MustCompile(`&(?:#x[0-9a-fA-F]+|#[0-9]+|[a-zA-Z][a-zA-Z0-9]*);`)
+
+// jsxOptions overrides the JSX options by the pragmas of the module, @jsx and @jsxFrag
+// switch it to the classic runtime and @jsxImportSource to the automatic one,
+// unless @jsxRuntime tells which one it is
+func jsxOptions(o *options.Options, pragmas ast.Pragmas) *options.Options {
+	if pragmas == (ast.Pragmas{}) {
+		return o
+	}
+
+	module := *o
+
+	if pragmas.JSX != "" {
+		module.JSX = options.JSXClassic
+		module.JSXPragma = pragmas.JSX
+	}
+
+	if pragmas.JSXFrag != "" {
+		module.JSX = options.JSXClassic
+		module.JSXPragmaFrag = pragmas.JSXFrag
+	}
+
+	if pragmas.JSXImportSource != "" {
+		module.JSX = options.JSXAutomatic
+		module.JSXImportSource = pragmas.JSXImportSource
+	}
+
+	switch pragmas.JSXRuntime {
+	case "classic":
+		module.JSX = options.JSXClassic
+	case "automatic":
+		module.JSX = options.JSXAutomatic
+	}
+
+	return &module
+}
+
+// jsxImport is the function or the component imported by the module for JSX,
+// Name of the id is the imported one
+type jsxImport struct {
+	from string
+	id   *ast.Identifier
+}
+
+func (t *Transpiler) JsxElement(e *ast.JSXElement) *ast.JSXElement {
+	t.Walker.ReplacementExpression = t.Expression(t.jsxElement(e.Loc, jsxType(e.Name), e.Attributes, e.Children, t.jsxSelf(e)))
+
+	return nil
+}
+
+func (t *Transpiler) JsxFragment(f *ast.JSXFragment) *ast.JSXFragment {
+	var fragment ast.IExpr
+
+	if t.options.JSX == options.JSXAutomatic {
+		fragment = t.jsxImport(t.jsxRuntimeSource(), "Fragment")
+	} else {
+		fragment = jsxPragma(t.options.JSXPragmaFrag, "React.Fragment")
+	}
+
+	t.Walker.ReplacementExpression = t.Expression(t.jsxElement(f.Loc, fragment, nil, f.Children, t.jsxSelf(f)))
+
+	return nil
+}
+
+func (t *Transpiler) jsxElement(loc *file.Loc, tag ast.IExpr, attributes []ast.JSXAttribute, children []ast.JSXChild, self ast.IExpr) ast.IExpr {
+	list := jsxChildren(children)
+
+	if t.options.JSX != options.JSXAutomatic {
+		return call(jsxPragma(t.options.JSXPragma, "React.createElement"), append([]ast.IExpr{tag, t.jsxProps(loc, attributes, self)}, list...)...)
+	}
+
+	var key ast.IExpr
+	spread := false
+
+	for index := 0; index < len(attributes); index++ {
+		switch a := attributes[index].(type) {
+		case *ast.JSXSpreadAttribute:
+			spread = true
+		case *ast.JSXNamedAttribute:
+			if a.Name.Name != "key" {
+				continue
+			}
+
+			if spread {
+				createElement := t.jsxImport(t.jsxImportSource(), "createElement")
+
+				return call(createElement, append([]ast.IExpr{tag, t.jsxProps(loc, attributes, self)}, list...)...)
+			}
+
+			key = jsxValue(a.Value)
+			attributes = append(attributes[:index:index], attributes[index+1:]...)
+			index--
+		}
+	}
+
+	props := &ast.ObjectLiteral{Properties: jsxProperties(attributes)}
+	function := "jsx"
+
+	switch len(list) {
+	case 0:
+	case 1:
+		props.Properties = append(props.Properties, &ast.ObjectPropertyValue{PropertyName: createId("children"), Value: list[0]})
+	default:
+		props.Properties = append(props.Properties, &ast.ObjectPropertyValue{PropertyName: createId("children"), Value: &ast.ArrayLiteral{List: list}})
+		function = "jsxs"
+	}
+
+	if t.options.JSXDevelopment {
+		if key == nil {
+			key = undefined()
+		}
+
+		if self == nil {
+			self = undefined()
+		}
+
+		static := &ast.BooleanLiteral{Literal: strconv.FormatBool(function == "jsxs")}
+
+		return call(t.jsxImport(t.jsxRuntimeSource(), "jsxDEV"), tag, props, key, static, t.jsxSource(loc), self)
+	}
+
+	arguments := []ast.IExpr{tag, props}
+
+	if key != nil {
+		arguments = append(arguments, key)
+	}
+
+	return call(t.jsxImport(t.jsxRuntimeSource(), function), arguments...)
+}
+
+// jsxSource is the location of the element in the module for the development runtime
+func (t *Transpiler) jsxSource(loc *file.Loc) ast.IExpr {
+	var fileName string
+
+	if t.module.File != nil {
+		fileName = t.module.File.Name()
+	}
+
+	return &ast.ObjectLiteral{Properties: []ast.ObjectProperty{
+		&ast.ObjectPropertyValue{PropertyName: createId("fileName"), Value: createString(fileName)},
+		&ast.ObjectPropertyValue{PropertyName: createId("lineNumber"), Value: &ast.NumberLiteral{Literal: strconv.Itoa(loc.Line)}},
+		&ast.ObjectPropertyValue{PropertyName: createId("columnNumber"), Value: &ast.NumberLiteral{Literal: strconv.Itoa(loc.Col)}},
+	}}
+}
+
+// jsxSelf is this of the element for the development runtime, the elements moved out
+// of class members keep the one of the member. Constructors of derived classes have none
+// before super() is called, nil is returned then
+func (t *Transpiler) jsxSelf(element ast.IExpr) ast.IExpr {
+	if self, ok := t.jsxSelves[element]; ok {
+		return self
+	}
+
+	if t.thisScope.DerivedConstructor {
+		return nil
+	}
+
+	return &ast.ThisExpression{}
+}
+
+func (t *Transpiler) jsxImportSource() string {
+	if t.options.JSXImportSource == "" {
+		return "react"
+	}
+
+	return t.options.JSXImportSource
+}
+
+func (t *Transpiler) jsxRuntimeSource() string {
+	if t.options.JSXDevelopment {
+		return t.jsxImportSource() + "/jsx-dev-runtime"
+	}
+
+	return t.jsxImportSource() + "/jsx-runtime"
+}
+
+// jsxImport returns the id of the name imported from the module, the import is declared once
+func (t *Transpiler) jsxImport(from string, name string) *ast.Identifier {
+	for _, i := range t.jsxImports {
+		if i.from == from && i.id.Name == name {
+			return &ast.Identifier{LegacyRef: i.id.LegacyRef, Name: i.id.LegacyRef.Name}
+		}
+	}
+
+	ref := t.runtimeRef(name)
+	t.jsxImports = append(t.jsxImports, &jsxImport{from: from, id: &ast.Identifier{LegacyRef: ref, Name: name}})
+
+	return &ast.Identifier{LegacyRef: ref, Name: ref.Name}
+}
+
+// declareJSXImports prepends the imports of the JSX runtime, a statement per module
+func (t *Transpiler) declareJSXImports(list []ast.IStmt) []ast.IStmt {
+	var statements []ast.IStmt
+	declared := make(map[string]bool)
+
+	for _, i := range t.jsxImports {
+		if declared[i.from] {
+			continue
+		}
+
+		declared[i.from] = true
+
+		var ids []*ast.Identifier
+
+		for _, other := range t.jsxImports {
+			if other.from == i.from {
+				ids = append(ids, other.id)
+			}
+		}
+
+		statements = append(statements, importStatement(i.from, ids))
+	}
+
+	return prepend(list, statements...)
+}
+
+// jsxPragma builds the expression of the pragma like React.createElement
+func jsxPragma(pragma string, fallback string) ast.IExpr {
+	if pragma == "" {
+		pragma = fallback
+	}
+
+	names := strings.Split(pragma, ".")
+	var exp ast.IExpr = createId(names[0])
+
+	for _, name := range names[1:] {
+		exp = createMember(exp, name)
+	}
+
+	return exp
+}
+
+// jsxType is the component of the element or the tag name, those are lower case,
+// have dashes or namespaces, e.g. div, my-element and svg:rect
+func jsxType(name *ast.JSXElementName) ast.IExpr {
+	if id, ok := name.Expression.(*ast.Identifier); ok {
+		first, _ := utf8.DecodeRuneInString(id.Name)
+
+		if first >= 'a' && first <= 'z' || strings.Contains(id.Name, "-") {
+			return createString(id.Name)
+		}
+	}
+
+	if _, ok := name.Expression.(*ast.JSXNamespacedName); ok {
+		return createString(name.StringName)
+	}
+
+	return name.Expression
+}
+
+// jsxProps is the props object of createElement, null when there are no attributes,
+// the development one gets the location and this as __source and __self
+func (t *Transpiler) jsxProps(loc *file.Loc, attributes []ast.JSXAttribute, self ast.IExpr) ast.IExpr {
+	properties := jsxProperties(attributes)
+
+	if t.options.JSXDevelopment {
+		properties = append(properties, &ast.ObjectPropertyValue{PropertyName: createId("__source"), Value: t.jsxSource(loc)})
+	}
+
+	if t.options.JSXDevelopment && self != nil {
+		properties = append(properties, &ast.ObjectPropertyValue{PropertyName: createId("__self"), Value: self})
+	}
+
+	if len(properties) == 0 {
+		return &ast.NullLiteral{Literal: "null"}
+	}
+
+	return &ast.ObjectLiteral{Properties: properties}
+}
+
+func jsxProperties(attributes []ast.JSXAttribute) []ast.ObjectProperty {
+	properties := make([]ast.ObjectProperty, 0, len(attributes))
+
+	for _, attribute := range attributes {
+		switch a := attribute.(type) {
+		case *ast.JSXSpreadAttribute:
+			properties = append(properties, &ast.ObjectSpread{Expression: a.Expression})
+		case *ast.JSXNamedAttribute:
+			var name ast.ObjectPropertyName = createId(a.Name.Name)
+
+			if strings.Contains(a.Name.Name, "-") {
+				name = createString(a.Name.Name)
+			}
+
+			properties = append(properties, &ast.ObjectPropertyValue{PropertyName: name, Value: jsxValue(a.Value)})
+		}
+	}
+
+	return properties
+}
+
+// jsxValue decodes entities of the string value, backslashes are not escapes there,
+// new lines with the indentation following are replaced by spaces
+func jsxValue(value ast.IExpr) ast.IExpr {
+	s, ok := value.(*ast.StringLiteral)
+
+	if !ok || s.Raw {
+		return value
+	}
+
+	text := jsxDecode(s.Literal[1 : len(s.Literal)-1])
+	lines := strings.Split(text, "\n")
+
+	for index := 1; index < len(lines); index++ {
+		lines[index] = strings.TrimLeft(lines[index], " \t\r")
+	}
+
+	return createString(strings.Join(lines, " "))
+}
+
+func jsxChildren(children []ast.JSXChild) []ast.IExpr {
+	list := make([]ast.IExpr, 0, len(children))
+
+	for _, child := range children {
+		switch c := child.(type) {
+		case *ast.JSXText:
+			if text := jsxText(jsxDecode(c.Text)); text != "" {
+				list = append(list, createString(text))
+			}
+		case *ast.JSXChildExpression:
+			// {} and {/* comments */} are empty
+			if c.IExpr != nil {
+				list = append(list, c.IExpr)
+			}
+		case *ast.JSXElement:
+			list = append(list, c)
+		case *ast.JSXFragment:
+			list = append(list, c)
+		}
+	}
+
+	return list
+}
+
+// jsxText trims the lines of the text, but the start of the first one and the end of the last,
+// and joins those left with spaces, the lines of white space only are dropped, so
+// <p>Hello,\n  {name}\n</p> has children 'Hello,' and name
+func jsxText(text string) string {
+	lines := strings.Split(strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(text), "\n")
+	var result []string
+
+	for index, line := range lines {
+		line = strings.Replace(line, "\t", " ", -1)
+
+		if index > 0 {
+			line = strings.TrimLeft(line, " ")
+		}
+
+		if index < len(lines)-1 {
+			line = strings.TrimRight(line, " ")
+		}
+
+		if line != "" {
+			result = append(result, line)
+		}
+	}
+
+	return strings.Join(result, " ")
+}
+
+// jsxDecode replaces the entities like &amp; and &#x41; by the characters
+func jsxDecode(text string) string {
+	if !strings.Contains(text, "&") {
+		return text
+	}
+
+	return jsxEntity.ReplaceAllStringFunc(text, html.UnescapeString)
+}
@@ -0,0 +1,318 @@
+package transpiler
+
+import (
+	"yawp/builtins"
+	"yawp/parser/ast"
+	"yawp/parser/token"
+)
+
+// Legacy decorators follow Babel decorators-legacy mode. Members are decorated once the class
+// is defined through their property descriptors, decorated fields are defined by the constructor
+// with descriptors returned by decorators, and class decorators replace the class:
+//
+// class A { m() {} }
+// @applyDecoratedDescriptor(A.prototype, 'm', [dec], Object.getOwnPropertyDescriptor(A.prototype, 'm'), A.prototype);
+// A = cls(A) || A;
+
+type legacyDecoration struct {
+	t     *Transpiler
+	class *ast.ClassExpression
+	ref   *ast.Identifier // temp holding the class expression
+
+	targets []*ast.Identifier // references to the class, see expression
+
+	applications    []ast.IExpr
+	initializers    []ast.IStmt // decorated instance fields defined by the constructor
+	classDecorators []ast.IExpr
+}
+
+// decoration is the class with decorators lowered in place,
+// it is either a declaration or an expression replacement
+type decoration interface {
+	statements(stmt ast.IStmt) ast.Statements
+	expression() ast.IExpr
+}
+
+func (t *Transpiler) decorate(c *ast.ClassExpression) decoration {
+	if t.options.LegacyDecorators && hasDecorators(c) {
+		return t.legacyDecorateClass(c)
+	}
+
+	return t.decorateClass(c)
+}
+
+func hasDecorators(c *ast.ClassExpression) bool {
+	if len(c.Decorators) > 0 {
+		return true
+	}
+
+	for _, member := range c.Body.(*ast.BlockStatement).List {
+		switch m := member.(type) {
+		case *ast.ClassFieldStatement:
+			if len(m.Decorators) > 0 {
+				return true
+			}
+		case *ast.ClassAccessorStatement:
+			if len(m.Decorators) > 0 {
+				return true
+			}
+		case *ast.ClassMethodStatement:
+			if len(m.Decorators) > 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// legacyDecorateClass removes decorators from the class,
+// auto accessors are left to be lowered as undecorated class is visited again
+func (t *Transpiler) legacyDecorateClass(c *ast.ClassExpression) *legacyDecoration {
+	body := c.Body.(*ast.BlockStatement)
+
+	d := &legacyDecoration{
+		t:               t,
+		class:           c,
+		classDecorators: c.Decorators,
+	}
+
+	c.Decorators = nil
+
+	if c.Name == nil {
+		d.ref = t.tempId("class")
+	}
+
+	members := make([]ast.IStmt, 0, len(body.List))
+
+	for _, member := range body.List {
+		switch m := member.(type) {
+		case *ast.ClassFieldStatement:
+			if len(m.Decorators) > 0 && m.Private {
+				t.error(m.Loc, errPrivateDecorated, "field")
+				m.Decorators = nil
+			} else if len(m.Decorators) > 0 && m.Accessor {
+				// decorates the getter and setter pair defined by the auto accessor
+				d.member(m.Decorators, m.Name, m.Static)
+				m.Decorators = nil
+			} else if len(m.Decorators) > 0 {
+				d.field(m)
+
+				continue
+			}
+		case *ast.ClassMethodStatement:
+			if len(m.Decorators) > 0 && m.Private {
+				t.error(m.Loc, errPrivateDecorated, "method")
+			} else if len(m.Decorators) > 0 {
+				d.member(m.Decorators, m.Name, m.Static)
+			}
+
+			m.Decorators = nil
+		case *ast.ClassAccessorStatement:
+			if len(m.Decorators) > 0 && m.Private {
+				t.error(m.Loc, errPrivateDecorated, "accessor")
+			} else if len(m.Decorators) > 0 {
+				d.member(m.Decorators, m.Field, m.Static)
+			}
+
+			m.Decorators = nil
+		}
+
+		members = append(members, member)
+	}
+
+	if len(d.initializers) > 0 {
+		members = addToConstructor(members, c.SuperClass != nil, d.initializers...)
+	}
+
+	body.List = members
+
+	return d
+}
+
+func (d *legacyDecoration) statements(stmt ast.IStmt) ast.Statements {
+	lowered := ast.Statements{stmt}
+
+	for _, application := range d.applications {
+		lowered = append(lowered, &ast.ExpressionStatement{Expression: application})
+	}
+
+	if len(d.classDecorators) > 0 {
+		lowered = append(lowered, &ast.ExpressionStatement{
+			Expression: assign(d.target(false), d.decorateTarget()),
+		})
+	}
+
+	return lowered
+}
+
+// expression builds (_class = class {}, ...applications, cls(_class) || _class),
+// class name is not bound outside of class expression so references made so far are renamed
+func (d *legacyDecoration) expression() ast.IExpr {
+	if d.ref == nil {
+		d.ref = d.t.tempId("class")
+
+		for _, target := range d.targets {
+			target.Name = d.ref.Name
+		}
+	}
+
+	list := append([]ast.IExpr{assign(d.target(false), d.class)}, d.applications...)
+
+	if len(d.classDecorators) > 0 {
+		list = append(list, d.decorateTarget())
+	} else {
+		list = append(list, d.target(false))
+	}
+
+	return sequence(list)
+}
+
+// decorateTarget builds a(_class = b(_class) || _class) || _class,
+// class decorators are applied starting with the last one
+func (d *legacyDecoration) decorateTarget() ast.IExpr {
+	exp := ast.IExpr(d.target(false))
+
+	for index := len(d.classDecorators) - 1; index >= 0; index-- {
+		exp = &ast.BinaryExpression{
+			Operator: token.LOGICAL_OR,
+			Left:     call(d.classDecorators[index], exp),
+			Right:    d.target(false),
+		}
+
+		if index > 0 {
+			exp = assign(d.target(false), exp)
+		}
+	}
+
+	return exp
+}
+
+// target is the class or its prototype for instance members
+func (d *legacyDecoration) target(proto bool) ast.IExpr {
+	var target *ast.Identifier
+
+	if d.ref != nil {
+		target = createId(d.ref.Name)
+	} else {
+		target = createId(d.class.Name.Name)
+		d.targets = append(d.targets, target)
+	}
+
+	if proto {
+		return createMember(target, "prototype")
+	}
+
+	return target
+}
+
+// name is the property name passed to decorators,
+// computed keys are saved to temps as the class evaluates them only once
+func (d *legacyDecoration) name(name ast.ObjectPropertyName) ast.IExpr {
+	switch n := name.(type) {
+	case *ast.Identifier:
+		return createString(n.Name)
+	case *ast.StringLiteral:
+		return &ast.StringLiteral{Literal: n.Literal, Raw: n.Raw}
+	case *ast.NumberLiteral:
+		return &ast.NumberLiteral{Literal: n.Literal}
+	case *ast.ComputedName:
+		key := d.t.tempId("key")
+		n.Expression = assign(key, n.Expression)
+
+		return createId(key.Name)
+	}
+
+	return nil
+}
+
+// member decorates method, getter or setter with the descriptor already defined by the class
+func (d *legacyDecoration) member(decorators []ast.IExpr, name ast.ObjectPropertyName, static bool) {
+	property := d.name(name)
+
+	d.applications = append(d.applications, call(
+		d.t.helper(builtins.ApplyDecoratedDescriptor),
+		d.target(!static),
+		property,
+		&ast.ArrayLiteral{List: decorators},
+		call(createMember(createId("Object"), "getOwnPropertyDescriptor"), d.target(!static), copyName(property)),
+		d.target(!static),
+	))
+}
+
+// field is removed from the class and described by the initializer,
+// static fields are defined right away and instance ones by the constructor
+func (d *legacyDecoration) field(f *ast.ClassFieldStatement) {
+	var property, key ast.IExpr
+
+	if computed, ok := f.Name.(*ast.ComputedName); ok {
+		// the key is evaluated once the class is defined
+		temp := d.t.tempId("key")
+		property = assign(temp, computed.Expression)
+		key = createId(temp.Name)
+	} else {
+		property = d.name(f.Name)
+		key = copyName(property)
+	}
+
+	initializer := ast.IExpr(&ast.NullLiteral{Literal: "null"})
+
+	if f.Initializer != nil {
+		initializer = getter(f.Initializer)
+	}
+
+	descriptor := &ast.ObjectLiteral{
+		Properties: []ast.ObjectProperty{
+			&ast.ObjectPropertyValue{PropertyName: createId("configurable"), Value: &ast.BooleanLiteral{Literal: "true"}},
+			&ast.ObjectPropertyValue{PropertyName: createId("enumerable"), Value: &ast.BooleanLiteral{Literal: "true"}},
+			&ast.ObjectPropertyValue{PropertyName: createId("writable"), Value: &ast.BooleanLiteral{Literal: "true"}},
+			&ast.ObjectPropertyValue{PropertyName: createId("initializer"), Value: initializer},
+		},
+	}
+
+	if f.Static {
+		d.applications = append(d.applications, call(
+			d.t.helper(builtins.ApplyDecoratedDescriptor),
+			d.target(false),
+			property,
+			&ast.ArrayLiteral{List: f.Decorators},
+			descriptor,
+			d.target(false),
+		))
+
+		return
+	}
+
+	temp := d.t.tempId("descriptor")
+
+	d.applications = append(d.applications, assign(temp, call(
+		d.t.helper(builtins.ApplyDecoratedDescriptor),
+		d.target(true),
+		property,
+		&ast.ArrayLiteral{List: f.Decorators},
+		descriptor,
+	)))
+
+	d.initializers = append(d.initializers, &ast.ExpressionStatement{
+		Expression: call(
+			d.t.helper(builtins.InitializerDefineProperty),
+			&ast.ThisExpression{},
+			key,
+			createId(temp.Name),
+			&ast.ThisExpression{},
+		),
+	})
+}
+
+func copyName(name ast.IExpr) ast.IExpr {
+	switch n := name.(type) {
+	case *ast.Identifier:
+		return createId(n.Name)
+	case *ast.StringLiteral:
+		return &ast.StringLiteral{Literal: n.Literal, Raw: n.Raw}
+	case *ast.NumberLiteral:
+		return &ast.NumberLiteral{Literal: n.Literal}
+	}
+
+	return name
+}
@@ -0,0 +1,176 @@
+package transpiler
+
+import (
+	"math/big"
+	"strings"
+	"yawp/options"
+	"yawp/parser/ast"
+)
+
+// Numbers are printed in decimal before ES2015 for binary and octal literals
+// and without separators before ES2021, 0b1_01 is 5. BigInt can not be lowered.
+//
+// RegExp flags and groups are lowered to equivalent patterns when there are those:
+//
+// /a.b/s is /a[\s\S]b/
+// /ab/u is /ab/
+//
+// the rest is created at runtime, so the old engines parse the code at least,
+// /(?<y>\d{4})/y is new RegExp('(?<y>\\d{4})', 'y')
+
+func (t *Transpiler) NumberLiteral(n *ast.NumberLiteral) *ast.NumberLiteral {
+	literal := n.Literal
+	bigInt := strings.HasSuffix(literal, "n")
+
+	if bigInt {
+		if !t.supports(options.BigInt) {
+			t.error(n.Loc, errBigInt, literal)
+		}
+
+		literal = strings.TrimSuffix(literal, "n")
+	}
+
+	if !t.supports(options.NumericSeparators) {
+		literal = strings.Replace(literal, "_", "", -1)
+	}
+
+	if !t.supports(options.BinaryOctalLiterals) && len(literal) > 1 && strings.ContainsAny(literal[1:2], "bBoO") {
+		if value, ok := new(big.Int).SetString(literal, 0); ok {
+			literal = value.String()
+		}
+	}
+
+	if bigInt {
+		literal += "n"
+	}
+
+	n.Literal = literal
+
+	return n
+}
+
+func (t *Transpiler) RegExpLiteral(r *ast.RegExpLiteral) *ast.RegExpLiteral {
+	pattern, flags := r.Pattern, r.Flags
+
+	if !t.supports(options.DotAllRegExp) && strings.Contains(flags, "s") {
+		pattern, flags = dotAll(pattern), strings.Replace(flags, "s", "", 1)
+	}
+
+	if !t.supports(options.UnicodeRegExp) && strings.Contains(flags, "u") && !unicodeSensitive(pattern, flags) {
+		flags = strings.Replace(flags, "u", "", 1)
+	}
+
+	supported := t.supports(options.NamedGroupsRegExp) || !hasNamedGroups(pattern)
+
+	if !t.supports(options.UnicodeRegExp) && strings.Contains(flags, "u") ||
+		!t.supports(options.StickyRegExp) && strings.Contains(flags, "y") {
+		supported = false
+	}
+
+	if !supported {
+		t.warn(r.Loc, warnRegExp, "/"+r.Pattern+"/"+r.Flags)
+
+		arguments := []ast.IExpr{createString(pattern)}
+
+		if flags != "" {
+			arguments = append(arguments, createString(flags))
+		}
+
+		t.Walker.ReplacementExpression = &ast.NewExpression{
+			Callee:       createId("RegExp"),
+			ArgumentList: arguments,
+		}
+
+		return nil
+	}
+
+	if pattern != r.Pattern || flags != r.Flags {
+		r.Pattern, r.Flags = pattern, flags
+		r.Literal = "/" + pattern + "/" + flags
+	}
+
+	return r
+}
+
+// scanRegExp calls visit with the escapes and the characters of the pattern in order,
+// class tells those are inside of [], the brackets are not
+func scanRegExp(pattern string, visit func(at int, token string, class bool)) {
+	class := false
+
+	for at := 0; at < len(pattern); at++ {
+		token := pattern[at : at+1]
+
+		switch {
+		case token == "\\" && at+1 < len(pattern):
+			token = pattern[at : at+2]
+		case token == "[" && !class:
+			visit(at, token, class)
+			class = true
+
+			continue
+		case token == "]" && class:
+			class = false
+		}
+
+		visit(at, token, class)
+		at += len(token) - 1
+	}
+}
+
+// dotAll replaces . of the pattern with s flag by the class matching any character as well
+func dotAll(pattern string) string {
+	var result strings.Builder
+
+	scanRegExp(pattern, func(at int, token string, class bool) {
+		if token == "." && !class {
+			token = `[\s\S]`
+		}
+
+		result.WriteString(token)
+	})
+
+	return result.String()
+}
+
+// unicodeSensitive tells whether u flag changes what the pattern matches,
+// those are characters out of ASCII, code point escapes, properties and the classes
+// that match astral characters as a whole
+func unicodeSensitive(pattern string, flags string) bool {
+	if strings.Contains(flags, "i") {
+		return true
+	}
+
+	sensitive := false
+	previous := ""
+
+	scanRegExp(pattern, func(at int, token string, class bool) {
+		switch {
+		case token[0] >= 0x80:
+			sensitive = true
+		case token == "." && !class, token == "^" && previous == "[":
+			sensitive = true
+		case token == `\u` && at+2 < len(pattern) && pattern[at+2] == '{':
+			sensitive = true
+		case token == `\p`, token == `\P`, token == `\S`, token == `\W`, token == `\D`:
+			sensitive = true
+		}
+
+		previous = token
+	})
+
+	return sensitive
+}
+
+// hasNamedGroups tells whether there are (?<name>) groups, those are not lookbehinds (?<= and (?<!
+func hasNamedGroups(pattern string) bool {
+	named := false
+
+	scanRegExp(pattern, func(at int, token string, class bool) {
+		if token == "(" && !class && strings.HasPrefix(pattern[at:], "(?<") &&
+			!strings.HasPrefix(pattern[at:], "(?<=") && !strings.HasPrefix(pattern[at:], "(?<!") {
+			named = true
+		}
+	})
+
+	return named
+}
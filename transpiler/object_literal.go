@@ -1,14 +1,267 @@
 package transpiler
 
-import "yawp/parser/ast"
+import (
+	"yawp/builtins"
+	"yawp/options"
+	"yawp/parser/ast"
+)
+
+// ObjectLiteral lowers spread before ES2018 and, for ES5, properties defined
+// from the first computed key or __proto__ on, methods become function expressions
+// reaching super through the object, shorthand properties are printed as key: value already
+func (t *Transpiler) ObjectLiteral(o *ast.ObjectLiteral) *ast.ObjectLiteral {
+	if !t.supports(options.ObjectRestSpread) && hasObjectSpread(o) {
+		t.Walker.ReplacementExpression = t.Expression(t.spreadObject(o))
+
+		return nil
+	}
+
+	if !t.supports(options.ObjectExtensions) {
+		index := len(o.Properties)
+		homeObject := false
+
+		for i, property := range o.Properties {
+			if isObjectExtension(property) && index == len(o.Properties) {
+				index = i
+			}
+
+			if body := methodBody(property); body != nil {
+				homeObject = homeObject || usesSuper(body)
+			}
+		}
+
+		if index < len(o.Properties) || homeObject {
+			t.Walker.ReplacementExpression = t.Expression(t.objectES5(o, index))
+
+			return nil
+		}
+
+		functionValues(o)
+	}
+
+	return t.Walker.ObjectLiteral(o)
+}
+
+// functionValues turns methods into properties with function values
+func functionValues(o *ast.ObjectLiteral) {
+	for _, property := range o.Properties {
+		if value, ok := property.(*ast.ObjectPropertyValue); ok {
+			value.Method = false
+		}
+	}
+}
+
+// methodBody is the body of methods, getters and setters, those may use super
+func methodBody(property ast.ObjectProperty) *ast.FunctionBody {
+	switch p := property.(type) {
+	case *ast.ObjectPropertyValue:
+		if fl, ok := p.Value.(*ast.FunctionLiteral); ok && p.Method {
+			return fl.Body
+		}
+	case *ast.ObjectPropertyGetter:
+		return p.Getter.Body
+	case *ast.ObjectPropertySetter:
+		return p.Setter.Body
+	}
+
+	return nil
+}
 
 func (t *Transpiler) ObjectPropertyValue(opv *ast.ObjectPropertyValue) *ast.ObjectPropertyValue {
 	if opv == nil {
 		return nil
 	}
 
+	if id, ok := opv.PropertyName.(*ast.Identifier); ok {
+		t.inferClassName(opv.Value, id.Name)
+	}
+
 	opv.Value = t.Expression(opv.Value)
 	opv.PropertyName = t.ObjectPropertyName(opv.PropertyName)
 
 	return opv
 }
+
+// ObjectPropertyName leaves identifier keys as they are, those are not references
+func (t *Transpiler) ObjectPropertyName(name ast.ObjectPropertyName) ast.ObjectPropertyName {
+	if _, ok := name.(*ast.Identifier); ok {
+		return name
+	}
+
+	return t.Walker.ObjectPropertyName(name)
+}
+
+func hasObjectSpread(o *ast.ObjectLiteral) bool {
+	for _, property := range o.Properties {
+		if _, ok := property.(*ast.ObjectSpread); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// spreadObject builds @objectSpread({ a }, b, { c }) of { a, ...b, c },
+// the properties before the first spread are defined on the result as they are
+func (t *Transpiler) spreadObject(o *ast.ObjectLiteral) ast.IExpr {
+	var arguments []ast.IExpr
+	var group *ast.ObjectLiteral
+
+	for _, property := range o.Properties {
+		if spread, ok := property.(*ast.ObjectSpread); ok {
+			if len(arguments) == 0 && group == nil {
+				arguments = append(arguments, &ast.ObjectLiteral{})
+			}
+
+			arguments = append(arguments, spread.Expression)
+			group = nil
+
+			continue
+		}
+
+		if group == nil {
+			group = &ast.ObjectLiteral{}
+			arguments = append(arguments, group)
+		}
+
+		group.Properties = append(group.Properties, property)
+	}
+
+	return call(t.helper(builtins.ObjectSpread), arguments...)
+}
+
+// isObjectExtension tells whether the property needs ES2015 object literal
+func isObjectExtension(property ast.ObjectProperty) bool {
+	switch p := property.(type) {
+	case *ast.ObjectPropertyValue:
+		return isComputed(p.PropertyName) || isProto(p.PropertyName)
+	case *ast.ObjectPropertyGetter:
+		return isComputed(p.PropertyName)
+	case *ast.ObjectPropertySetter:
+		return isComputed(p.PropertyName)
+	}
+
+	return false
+}
+
+func isComputed(name ast.ObjectPropertyName) bool {
+	_, ok := name.(*ast.ComputedName)
+
+	return ok
+}
+
+// isProto tells whether the key sets prototype of the object, computed __proto__ keys define properties
+func isProto(name ast.ObjectPropertyName) bool {
+	switch n := name.(type) {
+	case *ast.Identifier:
+		return n.Name == "__proto__"
+	case *ast.StringLiteral:
+		if n.Raw {
+			return n.Literal == "__proto__"
+		}
+
+		return len(n.Literal) > 2 && n.Literal[1:len(n.Literal)-1] == "__proto__"
+	}
+
+	return false
+}
+
+// objectES5 keeps the properties before the given index in the literal and defines the rest in order,
+// methods reach super through the prototype of the object:
+//
+// (_obj = { a: 1, m: function () { return @superGet(Object.getPrototypeOf(_obj), 'm', this).call(this); } }, @defineProperty(_obj, k, 2),
+// Object.defineProperty(_obj, "g", { get: ..., enumerable: true, configurable: true }), _obj)
+func (t *Transpiler) objectES5(o *ast.ObjectLiteral, index int) ast.IExpr {
+	obj := t.tempId("obj")
+
+	rewriter := newSuperRewriter(t, func() ast.IExpr {
+		return call(createMember(createId("Object"), "getPrototypeOf"), createId(obj.Name))
+	}, "", "")
+
+	for _, property := range o.Properties {
+		if body := methodBody(property); body != nil {
+			rewriter.Statements(body.List)
+		}
+	}
+
+	functionValues(o)
+
+	list := []ast.IExpr{
+		assign(obj, &ast.ObjectLiteral{Properties: o.Properties[:index]}),
+	}
+
+	accessor := func(name ast.ObjectPropertyName, kind string, function *ast.FunctionLiteral) ast.IExpr {
+		return call(
+			createMember(createId("Object"), "defineProperty"),
+			createId(obj.Name),
+			propertyKey(name),
+			&ast.ObjectLiteral{
+				Properties: []ast.ObjectProperty{
+					&ast.ObjectPropertyValue{PropertyName: createId(kind), Value: function},
+					&ast.ObjectPropertyValue{PropertyName: createId("enumerable"), Value: &ast.BooleanLiteral{Literal: "true"}},
+					&ast.ObjectPropertyValue{PropertyName: createId("configurable"), Value: &ast.BooleanLiteral{Literal: "true"}},
+				},
+			},
+		)
+	}
+
+	for _, property := range o.Properties[index:] {
+		switch p := property.(type) {
+		case *ast.ObjectPropertyValue:
+			if isProto(p.PropertyName) {
+				list = append(list, assign(createMember(createId(obj.Name), "__proto__"), p.Value))
+			} else {
+				list = append(list, call(t.helper(builtins.DefineProperty), createId(obj.Name), propertyKey(p.PropertyName), p.Value))
+			}
+		case *ast.ObjectPropertyGetter:
+			list = append(list, accessor(p.PropertyName, "get", p.Getter))
+		case *ast.ObjectPropertySetter:
+			list = append(list, accessor(p.PropertyName, "set", p.Setter))
+		}
+	}
+
+	return &ast.SequenceExpression{Sequence: append(list, createId(obj.Name))}
+}
+
+// propertyKey is the expression giving the key of the property
+func propertyKey(name ast.ObjectPropertyName) ast.IExpr {
+	switch n := name.(type) {
+	case *ast.Identifier:
+		return createString(n.Name)
+	case *ast.ComputedName:
+		return n.Expression
+	}
+
+	return name.(ast.IExpr)
+}
+
+// superFinder looks for super of the method, arrow functions share it with the method
+type superFinder struct {
+	ast.Walker
+
+	found bool
+}
+
+func usesSuper(body *ast.FunctionBody) bool {
+	f := &superFinder{}
+	f.Walker.Visitor = f
+	f.FunctionBody(body)
+
+	return f.found
+}
+
+func (f *superFinder) FunctionLiteral(fl *ast.FunctionLiteral) *ast.FunctionLiteral {
+	return fl
+}
+
+func (f *superFinder) ClassExpression(c *ast.ClassExpression) *ast.ClassExpression {
+	c.SuperClass = f.Expression(c.SuperClass)
+
+	return c
+}
+
+func (f *superFinder) SuperExpression(exp *ast.SuperExpression) ast.IExpr {
+	f.found = true
+
+	return exp
+}
@@ -0,0 +1,243 @@
+package transpiler
+
+import (
+	"yawp/options"
+	"yawp/parser/ast"
+	"yawp/parser/token"
+)
+
+// Optional chains and nullish coalescing are lowered before ES2020 to conditionals,
+// checked values are kept in temps unless those are identifiers or this,
+// the rest of the chain is skipped when the value is null or undefined:
+//
+// a.b?.c.d() is (_ref = a.b) == null ? void 0 : _ref.c.d()
+// a.b?.() is (_ref = (_obj = a).b) == null ? void 0 : _ref.call(_obj)
+// a ?? b is a != null ? a : b
+
+func (t *Transpiler) CoalesceExpression(ce *ast.CoalesceExpression) *ast.CoalesceExpression {
+	if t.supports(options.NullishCoalescing) {
+		return t.Walker.CoalesceExpression(ce)
+	}
+
+	check, value := t.checkedValue(ce.Head)
+
+	t.Walker.ReplacementExpression = t.Expression(&ast.ConditionalExpression{
+		Test: &ast.BinaryExpression{
+			Operator:   token.NOT_EQUAL,
+			Left:       check,
+			Right:      &ast.NullLiteral{Literal: "null"},
+			Comparison: true,
+		},
+		Consequent: value,
+		Alternate:  ce.Consequent,
+	})
+
+	return nil
+}
+
+func (t *Transpiler) OptionalObjectMemberAccessExpression(exp *ast.OptionalObjectMemberAccessExpression) *ast.OptionalObjectMemberAccessExpression {
+	if t.lowerOptionalChain(exp) {
+		return nil
+	}
+
+	return t.Walker.OptionalObjectMemberAccessExpression(exp)
+}
+
+func (t *Transpiler) OptionalArrayMemberAccessExpression(exp *ast.OptionalArrayMemberAccessExpression) *ast.OptionalArrayMemberAccessExpression {
+	if t.lowerOptionalChain(exp) {
+		return nil
+	}
+
+	return t.Walker.OptionalArrayMemberAccessExpression(exp)
+}
+
+func (t *Transpiler) OptionalCallExpression(exp *ast.OptionalCallExpression) *ast.OptionalCallExpression {
+	if t.lowerOptionalChain(exp) {
+		return nil
+	}
+
+	return t.Walker.OptionalCallExpression(exp)
+}
+
+// UnaryExpression lowers delete of optional chains, those are true when short-circuited
+func (t *Transpiler) UnaryExpression(ue *ast.UnaryExpression) *ast.UnaryExpression {
+	if id, ok := ue.Operand.(*ast.Identifier); ok && (ue.Operator == token.INCREMENT || ue.Operator == token.DECREMENT) &&
+		t.blockScopedAssignment(id) {
+		t.Walker.ReplacementExpression = t.tdz(id)
+
+		return nil
+	}
+
+	if ue.Operator != token.DELETE || t.supports(options.OptionalChaining) || !isOptionalChain(ue.Operand) {
+		return t.Walker.UnaryExpression(ue)
+	}
+
+	t.Walker.ReplacementExpression = t.Expression(t.optionalChain(ue.Operand, &ast.BooleanLiteral{Literal: "true"}, func(exp ast.IExpr) ast.IExpr {
+		return &ast.UnaryExpression{Operator: token.DELETE, Operand: exp}
+	}))
+
+	return nil
+}
+
+// lowerOptionalChain replaces the chain the expression ends, false is returned
+// for the chains kept for the target and the expressions that are not chains
+func (t *Transpiler) lowerOptionalChain(exp ast.IExpr) bool {
+	if t.supports(options.OptionalChaining) || !isOptionalChain(exp) {
+		return false
+	}
+
+	t.Walker.ReplacementExpression = t.Expression(t.optionalChain(exp, undefined(), func(exp ast.IExpr) ast.IExpr {
+		return exp
+	}))
+
+	return true
+}
+
+// optionalChain builds the conditional of the innermost optional link,
+// the rest of the chain is lowered the same way
+func (t *Transpiler) optionalChain(top ast.IExpr, short ast.IExpr, wrap func(ast.IExpr) ast.IExpr) ast.IExpr {
+	var parent ast.IExpr
+	var link ast.IExpr
+
+	for exp, prev := top, ast.IExpr(nil); exp != nil; exp, prev = chainLeft(exp), exp {
+		if isOptionalLink(exp) {
+			parent, link = prev, exp
+		}
+	}
+
+	if link == nil {
+		return wrap(top)
+	}
+
+	var check ast.IExpr
+	var plain ast.IExpr
+
+	switch l := link.(type) {
+	case *ast.OptionalObjectMemberAccessExpression:
+		var value ast.IExpr
+		check, value = t.checkedValue(l.Left)
+		plain = createMember(value, l.Identifier.Name)
+	case *ast.OptionalArrayMemberAccessExpression:
+		var value ast.IExpr
+		check, value = t.checkedValue(l.Left)
+		plain = &ast.MemberExpression{Left: value, Right: l.Index, Kind: ast.MKArray}
+	case *ast.OptionalCallExpression:
+		member, ok := l.Left.(*ast.MemberExpression)
+
+		if !ok {
+			var fn ast.IExpr
+			check, fn = t.checkedValue(l.Left)
+			plain = call(fn, l.Arguments...)
+
+			break
+		}
+
+		// methods are called with the object they are read from
+		var this ast.IExpr
+
+		switch object := member.Left.(type) {
+		case *ast.Identifier:
+			this = &ast.Identifier{Name: object.Name}
+		case *ast.ThisExpression, *ast.SuperExpression:
+			this = &ast.ThisExpression{}
+		default:
+			temp := t.tempId("obj")
+			member.Left = assign(temp, object)
+			this = createId(temp.Name)
+		}
+
+		fn := t.tempId("ref")
+		check = assign(fn, member)
+		plain = t.callWith(createId(fn.Name), this, l.Arguments)
+	}
+
+	rest := plain
+
+	if parent != nil {
+		setChainLeft(parent, plain)
+		rest = top
+	}
+
+	return &ast.ConditionalExpression{
+		Test: &ast.BinaryExpression{
+			Operator:   token.EQUAL,
+			Left:       check,
+			Right:      &ast.NullLiteral{Literal: "null"},
+			Comparison: true,
+		},
+		Consequent: short,
+		Alternate:  t.optionalChain(rest, short, wrap),
+	}
+}
+
+// checkedValue returns the expression checked for null and the expression reading it after,
+// values other than identifiers and this are evaluated once to the temp
+func (t *Transpiler) checkedValue(exp ast.IExpr) (ast.IExpr, ast.IExpr) {
+	switch e := exp.(type) {
+	case *ast.Identifier:
+		return e, &ast.Identifier{Name: e.Name}
+	case *ast.ThisExpression:
+		return e, &ast.ThisExpression{}
+	}
+
+	temp := t.tempId("ref")
+
+	return assign(temp, exp), createId(temp.Name)
+}
+
+func undefined() ast.IExpr {
+	return &ast.UnaryExpression{Operator: token.VOID, Operand: &ast.NumberLiteral{Literal: "0"}}
+}
+
+func isOptionalLink(exp ast.IExpr) bool {
+	switch exp.(type) {
+	case *ast.OptionalObjectMemberAccessExpression, *ast.OptionalArrayMemberAccessExpression, *ast.OptionalCallExpression:
+		return true
+	}
+
+	return false
+}
+
+// isOptionalChain tells whether there are optional links in the chain the expression ends
+func isOptionalChain(exp ast.IExpr) bool {
+	for ; exp != nil; exp = chainLeft(exp) {
+		if isOptionalLink(exp) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// chainLeft returns the object or the callee of chain links, nil for other expressions
+func chainLeft(exp ast.IExpr) ast.IExpr {
+	switch e := exp.(type) {
+	case *ast.MemberExpression:
+		return e.Left
+	case *ast.CallExpression:
+		return e.Callee
+	case *ast.OptionalObjectMemberAccessExpression:
+		return e.Left
+	case *ast.OptionalArrayMemberAccessExpression:
+		return e.Left
+	case *ast.OptionalCallExpression:
+		return e.Left
+	}
+
+	return nil
+}
+
+func setChainLeft(exp ast.IExpr, left ast.IExpr) {
+	switch e := exp.(type) {
+	case *ast.MemberExpression:
+		e.Left = left
+	case *ast.CallExpression:
+		e.Callee = left
+	case *ast.OptionalObjectMemberAccessExpression:
+		e.Left = left
+	case *ast.OptionalArrayMemberAccessExpression:
+		e.Left = left
+	case *ast.OptionalCallExpression:
+		e.Left = left
+	}
+}
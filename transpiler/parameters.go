@@ -0,0 +1,302 @@
+package transpiler
+
+import (
+	"strconv"
+	"yawp/builtins"
+	"yawp/options"
+	"yawp/parser/ast"
+	"yawp/parser/token"
+)
+
+func (t *Transpiler) parameterBinder(binder ast.PatternBinder) ast.PatternBinder {
+	bindingRefKind := t.bindingRefKind
+	t.bindingRefKind = ast.SRFnParam
+
+	defer func() {
+		t.bindingRefKind = bindingRefKind
+	}()
+
+	return t.PatternBinder(binder)
+}
+
+// lowerParameter declares the parameter in the function body from arguments, in order with the others,
+// parameters from the first default value on are not counted to the function length
+//
+// function f(a, b = 1) {}
+//
+// function f(a) { var b = arguments[1] === void 0 ? 1 : arguments[1]; }
+func (t *Transpiler) lowerParameter(binder ast.PatternBinder, defaultValue ast.IExpr) {
+	t.functionScope.LoweredParameters = true
+
+	index := &ast.NumberLiteral{Literal: strconv.Itoa(t.functionScope.ParameterIndex)}
+	var initializer ast.IExpr = &ast.MemberExpression{Left: builtins.Arguments, Right: index, Kind: ast.MKArray}
+
+	if defaultValue != nil {
+		initializer = &ast.ConditionalExpression{
+			Test: &ast.BinaryExpression{
+				Operator:   token.STRICT_EQUAL,
+				Left:       initializer,
+				Right:      undefined(),
+				Comparison: true,
+			},
+			Consequent: defaultValue,
+			Alternate:  &ast.MemberExpression{Left: builtins.Arguments, Right: index, Kind: ast.MKArray},
+		}
+	}
+
+	// parameters are not initialized for defaults of the parameters before
+	if t.uninitialized != nil {
+		for _, id := range binderIds(binder) {
+			if id.Symbol != nil && id.Symbol.Ref != nil {
+				t.uninitialized[id.Symbol.Ref] = true
+			}
+		}
+	}
+
+	t.pushExtraVariableToFunctionScope(&ast.VariableBinding{
+		Kind:        token.VAR,
+		Binder:      binder,
+		Initializer: initializer,
+	})
+}
+
+// separateParameterScope renames the body declarations of the names default values use,
+// those are the variables of the enclosing scopes and not of the body, the same
+// when the parameters are declared by the body for ES5
+//
+// function f(a = x) { var x; }
+//
+// function f(a = x) { var _x; }
+//
+// Functions declared by the body are renamed as well when named alike the parameters,
+// the body of non-simple parameter list has its own scope and those do not overwrite them
+//
+// function f(x = 1) { function x() {} }
+//
+// function f(x = 1) { function _x() {} }
+func (t *Transpiler) separateParameterScope(fp *ast.FunctionParameters, body *ast.FunctionBody) {
+	if t.supports(options.Parameters) || isSimpleParameterList(fp) {
+		return
+	}
+
+	parameters := make(map[string]bool)
+
+	for _, parameter := range fp.List {
+		for _, name := range parameterNames(parameter) {
+			parameters[name] = true
+		}
+	}
+
+	used := newNameCollector()
+	used.FunctionParameters(fp)
+
+	declarations := newDeclarationFinder()
+	declarations.Statements(body.List)
+
+	for _, declaration := range declarations.found {
+		shadowed := used.names[declaration.name] && !parameters[declaration.name]
+		overwriting := parameters[declaration.name] && declaration.function
+
+		if !shadowed && !overwriting {
+			continue
+		}
+
+		renamer := newClassRenamer(declaration.name, t.uniqueName("_"+declaration.name))
+
+		if declaration.scope == nil {
+			renamer.Statements(body.List)
+		} else {
+			renamer.Statement(declaration.scope)
+		}
+	}
+}
+
+func hasDefaultValues(fp *ast.FunctionParameters) bool {
+	for _, parameter := range fp.List {
+		switch p := parameter.(type) {
+		case *ast.IdentifierParameter:
+			if p.DefaultValue != nil {
+				return true
+			}
+		case *ast.PatternParameter:
+			if p.DefaultValue != nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isSimpleParameterList tells whether the parameters are identifiers without default values
+func isSimpleParameterList(fp *ast.FunctionParameters) bool {
+	for _, parameter := range fp.List {
+		if p, ok := parameter.(*ast.IdentifierParameter); !ok || p.DefaultValue != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// readsArguments tells whether the parameters lowered to the body read arguments,
+// there are default values or rest
+func readsArguments(list []ast.FunctionParameter) bool {
+	if hasDefaultValues(&ast.FunctionParameters{List: list}) {
+		return true
+	}
+
+	for _, parameter := range list {
+		if _, ok := parameter.(*ast.RestParameter); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parameterNames(parameter ast.FunctionParameter) []string {
+	switch p := parameter.(type) {
+	case *ast.IdentifierParameter:
+		return []string{p.Id.Name}
+	case *ast.PatternParameter:
+		return binderNames(p.Binder)
+	case *ast.RestParameter:
+		return binderNames(p.Binder)
+	}
+
+	return nil
+}
+
+// nameCollector collects the names of all ids, those of nested functions too
+type nameCollector struct {
+	ast.Walker
+
+	names map[string]bool
+}
+
+func newNameCollector() *nameCollector {
+	c := &nameCollector{names: make(map[string]bool)}
+	c.Walker.Visitor = c
+
+	return c
+}
+
+func (c *nameCollector) ObjectPropertyName(name ast.ObjectPropertyName) ast.ObjectPropertyName {
+	if computed, ok := name.(*ast.ComputedName); ok {
+		return c.ComputedName(computed)
+	}
+
+	return name
+}
+
+func (c *nameCollector) Identifier(id *ast.Identifier) *ast.Identifier {
+	if id != nil {
+		c.names[id.Name] = true
+	}
+
+	return id
+}
+
+// declaration is the name declared by the function body,
+// scope is the block or loop of let, const and class, nil for the function
+type declaration struct {
+	name     string
+	scope    ast.IStmt
+	function bool // function declared by the body and not by a block of it
+}
+
+type declarationFinder struct {
+	ast.Walker
+
+	scopes []ast.IStmt
+	found  []declaration
+}
+
+func newDeclarationFinder() *declarationFinder {
+	f := &declarationFinder{}
+	f.Walker.Visitor = f
+
+	return f
+}
+
+func (f *declarationFinder) FunctionLiteral(fl *ast.FunctionLiteral) *ast.FunctionLiteral {
+	return fl
+}
+
+func (f *declarationFinder) ArrowFunctionExpression(af *ast.ArrowFunctionExpression) *ast.ArrowFunctionExpression {
+	return af
+}
+
+func (f *declarationFinder) ClassExpression(c *ast.ClassExpression) *ast.ClassExpression {
+	return c
+}
+
+func (f *declarationFinder) declare(name string, blockScoped bool) {
+	var scope ast.IStmt
+
+	if blockScoped && len(f.scopes) > 0 {
+		scope = f.scopes[len(f.scopes)-1]
+	}
+
+	f.found = append(f.found, declaration{name: name, scope: scope})
+}
+
+func (f *declarationFinder) scope(stmt ast.IStmt, walk func() ast.IStmt) ast.IStmt {
+	f.scopes = append(f.scopes, stmt)
+	defer func() { f.scopes = f.scopes[:len(f.scopes)-1] }()
+
+	return walk()
+}
+
+func (f *declarationFinder) BlockStatement(stmt *ast.BlockStatement) ast.IStmt {
+	return f.scope(stmt, func() ast.IStmt { return f.Walker.BlockStatement(stmt) })
+}
+
+func (f *declarationFinder) ForStatement(stmt *ast.ForStatement) ast.IStmt {
+	return f.scope(stmt, func() ast.IStmt { return f.Walker.ForStatement(stmt) })
+}
+
+func (f *declarationFinder) ForInStatement(stmt *ast.ForInStatement) ast.IStmt {
+	return f.scope(stmt, func() ast.IStmt { return f.Walker.ForInStatement(stmt) })
+}
+
+func (f *declarationFinder) ForOfStatement(stmt *ast.ForOfStatement) ast.IStmt {
+	return f.scope(stmt, func() ast.IStmt { return f.Walker.ForOfStatement(stmt) })
+}
+
+func (f *declarationFinder) SwitchStatement(stmt *ast.SwitchStatement) ast.IStmt {
+	return f.scope(stmt, func() ast.IStmt { return f.Walker.SwitchStatement(stmt) })
+}
+
+func (f *declarationFinder) VariableStatement(vs *ast.VariableStatement) ast.IStmt {
+	for _, binding := range vs.List {
+		for _, name := range binderNames(binding.Binder) {
+			f.declare(name, vs.Kind != token.VAR)
+		}
+	}
+
+	return vs
+}
+
+// Statement finds function declarations, nested functions are not visited
+func (f *declarationFinder) Statement(stmt ast.IStmt) ast.IStmt {
+	if fl, ok := stmt.(*ast.FunctionLiteral); ok {
+		if fl.Id != nil {
+			f.declare(fl.Id.Name, false)
+			f.found[len(f.found)-1].function = len(f.scopes) == 0
+		}
+
+		return fl
+	}
+
+	return f.Walker.Statement(stmt)
+}
+
+func (f *declarationFinder) ClassStatement(stmt *ast.ClassStatement) ast.IStmt {
+	if stmt.Expression.Name != nil {
+		f.declare(stmt.Expression.Name.Name, true)
+	}
+
+	return stmt
+}
@@ -50,8 +50,28 @@ type RefScope struct {
 	Parent *RefScope
 	Refs   map[string]*ast.SymbolRef
 
-	ids    *ids.Ids
-	minify bool
+	ids      *ids.Ids
+	minify   bool
+	function bool // scope of the function body or the module
+
+	tsDeclared map[string]map[string]bool // enums and namespaces by the variables namespaces export, see tsDeclare
+}
+
+// unresolvedRef is created for the id used before the declaration in the scope, see Transpiler.resolvedRef
+type unresolvedRef struct {
+	ref   *ast.SymbolRef
+	scope *RefScope
+}
+
+// within tells whether the scope is the given one or nested in it
+func (r *RefScope) within(scope *RefScope) bool {
+	for current := r; current != nil; current = current.Parent {
+		if current == scope {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (r *RefScope) NextMangledId() string {
@@ -80,6 +100,26 @@ func (r *RefScope) createRef(name string) *ast.SymbolRef {
 	return ref
 }
 
+// shadowsInFunction tells whether the ref shadows the one of the same function,
+// those are the same variable when let and const are lowered to var
+func (r *RefScope) shadowsInFunction(ref *ast.SymbolRef) bool {
+	if ref.ShadowsRef == nil {
+		return false
+	}
+
+	for scope := r; scope != nil; scope = scope.Parent {
+		if scope != r && scope.Refs[ref.Name] == ref.ShadowsRef {
+			return true
+		}
+
+		if scope.function {
+			break
+		}
+	}
+
+	return false
+}
+
 func (r *RefScope) GhostRef() *ast.SymbolRef {
 	return &ast.SymbolRef{
 		Name: r.NextMangledId(),
@@ -0,0 +1,166 @@
+package transpiler
+
+import (
+	"yawp/builtins"
+	"yawp/options"
+	"yawp/parser/ast"
+)
+
+// Spread is lowered for ES5 to concat of the arrays, spread iterables
+// are converted to arrays by @toConsumableArray, unless AssumeArrays option is set:
+//
+// [a, ...b] is [a].concat(@toConsumableArray(b))
+// o.f(a, ...b) is o.f.apply(o, [a].concat(@toConsumableArray(b)))
+// new F(a, ...b) is new (Function.prototype.bind.apply(F, [null, a].concat(@toConsumableArray(b))))()
+
+func (t *Transpiler) ArrayLiteral(al *ast.ArrayLiteral) *ast.ArrayLiteral {
+	al = t.Walker.ArrayLiteral(al)
+
+	if t.supports(options.Spread) || !hasSpread(al.List) {
+		return al
+	}
+
+	t.Walker.ReplacementExpression = t.spreadArray(al.List)
+
+	return nil
+}
+
+func (t *Transpiler) CallExpression(ce *ast.CallExpression) *ast.CallExpression {
+	if t.lowerOptionalChain(ce) {
+		return nil
+	}
+
+	ce = t.Walker.CallExpression(ce)
+
+	if t.supports(options.Spread) || !hasSpread(ce.ArgumentList) {
+		return ce
+	}
+
+	var fn ast.IExpr = ce.Callee
+	var this = undefined()
+
+	// methods are called with the object they are read from, evaluated once
+	if member, ok := ce.Callee.(*ast.MemberExpression); ok {
+		switch member.Left.(type) {
+		case *ast.Identifier, *ast.ThisExpression:
+			this = member.Left
+		default:
+			object := t.tempId("ref")
+			this = createId(object.Name)
+			fn = &ast.MemberExpression{Left: assign(object, member.Left), Right: member.Right, Kind: member.Kind}
+		}
+	}
+
+	t.Walker.ReplacementExpression = call(createMember(fn, "apply"), this, t.spreadArguments(ce.ArgumentList))
+
+	return nil
+}
+
+func (t *Transpiler) NewExpression(ne *ast.NewExpression) *ast.NewExpression {
+	ne = t.Walker.NewExpression(ne)
+
+	if t.supports(options.Spread) || !hasSpread(ne.ArgumentList) {
+		return ne
+	}
+
+	// bound function gets the arguments, null stands for this ignored by new
+	list := append([]ast.IExpr{&ast.NullLiteral{Literal: "null"}}, ne.ArgumentList...)
+	bind := createMember(createMember(createId("Function"), "prototype"), "bind")
+
+	t.Walker.ReplacementExpression = &ast.NewExpression{
+		Callee: call(createMember(bind, "apply"), ne.Callee, t.spreadArray(list)),
+	}
+
+	return nil
+}
+
+// callWith builds fn.call(this, ...arguments), spread arguments are passed by fn.apply for ES5
+func (t *Transpiler) callWith(fn ast.IExpr, this ast.IExpr, arguments []ast.IExpr) *ast.CallExpression {
+	if t.supports(options.Spread) || !hasSpread(arguments) {
+		return call(createMember(fn, "call"), append([]ast.IExpr{this}, arguments...)...)
+	}
+
+	return call(createMember(fn, "apply"), this, t.spreadArguments(arguments))
+}
+
+// spreadArguments builds the array of arguments passed by apply,
+// single spread argument is passed as is if it is array
+func (t *Transpiler) spreadArguments(list []ast.IExpr) ast.IExpr {
+	if len(list) == 1 {
+		if t.options.AssumeArrays {
+			return spreadValue(list[0])
+		}
+
+		return call(t.helper(builtins.ToConsumableArray), spreadValue(list[0]))
+	}
+
+	return t.spreadArray(list)
+}
+
+// spreadArray builds concat of the array parts, items between spreads are grouped to arrays
+func (t *Transpiler) spreadArray(list []ast.IExpr) ast.IExpr {
+	var parts []ast.IExpr
+	var items *ast.ArrayLiteral
+
+	for _, item := range list {
+		if !isSpread(item) {
+			if items == nil {
+				items = &ast.ArrayLiteral{}
+				parts = append(parts, items)
+			}
+
+			items.List = append(items.List, item)
+
+			continue
+		}
+
+		items = nil
+
+		if t.options.AssumeArrays {
+			parts = append(parts, spreadValue(item))
+		} else {
+			parts = append(parts, call(t.helper(builtins.ToConsumableArray), spreadValue(item)))
+		}
+	}
+
+	// concat copies the first part unless it is a new array already
+	if _, ok := parts[0].(*ast.ArrayLiteral); !ok && t.options.AssumeArrays {
+		parts = append([]ast.IExpr{&ast.ArrayLiteral{}}, parts...)
+	}
+
+	if len(parts) == 1 {
+		return parts[0]
+	}
+
+	return call(createMember(parts[0], "concat"), parts[1:]...)
+}
+
+func hasSpread(list []ast.IExpr) bool {
+	for _, item := range list {
+		if isSpread(item) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSpread(exp ast.IExpr) bool {
+	switch exp.(type) {
+	case *ast.ArraySpread, *ast.SpreadExpression:
+		return true
+	}
+
+	return false
+}
+
+func spreadValue(exp ast.IExpr) ast.IExpr {
+	switch spread := exp.(type) {
+	case *ast.ArraySpread:
+		return spread.Expression
+	case *ast.SpreadExpression:
+		return spread.Value
+	}
+
+	return exp
+}
@@ -0,0 +1,255 @@
+package transpiler
+
+import (
+	"yawp/builtins"
+	"yawp/parser/ast"
+	"yawp/parser/token"
+)
+
+// superRewriter visits the code moved out of class members, it replaces super with the object
+// holding super members and this with the given name. Nested functions and classes have
+// their own this and super, except for arrow functions
+type superRewriter struct {
+	ast.Walker
+
+	t           *Transpiler
+	superTarget func() ast.IExpr
+	superClass  string // super() calls are replaced with its call, see es5Class
+	this        string // replacement of this, derived class constructor returns it
+	arrows      int
+
+	jsxSelves map[ast.IExpr]ast.IExpr // this of the JSX elements moved, see jsxSelf
+}
+
+func newSuperRewriter(t *Transpiler, superTarget func() ast.IExpr, superClass string, this string) *superRewriter {
+	r := &superRewriter{
+		t:           t,
+		superTarget: superTarget,
+		superClass:  superClass,
+		this:        this,
+		jsxSelves:   t.jsxSelves,
+	}
+
+	r.Walker.Visitor = r
+
+	return r
+}
+
+func (r *superRewriter) FunctionLiteral(f *ast.FunctionLiteral) *ast.FunctionLiteral {
+	return f
+}
+
+func (r *superRewriter) ClassExpression(c *ast.ClassExpression) *ast.ClassExpression {
+	c.SuperClass = r.Expression(c.SuperClass)
+
+	return c
+}
+
+func (r *superRewriter) ArrowFunctionExpression(af *ast.ArrowFunctionExpression) *ast.ArrowFunctionExpression {
+	r.arrows++
+	defer func() {
+		r.arrows--
+	}()
+
+	return r.Walker.ArrowFunctionExpression(af)
+}
+
+func (r *superRewriter) ReturnStatement(stmt *ast.ReturnStatement) ast.IStmt {
+	if stmt.Argument == nil && r.superClass != "" && r.this != "" && r.arrows == 0 {
+		stmt.Argument = createId(r.this)
+	}
+
+	return r.Walker.ReturnStatement(stmt)
+}
+
+func (r *superRewriter) ThisExpression(te *ast.ThisExpression) *ast.ThisExpression {
+	if r.this != "" {
+		r.Walker.ReplacementExpression = createId(r.this)
+
+		return nil
+	}
+
+	return te
+}
+
+// JsxElement keeps this of the element, the development runtime gets it once the element is lowered
+func (r *superRewriter) JsxElement(e *ast.JSXElement) *ast.JSXElement {
+	r.jsxSelves[e] = r.thisValue()
+
+	return r.Walker.JsxElement(e)
+}
+
+func (r *superRewriter) JsxFragment(f *ast.JSXFragment) *ast.JSXFragment {
+	r.jsxSelves[f] = r.thisValue()
+
+	return r.Walker.JsxFragment(f)
+}
+
+func (r *superRewriter) thisValue() ast.IExpr {
+	if r.this != "" {
+		return createId(r.this)
+	}
+
+	return &ast.ThisExpression{}
+}
+
+// superMember returns the key of super.x member, nil for other expressions
+func (r *superRewriter) superMember(exp ast.IExpr) ast.IExpr {
+	me, ok := exp.(*ast.MemberExpression)
+
+	if !ok {
+		return nil
+	}
+
+	if _, ok := me.Left.(*ast.SuperExpression); !ok {
+		return nil
+	}
+
+	if me.Kind == ast.MKArray {
+		return r.Expression(me.Right)
+	}
+
+	return createString(me.Right.(*ast.Identifier).Name)
+}
+
+// MemberExpression replaces super.x with @superGet(_B.prototype, "x", this), so getters get this as the receiver
+func (r *superRewriter) MemberExpression(me *ast.MemberExpression) ast.IExpr {
+	if key := r.superMember(me); key != nil {
+		r.Walker.ReplacementExpression = r.get(key)
+
+		return nil
+	}
+
+	return r.Walker.MemberExpression(me)
+}
+
+// AssignExpression replaces super.x = v with @superSet(_B.prototype, "x", v, this),
+// compound assignments read the value first
+func (r *superRewriter) AssignExpression(a *ast.AssignmentExpression) *ast.AssignmentExpression {
+	key := r.superMember(a.Left)
+
+	if key == nil {
+		return r.Walker.AssignExpression(a)
+	}
+
+	value := r.Expression(a.Right)
+
+	if a.Operator != token.ASSIGN {
+		operator := a.Operator
+
+		if operator == token.EXPONENTIATION_ASSIGN {
+			operator = token.EXPONENTIATION
+		}
+
+		var reference ast.IExpr
+		key, reference = r.reference(key)
+
+		value = &ast.BinaryExpression{
+			Operator: operator,
+			Left:     r.get(reference),
+			Right:    value,
+		}
+	}
+
+	r.Walker.ReplacementExpression = r.set(key, value)
+
+	return nil
+}
+
+// UnaryExpression replaces ++super.x with the setter call, postfix ones return the old value saved to temp
+func (r *superRewriter) UnaryExpression(u *ast.UnaryExpression) *ast.UnaryExpression {
+	key := r.superMember(u.Operand)
+
+	if key == nil || (u.Operator != token.INCREMENT && u.Operator != token.DECREMENT) {
+		return r.Walker.UnaryExpression(u)
+	}
+
+	key, reference := r.reference(key)
+
+	operator := token.PLUS
+
+	if u.Operator == token.DECREMENT {
+		operator = token.MINUS
+	}
+
+	old := ast.IExpr(&ast.UnaryExpression{Operator: token.PLUS, Operand: r.get(reference)})
+	var temp *ast.Identifier
+
+	if u.Postfix {
+		temp = r.t.tempId("tmp")
+		old = assign(temp, old)
+	}
+
+	exp := r.set(key, &ast.BinaryExpression{
+		Operator: operator,
+		Left:     old,
+		Right:    &ast.NumberLiteral{Literal: "1"},
+	})
+
+	if temp != nil {
+		exp = &ast.SequenceExpression{Sequence: []ast.IExpr{exp, createId(temp.Name)}}
+	}
+
+	r.Walker.ReplacementExpression = exp
+
+	return nil
+}
+
+// reference saves the computed key to temp when it is read twice, literals are used as is
+func (r *superRewriter) reference(key ast.IExpr) (ast.IExpr, ast.IExpr) {
+	switch k := key.(type) {
+	case *ast.StringLiteral:
+		reference := *k
+
+		return k, &reference
+	case *ast.NumberLiteral:
+		reference := *k
+
+		return k, &reference
+	}
+
+	temp := r.t.tempId("key")
+
+	return assign(temp, key), createId(temp.Name)
+}
+
+func (r *superRewriter) get(key ast.IExpr) ast.IExpr {
+	return call(r.t.helper(builtins.SuperGet), r.superTarget(), key, r.thisValue())
+}
+
+func (r *superRewriter) set(key ast.IExpr, value ast.IExpr) ast.IExpr {
+	return call(r.t.helper(builtins.SuperSet), r.superTarget(), key, value, r.thisValue())
+}
+
+// CallExpression replaces super() with _this = _B.call(this) || this
+// and super.m() with @superGet(_B.prototype, "m", this).call(this)
+func (r *superRewriter) CallExpression(ce *ast.CallExpression) *ast.CallExpression {
+	for index, argument := range ce.ArgumentList {
+		ce.ArgumentList[index] = r.Expression(argument)
+	}
+
+	switch callee := ce.Callee.(type) {
+	case *ast.SuperExpression:
+		if r.superClass == "" {
+			return ce
+		}
+
+		r.Walker.ReplacementExpression = assign(createId(r.this), &ast.BinaryExpression{
+			Operator: token.LOGICAL_OR,
+			Left:     r.t.callWith(createId(r.superClass), &ast.ThisExpression{}, ce.ArgumentList),
+			Right:    &ast.ThisExpression{},
+		})
+
+		return nil
+	case *ast.MemberExpression:
+		if key := r.superMember(callee); key != nil {
+			r.Walker.ReplacementExpression = r.t.callWith(r.get(key), r.thisValue(), ce.ArgumentList)
+
+			return nil
+		}
+	}
+
+	ce.Callee = r.Expression(ce.Callee)
+
+	return ce
+}
@@ -0,0 +1,82 @@
+package transpiler
+
+import (
+	"strconv"
+	"yawp/parser/ast"
+	"yawp/parser/token"
+)
+
+// tempId allocates a variable for intermediate values of lowered code,
+// it is declared with var at the top of the closest function or module
+func (t *Transpiler) tempId(hint string) *ast.Identifier {
+	name := t.uniqueName("_" + hint)
+
+	t.functionScope.Temps = append(t.functionScope.Temps, createId(name))
+
+	return createId(name)
+}
+
+// moduleTempId allocates a variable declared at the top of the module,
+// it keeps values shared by all calls of the code using it
+func (t *Transpiler) moduleTempId(hint string) *ast.Identifier {
+	name := t.uniqueName("_" + hint)
+
+	t.moduleScope.Temps = append(t.moduleScope.Temps, createId(name))
+
+	return createId(name)
+}
+
+// uniqueName picks a name that is used neither by the module source
+// nor by the names generated before
+func (t *Transpiler) uniqueName(base string) string {
+	if t.names == nil {
+		t.names = make(map[string]bool)
+
+		if t.module.Symbols != nil {
+			collectNames(t.module.Symbols, t.names)
+		}
+	}
+
+	name := base
+
+	for index := 2; t.names[name]; index++ {
+		name = base + strconv.Itoa(index)
+	}
+
+	t.names[name] = true
+
+	return name
+}
+
+func collectNames(scope *ast.SymbolsScope, names map[string]bool) {
+	for _, symbol := range scope.Symbols {
+		names[symbol.Name] = true
+	}
+
+	for _, child := range scope.Children {
+		collectNames(child, names)
+	}
+}
+
+// declareTemps prepends var declaration of the temps allocated in the function scope
+func (t *Transpiler) declareTemps(list []ast.IStmt) []ast.IStmt {
+	if len(t.functionScope.Temps) == 0 {
+		return list
+	}
+
+	bindings := make([]*ast.VariableBinding, 0, len(t.functionScope.Temps))
+
+	for _, id := range t.functionScope.Temps {
+		bindings = append(bindings, &ast.VariableBinding{
+			Kind:   token.VAR,
+			Binder: &ast.IdentifierBinder{Id: id},
+		})
+	}
+
+	t.functionScope.Temps = nil
+
+	return prepend(list, &ast.VariableStatement{
+		Kind: token.VAR,
+		List: bindings,
+	})
+}
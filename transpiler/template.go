@@ -0,0 +1,234 @@
+package transpiler
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+	"yawp/builtins"
+	"yawp/options"
+	"yawp/parser/ast"
+	"yawp/parser/token"
+)
+
+// Templates are lowered for ES5 to concatenation converting substitutions to strings in order,
+// as + would call valueOf first, `a${b}c${d}` is "a".concat(b, "c").concat(d).
+// Tags get the strings array created once for the template, as native templates do:
+//
+// var _templateObject;
+// tag(_templateObject || (_templateObject = @taggedTemplateLiteral(["a\n"], ["a\\n"])), b);
+
+func (t *Transpiler) TemplateExpression(te *ast.TemplateExpression) *ast.TemplateExpression {
+	if t.supports(options.Templates) {
+		return t.Walker.TemplateExpression(te)
+	}
+
+	var result ast.IExpr = cookedString(te.Strings[0])
+
+	for index, substitution := range te.Substitutions {
+		arguments := []ast.IExpr{t.Expression(substitution)}
+
+		if next := te.Strings[index+1]; next != "" {
+			arguments = append(arguments, cookedString(next))
+		}
+
+		result = call(createMember(result, "concat"), arguments...)
+	}
+
+	t.Walker.ReplacementExpression = result
+
+	return nil
+}
+
+func (t *Transpiler) TaggedTemplateExpression(tte *ast.TaggedTemplateExpression) *ast.TaggedTemplateExpression {
+	if t.supports(options.Templates) {
+		return t.Walker.TaggedTemplateExpression(tte)
+	}
+
+	cooked := &ast.ArrayLiteral{}
+	raw := &ast.ArrayLiteral{}
+	same := true
+
+	for _, str := range tte.Template.Strings {
+		rawString := quote(str)
+
+		if literal, ok := cookTemplate(str); ok {
+			cooked.List = append(cooked.List, &ast.StringLiteral{Literal: literal})
+			same = same && literal == rawString
+		} else {
+			// invalid escapes are allowed in tagged templates, those strings are undefined
+			cooked.List = append(cooked.List, &ast.UnaryExpression{Operator: token.VOID, Operand: &ast.NumberLiteral{Literal: "0"}})
+			same = false
+		}
+
+		raw.List = append(raw.List, &ast.StringLiteral{Literal: rawString})
+	}
+
+	arguments := []ast.IExpr{cooked}
+
+	if !same {
+		arguments = append(arguments, raw)
+	}
+
+	object := t.moduleTempId("templateObject")
+
+	// _templateObject || (_templateObject = @taggedTemplateLiteral([...]))
+	strs := &ast.BinaryExpression{
+		Operator: token.LOGICAL_OR,
+		Left:     object,
+		Right:    assign(createId(object.Name), call(t.helper(builtins.TaggedTemplateLiteral), arguments...)),
+	}
+
+	list := []ast.IExpr{strs}
+
+	for _, substitution := range tte.Template.Substitutions {
+		list = append(list, t.Expression(substitution))
+	}
+
+	t.Walker.ReplacementExpression = call(t.Expression(tte.Tag), list...)
+
+	return nil
+}
+
+func cookedString(raw string) *ast.StringLiteral {
+	literal, ok := cookTemplate(raw)
+
+	if !ok {
+		panic("Invalid escape sequence in template")
+	}
+
+	return &ast.StringLiteral{Literal: literal}
+}
+
+// quote builds string literal of the given value
+func quote(value string) string {
+	var b strings.Builder
+
+	b.WriteRune('"')
+
+	for _, chr := range value {
+		writeStringChr(&b, chr)
+	}
+
+	b.WriteRune('"')
+
+	return b.String()
+}
+
+func writeStringChr(b *strings.Builder, chr rune) {
+	switch chr {
+	case '"':
+		b.WriteString(`\"`)
+	case '\\':
+		b.WriteString(`\\`)
+	case '\n':
+		b.WriteString(`\n`)
+	case '\u2028':
+		b.WriteString(`\u2028`)
+	case '\u2029':
+		b.WriteString(`\u2029`)
+	default:
+		b.WriteRune(chr)
+	}
+}
+
+// cookTemplate builds ES5 string literal of the template part with escapes processed,
+// false is returned for the invalid escapes
+func cookTemplate(raw string) (string, bool) {
+	var b strings.Builder
+
+	b.WriteRune('"')
+
+	for index := 0; index < len(raw); {
+		chr, size := utf8.DecodeRuneInString(raw[index:])
+		index += size
+
+		if chr != '\\' {
+			writeStringChr(&b, chr)
+
+			continue
+		}
+
+		escape, size := utf8.DecodeRuneInString(raw[index:])
+		index += size
+
+		switch escape {
+		case 'n', 't', 'r', 'b', 'f', 'v', '\\', '"', '\'', '\n', '\u2028', '\u2029':
+			// the same in strings, line terminators continue lines
+			b.WriteRune('\\')
+			b.WriteRune(escape)
+		case '0':
+			if index < len(raw) && raw[index] >= '0' && raw[index] <= '9' {
+				return "", false
+			}
+
+			b.WriteString(`\0`)
+		case '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			// octal escapes are not allowed
+			return "", false
+		case 'x':
+			if !isHex(raw, index, 2) {
+				return "", false
+			}
+
+			b.WriteString(`\x` + raw[index:index+2])
+			index += 2
+		case 'u':
+			if isHex(raw, index, 4) {
+				b.WriteString(`\u` + raw[index:index+4])
+				index += 4
+
+				continue
+			}
+
+			// \u{1F600} is written as surrogate pair for ES5
+			end := strings.IndexByte(raw[index:], '}')
+
+			if index >= len(raw) || raw[index] != '{' || end < 2 || !isHex(raw, index+1, end-1) {
+				return "", false
+			}
+
+			code, err := strconv.ParseUint(raw[index+1:index+end], 16, 32)
+
+			if err != nil || code > utf8.MaxRune {
+				return "", false
+			}
+
+			index += end + 1
+
+			if code > 0xFFFF {
+				code -= 0x10000
+				b.WriteString(hexEscape(0xD800 + code>>10))
+				b.WriteString(hexEscape(0xDC00 + code&0x3FF))
+			} else {
+				b.WriteString(hexEscape(code))
+			}
+		default:
+			// ` $ { and the rest stand for themselves
+			writeStringChr(&b, escape)
+		}
+	}
+
+	b.WriteRune('"')
+
+	return b.String(), true
+}
+
+func isHex(str string, start int, length int) bool {
+	if length <= 0 || start+length > len(str) {
+		return false
+	}
+
+	for _, chr := range str[start : start+length] {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", chr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hexEscape(code uint64) string {
+	hex := strconv.FormatUint(code, 16)
+
+	return `\u` + strings.Repeat("0", 4-len(hex)) + hex
+}
@@ -1,6 +1,7 @@
 package transpiler
 
 import (
+	"yawp/options"
 	"yawp/parser/ast"
 )
 
@@ -13,3 +14,16 @@ func (t *Transpiler) ThisExpression(te *ast.ThisExpression) *ast.ThisExpression
 
 	return nil
 }
+
+func (t *Transpiler) NewTargetExpression(nt *ast.NewTargetExpression) *ast.NewTargetExpression {
+	switch {
+	case t.thisScope.NeedsReplacement:
+		t.Walker.ReplacementExpression = t.getNewTargetReplacement()
+	case !t.supports(options.NewTarget):
+		t.Walker.ReplacementExpression = t.newTarget()
+	default:
+		return nt
+	}
+
+	return nil
+}
@@ -1,19 +1,27 @@
 package transpiler
 
 import (
+	"yawp/options"
 	"yawp/parser/ast"
 	"yawp/parser/token"
 )
 
 var undefinedThis = &ast.Js{Code: "void 0"}
 
+// ThisScope is opened by functions other than arrow ones, arrow functions lowered
+// to function literals reach this, arguments and new.target of the scope through variables
 type ThisScope struct {
 	Parent *ThisScope
 
-	NeedsReplacement bool
+	NeedsReplacement bool // inside of the lowered arrow function
 
-	ThisId          *ast.Identifier
-	ThisInitializer ast.IExpr
+	DerivedConstructor bool // this is not initialized before super() is called
+
+	NewTarget func() ast.IExpr // new.target lowered for the targets before ES2015, see lowerNewTarget
+
+	ThisId      *ast.Identifier
+	ArgumentsId *ast.Identifier
+	NewTargetId *ast.Identifier
 }
 
 func (t *Transpiler) pushThisScope() {
@@ -32,31 +40,102 @@ func (t *Transpiler) popThisScope() {
 
 func (t *Transpiler) getThisReplacement() *ast.Identifier {
 	if t.thisScope.ThisId == nil {
-		t.thisScope.ThisId = t.refScope.GhostId()
+		t.thisScope.ThisId = createId(t.uniqueName("_this"))
+	}
+
+	return createId(t.thisScope.ThisId.Name)
+}
+
+func (t *Transpiler) getArgumentsReplacement() *ast.Identifier {
+	if t.thisScope.ArgumentsId == nil {
+		t.thisScope.ArgumentsId = createId(t.uniqueName("_arguments"))
 	}
 
-	if t.thisScope.ThisInitializer == nil {
-		t.thisScope.ThisInitializer = undefinedThis
+	return createId(t.thisScope.ArgumentsId.Name)
+}
+
+func (t *Transpiler) getNewTargetReplacement() *ast.Identifier {
+	if t.thisScope.NewTargetId == nil {
+		t.thisScope.NewTargetId = createId(t.uniqueName("_newTarget"))
 	}
 
-	return t.thisScope.ThisId
+	return createId(t.thisScope.NewTargetId.Name)
 }
 
+// getThisDeclaration declares the variables replacing this, arguments and new.target,
+// this is undefined at the module level
 func (t *Transpiler) getThisDeclaration() ast.IStmt {
-	if t.thisScope.ThisInitializer == nil {
+	var bindings []*ast.VariableBinding
+
+	declare := func(id *ast.Identifier, initializer ast.IExpr) {
+		if id != nil {
+			bindings = append(bindings, &ast.VariableBinding{
+				Kind:        token.VAR,
+				Binder:      &ast.IdentifierBinder{Id: id},
+				Initializer: initializer,
+			})
+		}
+	}
+
+	if t.thisScope.Parent == nil {
+		declare(t.thisScope.ThisId, undefinedThis)
+	} else {
+		declare(t.thisScope.ThisId, &ast.ThisExpression{})
+	}
+
+	declare(t.thisScope.ArgumentsId, createId("arguments"))
+
+	if t.thisScope.NewTargetId != nil {
+		declare(t.thisScope.NewTargetId, t.newTarget())
+	}
+
+	if len(bindings) == 0 {
 		return nil
 	}
 
 	return &ast.VariableStatement{
 		Kind: token.VAR,
-		List: []*ast.VariableBinding{
-			{
-				Kind: token.VAR,
-				Binder: &ast.IdentifierBinder{
-					Id: t.thisScope.ThisId,
-				},
-				Initializer: t.thisScope.ThisInitializer,
+		List: bindings,
+	}
+}
+
+// newTarget is new.target of the function, it is lowered for the targets not supporting it
+func (t *Transpiler) newTarget() ast.IExpr {
+	if t.supports(options.NewTarget) {
+		return &ast.NewTargetExpression{}
+	}
+
+	if t.thisScope.NewTarget == nil {
+		return undefined()
+	}
+
+	return t.thisScope.NewTarget()
+}
+
+// lowerNewTarget checks how the function is called, it is named when anonymous:
+//
+// function F() { return new.target; }
+//
+// function F() { return this instanceof F ? this.constructor : void 0; }
+func (t *Transpiler) lowerNewTarget(fl *ast.FunctionLiteral) func() ast.IExpr {
+	return func() ast.IExpr {
+		if fl.Id == nil {
+			fl.Id = createId(t.uniqueName("_target"))
+		}
+
+		return &ast.ConditionalExpression{
+			Test: &ast.BinaryExpression{
+				Operator: token.INSTANCEOF,
+				Left:     &ast.ThisExpression{},
+				Right:    createId(fl.Id.Name),
 			},
-		},
+			Consequent: createMember(&ast.ThisExpression{}, "constructor"),
+			Alternate:  undefined(),
+		}
 	}
 }
+
+// constructorNewTarget is new.target of class constructors, those are always called by new
+func constructorNewTarget() ast.IExpr {
+	return createMember(&ast.ThisExpression{}, "constructor")
+}
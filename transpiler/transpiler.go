@@ -1,41 +1,103 @@
 package transpiler
 
 import (
+	"yawp/builtins"
 	"yawp/ids"
 	"yawp/options"
 	"yawp/parser/ast"
-	"yawp/parser/token"
 )
 
-func Transpile(module *ast.Module, options *options.Options) {
+// Transpile lowers the module for the target, warnings are reported for the code
+// that is transpiled but still needs the newer runtime and, when compliance is checked,
+// for the syntax the target does not support left as is
+func Transpile(module *ast.Module, options *options.Options) (warnings []error, err error) {
+	features, err := options.Features()
+
+	if err != nil {
+		return nil, err
+	}
+
 	transpiler := &Transpiler{
-		Walker:  ast.Walker{},
-		module:  module,
-		options: options,
-		ids:     module.Ids,
+		Walker:   ast.Walker{},
+		module:   module,
+		options:  jsxOptions(options, module.Pragmas),
+		features: features,
+		ids:      module.Ids,
+
+		unresolved:   make(map[*ast.SymbolRef][]*unresolvedRef),
+		classNames:   make(map[*ast.ClassExpression]string),
+		staticBlocks: make(map[*ast.ClassFieldStatement]bool),
+		jsxSelves:    make(map[ast.IExpr]ast.IExpr),
 	}
 	transpiler.Walker.Visitor = transpiler
-	transpiler.pushRefScope()
+	transpiler.pushRefScope().function = true
 	transpiler.pushThisScope()
+	transpiler.pushFunctionScope()
+	transpiler.moduleScope = transpiler.functionScope
+	transpiler.trackUninitialized(ast.Statements(module.Body))
+
+	if options.FlowChecks && !module.TypeScript && !module.Pragmas.NoFlow {
+		transpiler.checkFlowTypes(module)
+	}
 
 	module.Visit(transpiler)
+
+	if options.CheckCompliance {
+		transpiler.checkCompliance(module)
+	}
+
+	module.Body = transpiler.declareTemps(module.Body)
+	module.Body = transpiler.declareHelpers(module.Body)
+	module.Body = transpiler.declareJSXImports(module.Body)
+
+	sortWarnings(transpiler.warnings)
+
+	return transpiler.warnings, transpiler.err
 }
 
 type Transpiler struct {
 	ast.Walker
 
-	ids     *ids.Ids
-	module  *ast.Module
-	options *options.Options
+	ids      *ids.Ids
+	module   *ast.Module
+	options  *options.Options
+	features options.Features // supported by the target or the browsers, the others are lowered
 
 	refScope  *RefScope
 	thisScope *ThisScope
 
+	unresolved map[*ast.SymbolRef][]*unresolvedRef // ids used before the declaration by the parser refs, see resolvedRef
+
 	bindingRefKind ast.SymbolRefType
 
 	extraVariables []*ast.VariableBinding
 
+	assigningPattern bool // variables of the pattern are assigned and not declared, see patternAssignment
+
+	derivedClass bool // methods of the class with the super class are visited
+
+	classNames   map[*ast.ClassExpression]string   // inferred for anonymous classes lowered to ES5, see inferClassName
+	staticBlocks map[*ast.ClassFieldStatement]bool // static private fields run as static blocks, see classDecoration.apply
+
 	functionScope *FunctionScope
+	moduleScope   *FunctionScope
+
+	names map[string]bool // names taken by the module, see uniqueName
+
+	helpers []*builtins.Helper // runtime helpers called by the module, see helper
+
+	jsxImports []*jsxImport            // functions and components of the JSX runtime, see jsxImport
+	jsxSelves  map[ast.IExpr]ast.IExpr // this of the elements moved out of class members, see jsxSelf
+
+	uninitialized map[*ast.SymbolRef]bool // let and const of the function not declared yet, see TDZ
+
+	err      error
+	warnings []error
+}
+
+// supports reports whether the feature is emitted as is, the transform lowering it is skipped
+func (t *Transpiler) supports(feature options.Feature) bool {
+	return t.features.Has(feature)
 }
 
 func (t *Transpiler) pushFunctionScope() func() {
@@ -54,24 +116,11 @@ func (t *Transpiler) pushFunctionScope() func() {
 func (t *Transpiler) Body(stmts []ast.IStmt) []ast.IStmt {
 	stmts = t.Walker.Body(stmts)
 
-	extras := make([]ast.IStmt, 0)
-
-	if t.thisScope.ThisInitializer != nil {
-		extras = append(extras, &ast.VariableStatement{
-			Kind: token.VAR,
-			List: []*ast.VariableBinding{
-				{
-					Kind: token.VAR,
-					Binder: &ast.IdentifierBinder{
-						Id: t.thisScope.ThisId,
-					},
-					Initializer: t.thisScope.ThisInitializer,
-				},
-			},
-		})
+	if declaration := t.getThisDeclaration(); declaration != nil {
+		return append([]ast.IStmt{declaration}, stmts...)
 	}
 
-	return append(extras, stmts...)
+	return stmts
 }
 
 func (t *Transpiler) BlockStatement(bs *ast.BlockStatement) ast.IStmt {
@@ -81,11 +130,32 @@ func (t *Transpiler) BlockStatement(bs *ast.BlockStatement) ast.IStmt {
 	return t.Walker.BlockStatement(bs)
 }
 
+// Expression replaces ids used before the declaration with the TDZ error
+// and lowers destructuring assignment for ES5
+func (t *Transpiler) Expression(exp ast.IExpr) ast.IExpr {
+	switch e := exp.(type) {
+	case *ast.Identifier:
+		if t.isUninitialized(e) {
+			return t.tdz(e)
+		}
+	}
+
+	if vb := t.assignedPattern(exp); vb != nil {
+		return t.patternAssignment(vb, true)
+	}
+
+	return t.Walker.Expression(exp)
+}
+
 func (t *Transpiler) MemberExpression(me *ast.MemberExpression) ast.IExpr {
-	if leftIdentifier, ok := me.Left.(*ast.Identifier); ok {
-		me.Left = t.Identifier(leftIdentifier)
-	} else {
-		me.Left = t.Expression(me.Left)
+	if t.lowerOptionalChain(me) {
+		return nil
+	}
+
+	me.Left = t.Expression(me.Left)
+
+	if me.Kind == ast.MKArray {
+		me.Right = t.Expression(me.Right)
 	}
 
 	return me
@@ -0,0 +1,952 @@
+package transpiler
+
+import (
+	"regexp"
+	"strconv"
+	"yawp/options"
+	"yawp/parser/ast"
+	"yawp/parser/token"
+)
+
+var matchIdentifier = regexp.MustCompile(`^[$_\p{L}][$_\p{L}\d]*$`)
+
+// TypeScript syntax is either erased or lowered to plain js,
+// lowered code is built as untranspiled AST and then walked as usual
+// so refs of generated identifiers are resolved like the ones from source
+
+func (t *Transpiler) TSTypeAssertion(exp *ast.TSTypeAssertionExpression) *ast.TSTypeAssertionExpression {
+	t.Walker.ReplacementExpression = t.Expression(exp.Expression)
+
+	return nil
+}
+
+func (t *Transpiler) TSDeclareStatement(stmt *ast.TSDeclareStatement) *ast.TSDeclareStatement {
+	t.Walker.ReplacementStatement = &ast.EmptyStatement{}
+
+	return nil
+}
+
+func (t *Transpiler) TSEnumStatement(stmt *ast.TSEnumStatement) *ast.TSEnumStatement {
+	t.Walker.ReplacementStatement = t.Statement(tsLowered(t.tsEnum(stmt)))
+
+	return nil
+}
+
+func (t *Transpiler) TSNamespaceStatement(stmt *ast.TSNamespaceStatement) *ast.TSNamespaceStatement {
+	if isTSTypeOnly(stmt) {
+		t.Walker.ReplacementStatement = &ast.EmptyStatement{}
+
+		return nil
+	}
+
+	t.Walker.ReplacementStatement = t.Statement(tsLowered(t.tsNamespace(stmt)))
+
+	return nil
+}
+
+// tsLowered lists the declaration and the initialization, merged enums and namespaces have no declaration
+func tsLowered(declaration *ast.VariableStatement, initialization ast.IStmt) ast.Statements {
+	if declaration == nil {
+		return ast.Statements{initialization}
+	}
+
+	return ast.Statements{declaration, initialization}
+}
+
+func (t *Transpiler) TSImportEqualsStatement(stmt *ast.TSImportEqualsStatement) *ast.TSImportEqualsStatement {
+	// aliases used only as types are dropped like imports
+	if stmt.Name.Symbol != nil && stmt.Name.Symbol.Ref != nil && stmt.Name.Symbol.Ref.Usages == 0 {
+		t.Walker.ReplacementStatement = &ast.EmptyStatement{}
+
+		return nil
+	}
+
+	t.Walker.ReplacementStatement = t.Statement(tsImportEquals(stmt))
+
+	return nil
+}
+
+// export = a becomes module.exports = a
+func (t *Transpiler) TSExportAssignmentStatement(stmt *ast.TSExportAssignmentStatement) *ast.TSExportAssignmentStatement {
+	t.Walker.ReplacementStatement = t.Statement(&ast.ExpressionStatement{
+		StmtNode: stmt.StmtNode,
+		Expression: &ast.AssignmentExpression{
+			Operator: token.ASSIGN,
+			Left:     createMember(createId("module"), "exports"),
+			Right:    stmt.Expression,
+		},
+	})
+
+	return nil
+}
+
+func (t *Transpiler) ExportDeclaration(stmt *ast.ExportStatement) ast.IStmt {
+	if stmt.Kind == ast.EKType {
+		t.Walker.ReplacementStatement = &ast.EmptyStatement{}
+
+		return nil
+	}
+
+	var declaration *ast.VariableStatement
+	var initialization ast.IStmt
+
+	switch c := stmt.Clause.(type) {
+	case *ast.TSDeclareStatement, *ast.FlowTypeStatement, *ast.FlowInterfaceStatement:
+		t.Walker.ReplacementStatement = &ast.EmptyStatement{}
+
+		return nil
+	case *ast.TSEnumStatement:
+		declaration, initialization = t.tsEnum(c)
+	case *ast.TSNamespaceStatement:
+		if isTSTypeOnly(c) {
+			t.Walker.ReplacementStatement = &ast.EmptyStatement{}
+
+			return nil
+		}
+
+		declaration, initialization = t.tsNamespace(c)
+	case *ast.TSImportEqualsStatement:
+		declaration = tsImportEquals(c)
+	case *ast.FlowEnumStatement:
+		declaration = flowEnum(c)
+	case *ast.ExportClassClause:
+		if lowering := t.lowerClass(c.ClassExpression); lowering != nil {
+			t.Walker.ReplacementStatement = t.Statement(lowering.statements(stmt))
+
+			return nil
+		}
+
+		if t.supports(options.Classes) {
+			return t.Walker.ExportDeclaration(stmt)
+		}
+
+		declaration = t.classES5Declaration(c.ClassExpression)
+	case *ast.ExportDefaultClause:
+		// export default class A {} binds A, so it is lowered as a statement
+		if class, ok := c.Declaration.(*ast.ClassExpression); ok && class.Name != nil && (isDecorated(class) ||
+			!t.supports(options.ClassFields) && hasFields(class) || !t.supports(options.Classes)) {
+			lowered := ast.Statements{
+				&ast.ClassStatement{Expression: class},
+				&ast.ExportStatement{
+					StmtNode: stmt.StmtNode,
+					Clause: &ast.ExportNamedClause{
+						Exports: []*ast.NamedExportClause{
+							{
+								ModuleIdentifier: createId("default"),
+								LocalIdentifier:  createId(class.Name.Name),
+							},
+						},
+					},
+				},
+			}
+
+			t.Walker.ReplacementStatement = t.Statement(lowered)
+
+			return nil
+		}
+
+		return t.Walker.ExportDeclaration(stmt)
+	default:
+		return t.Walker.ExportDeclaration(stmt)
+	}
+
+	var lowered ast.Statements
+
+	// merged enums and namespaces are exported by the first declaration
+	if declaration != nil {
+		stmt.Clause = &ast.ExportVarClause{
+			Declaration: declaration,
+		}
+
+		lowered = append(lowered, stmt)
+	}
+
+	if initialization != nil {
+		lowered = append(lowered, initialization)
+	}
+
+	t.Walker.ReplacementStatement = t.Statement(lowered)
+
+	return nil
+}
+
+func (t *Transpiler) ImportDeclaration(stmt *ast.ImportStatement) ast.IStmt {
+	if stmt.Kind != ast.IKValue {
+		t.Walker.ReplacementStatement = &ast.EmptyStatement{}
+
+		return nil
+	}
+
+	if !t.module.TypeScript || len(stmt.Imports) == 0 {
+		return t.Walker.ImportDeclaration(stmt)
+	}
+
+	// TypeScript does not tell types from values in imports,
+	// so the ones never used as a value are dropped
+	imports := make([]*ast.ImportClause, 0, len(stmt.Imports))
+
+	for _, clause := range stmt.Imports {
+		symbol := clause.LocalIdentifier.Symbol
+
+		if symbol != nil && symbol.Ref != nil && symbol.Ref.Usages == 0 {
+			continue
+		}
+
+		imports = append(imports, clause)
+	}
+
+	if len(imports) == 0 {
+		t.Walker.ReplacementStatement = &ast.EmptyStatement{}
+
+		return nil
+	}
+
+	stmt.Imports = imports
+
+	return t.Walker.ImportDeclaration(stmt)
+}
+
+// tsClassMembers drops fields that only declare a type
+func tsClassMembers(c *ast.ClassExpression) {
+	body, ok := c.Body.(*ast.BlockStatement)
+
+	if !ok {
+		return
+	}
+
+	members := make([]ast.IStmt, 0, len(body.List))
+
+	for _, member := range body.List {
+		if m, ok := member.(*ast.ClassFieldStatement); ok &&
+			m.Initializer == nil && !m.Private && !m.Accessor && len(m.Decorators) == 0 {
+			continue
+		}
+
+		members = append(members, member)
+	}
+
+	body.List = members
+}
+
+// tsConstructor returns the constructor of the class, nil if there is none
+func tsConstructor(c *ast.ClassExpression) *ast.ClassMethodStatement {
+	body, ok := c.Body.(*ast.BlockStatement)
+
+	if !ok {
+		return nil
+	}
+
+	for _, member := range body.List {
+		if m, ok := member.(*ast.ClassMethodStatement); ok && isConstructor(m) {
+			return m
+		}
+	}
+
+	return nil
+}
+
+func hasParameterProperties(c *ast.ClassExpression) bool {
+	constructor := tsConstructor(c)
+
+	if constructor == nil {
+		return false
+	}
+
+	for _, param := range constructor.Parameters.List {
+		if ip, ok := param.(*ast.IdentifierParameter); ok && ip.Modifiers != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tsParameterProperties turns parameter properties into constructor assignments
+func tsParameterProperties(c *ast.ClassExpression) {
+	constructor := tsConstructor(c)
+
+	if constructor == nil {
+		return
+	}
+
+	assignments := make([]ast.IStmt, 0)
+
+	for _, param := range constructor.Parameters.List {
+		if ip, ok := param.(*ast.IdentifierParameter); ok && ip.Modifiers != 0 {
+			assignments = append(assignments, &ast.ExpressionStatement{
+				Expression: &ast.AssignmentExpression{
+					Operator: token.ASSIGN,
+					Left:     createMember(&ast.ThisExpression{}, ip.Id.Name),
+					Right:    createId(ip.Id.Name),
+				},
+			})
+
+			ip.Modifiers = 0
+		}
+	}
+
+	if len(assignments) == 0 {
+		return
+	}
+
+	// properties can not be assigned before super() is called
+	list := constructor.Body.List
+	position := 0
+
+	for index, stmt := range list {
+		if isSuperCall(stmt) {
+			position = index + 1
+			break
+		}
+	}
+
+	body := make(ast.Statements, 0, len(list)+len(assignments))
+	body = append(body, list[:position]...)
+	body = append(body, assignments...)
+	body = append(body, list[position:]...)
+
+	constructor.Body.List = body
+}
+
+func isSuperCall(stmt ast.IStmt) bool {
+	if es, ok := stmt.(*ast.ExpressionStatement); ok {
+		if call, ok := es.Expression.(*ast.CallExpression); ok {
+			_, ok = call.Callee.(*ast.SuperExpression)
+
+			return ok
+		}
+	}
+
+	return false
+}
+
+// tsEnum lowers enum E { A, B = 'b' } to
+// var E; (function(E){ E[E['A'] = 0] = 'A'; E['B'] = 'b' })(E || (E = {}))
+func (t *Transpiler) tsEnum(stmt *ast.TSEnumStatement) (*ast.VariableStatement, ast.IStmt) {
+	name := stmt.Name.Name
+	body := make(ast.Statements, 0, len(stmt.Members))
+
+	refs := &tsEnumMemberRefs{
+		enum:    name,
+		members: make(map[string]bool),
+		strings: make(map[string]bool),
+	}
+	refs.Walker.Visitor = refs
+
+	var previous string
+	var value float64
+	constant := true
+
+	for _, member := range stmt.Members {
+		var initializer ast.IExpr
+		reverse := true
+
+		switch {
+		case member.Initializer != nil:
+			initializer = refs.Expression(member.Initializer)
+
+			if number, ok := tsNumber(member.Initializer); ok {
+				value, constant = number, true
+			} else {
+				constant = false
+				reverse = !refs.isString(initializer)
+			}
+		case constant:
+			initializer = &ast.NumberLiteral{
+				Literal: strconv.FormatFloat(value, 'f', -1, 64),
+			}
+		default:
+			initializer = &ast.BinaryExpression{
+				Operator: token.PLUS,
+				Left:     tsEnumMember(name, previous),
+				Right:    &ast.NumberLiteral{Literal: "1"},
+			}
+		}
+
+		value++
+		previous = member.Name
+		refs.members[member.Name] = true
+		refs.strings[member.Name] = !reverse
+
+		// E[E['A'] = 0] = 'A', strings have no reverse mapping
+		assignment := &ast.AssignmentExpression{
+			Operator: token.ASSIGN,
+			Left:     createIndex(createId(name), member.Name),
+			Right:    initializer,
+		}
+
+		if reverse {
+			assignment = &ast.AssignmentExpression{
+				Operator: token.ASSIGN,
+				Left: &ast.MemberExpression{
+					Left:  createId(name),
+					Right: assignment,
+					Kind:  ast.MKArray,
+				},
+				Right: createString(member.Name),
+			}
+		}
+
+		body = append(body, &ast.ExpressionStatement{
+			Expression: assignment,
+		})
+	}
+
+	_, merged := t.tsDeclare(name)
+
+	return tsSelfInitialized(stmt.StmtNode, name, body, merged)
+}
+
+// tsNamespace lowers namespace N { export const a = 1; export function f() {} } to
+// var N; (function(N){ N.a = 1; function f() {} N.f = f })(N || (N = {})),
+// exported variables are the properties of N, so references to them are rewritten
+func (t *Transpiler) tsNamespace(stmt *ast.TSNamespaceStatement) (*ast.VariableStatement, ast.IStmt) {
+	name := stmt.Name.Name
+	body := make(ast.Statements, 0, len(stmt.Body))
+
+	// members exported by the merged namespaces before are not in the scope either
+	exports, merged := t.tsDeclare(name)
+	refs := newTSNamespaceRefs(name, exports)
+	var members []string // exported functions, classes, enums and namespaces
+
+	for _, s := range stmt.Body {
+		export, ok := s.(*ast.ExportStatement)
+
+		if !ok {
+			body = append(body, s)
+			continue
+		}
+
+		if export.Kind == ast.EKType {
+			continue
+		}
+
+		var exported []string
+
+		switch c := export.Clause.(type) {
+		case *ast.ExportVarClause:
+			for _, binding := range c.Declaration.List {
+				for _, id := range binderNames(binding.Binder) {
+					exports[id] = true
+				}
+
+				if binding.Initializer != nil {
+					body = append(body, &ast.ExpressionStatement{Expression: &ast.AssignmentExpression{
+						Operator: token.ASSIGN,
+						Left:     tsAssignmentTarget(binding.Binder),
+						Right:    binding.Initializer,
+					}})
+				}
+			}
+		case *ast.ExportFunctionClause:
+			body = append(body, c.FunctionLiteral)
+			exported = append(exported, c.FunctionLiteral.Id.Name)
+		case *ast.ExportClassClause:
+			body = append(body, &ast.ClassStatement{Expression: c.ClassExpression})
+			exported = append(exported, c.ClassExpression.Name.Name)
+		case *ast.TSEnumStatement:
+			body = append(body, c)
+			exported = append(exported, c.Name.Name)
+		case *ast.TSNamespaceStatement:
+			body = append(body, c)
+
+			if !isTSTypeOnly(c) {
+				exported = append(exported, c.Name.Name)
+			}
+		case *ast.TSImportEqualsStatement:
+			body = append(body, tsImportEquals(c))
+			exported = append(exported, c.Name.Name)
+		case ast.IStmt:
+			// types, declarations
+			body = append(body, c)
+		}
+
+		members = append(members, exported...)
+
+		for _, id := range exported {
+			body = append(body, &ast.ExpressionStatement{
+				Expression: &ast.AssignmentExpression{
+					Operator: token.ASSIGN,
+					Left:     createMember(createId(name), id),
+					Right:    createId(id),
+				},
+			})
+		}
+	}
+
+	// local declarations, exported functions and classes among them, shadow the merged members
+	refs.shadowed = append(refs.shadowed, tsDeclaredNames(body))
+	refs.Statements(body)
+
+	for _, id := range members {
+		exports[id] = true
+	}
+
+	return tsSelfInitialized(stmt.StmtNode, name, body, merged)
+}
+
+// tsAssignmentTarget turns the binder of the exported variable into the assignment target,
+// the ids are replaced by the namespace members later
+func tsAssignmentTarget(binder ast.PatternBinder) ast.IExpr {
+	switch b := binder.(type) {
+	case *ast.IdentifierBinder:
+		return b.Id
+	case *ast.ObjectBinding:
+		return b
+	case *ast.ArrayBinding:
+		return b
+	}
+
+	return nil
+}
+
+// tsDeclare returns the names exported by the namespaces of the name declared before in the scope,
+// enums and namespaces are merged so only the first one declares the variable
+func (t *Transpiler) tsDeclare(name string) (map[string]bool, bool) {
+	if t.refScope.tsDeclared == nil {
+		t.refScope.tsDeclared = make(map[string]map[string]bool)
+	}
+
+	exports, merged := t.refScope.tsDeclared[name]
+
+	if !merged {
+		exports = make(map[string]bool)
+		t.refScope.tsDeclared[name] = exports
+	}
+
+	return exports, merged
+}
+
+// tsSelfInitialized builds var declaration and iife
+// which initializes it, enums and namespaces could be merged,
+// so existing value is extended and the variable is declared only once
+func tsSelfInitialized(node ast.StmtNode, name string, body ast.Statements, merged bool) (*ast.VariableStatement, ast.IStmt) {
+	var declaration *ast.VariableStatement
+
+	if !merged {
+		declaration = &ast.VariableStatement{
+			StmtNode: node,
+			Kind:     token.VAR,
+			List: []*ast.VariableBinding{
+				{
+					Kind:   token.VAR,
+					Binder: &ast.IdentifierBinder{Id: createId(name)},
+				},
+			},
+		}
+	}
+	initialization := &ast.ExpressionStatement{
+		Expression: &ast.CallExpression{
+			Callee: &ast.FunctionLiteral{
+				Parameters: &ast.FunctionParameters{
+					List: []ast.FunctionParameter{
+						&ast.IdentifierParameter{Id: createId(name)},
+					},
+				},
+				Body: &ast.FunctionBody{List: body},
+			},
+			ArgumentList: []ast.IExpr{
+				&ast.BinaryExpression{
+					Operator: token.LOGICAL_OR,
+					Left:     createId(name),
+					Right: &ast.AssignmentExpression{
+						Operator: token.ASSIGN,
+						Left:     createId(name),
+						Right:    &ast.ObjectLiteral{},
+					},
+				},
+			},
+		},
+	}
+
+	return declaration, initialization
+}
+
+func tsImportEquals(stmt *ast.TSImportEqualsStatement) *ast.VariableStatement {
+	return &ast.VariableStatement{
+		StmtNode: stmt.StmtNode,
+		Kind:     token.VAR,
+		List: []*ast.VariableBinding{
+			{
+				Kind:        token.VAR,
+				Binder:      &ast.IdentifierBinder{Id: createId(stmt.Name.Name)},
+				Initializer: stmt.ModuleReference,
+			},
+		},
+	}
+}
+
+// isTSTypeOnly reports whether statement has no runtime semantics
+func isTSTypeOnly(stmt ast.IStmt) bool {
+	switch s := stmt.(type) {
+	case *ast.FlowTypeStatement, *ast.FlowInterfaceStatement, *ast.TSDeclareStatement, *ast.EmptyStatement:
+		return true
+	case *ast.TSNamespaceStatement:
+		for _, bodyStmt := range s.Body {
+			if !isTSTypeOnly(bodyStmt) {
+				return false
+			}
+		}
+
+		return true
+	case *ast.ExportStatement:
+		if s.Kind == ast.EKType {
+			return true
+		}
+
+		if clause, ok := s.Clause.(ast.IStmt); ok {
+			return isTSTypeOnly(clause)
+		}
+	}
+
+	return false
+}
+
+func binderNames(binder ast.PatternBinder) []string {
+	ids := binderIds(binder)
+	names := make([]string, len(ids))
+
+	for index, id := range ids {
+		names[index] = id.Name
+	}
+
+	return names
+}
+
+func bindersNames(list []ast.PatternBinder) []string {
+	names := make([]string, 0, len(list))
+
+	for _, binder := range list {
+		names = append(names, binderNames(binder)...)
+	}
+
+	return names
+}
+
+// binderIds returns the ids the binder declares
+func binderIds(binder ast.PatternBinder) []*ast.Identifier {
+	switch b := binder.(type) {
+	case *ast.IdentifierBinder:
+		return []*ast.Identifier{b.Id}
+	case *ast.ObjectBinding:
+		return bindersIds(b.List)
+	case *ast.ArrayBinding:
+		return bindersIds(b.List)
+	case *ast.ObjectPropertyBinder:
+		return binderIds(b.Binder)
+	case *ast.ArrayItemBinder:
+		return binderIds(b.Binder)
+	case *ast.ObjectRestBinder:
+		return binderIds(b.Binder)
+	case *ast.ArrayRestBinder:
+		return binderIds(b.Binder)
+	}
+
+	return nil
+}
+
+func bindersIds(list []ast.PatternBinder) []*ast.Identifier {
+	ids := make([]*ast.Identifier, 0, len(list))
+
+	for _, binder := range list {
+		ids = append(ids, binderIds(binder)...)
+	}
+
+	return ids
+}
+
+// tsEnumMemberRefs rewrites references to previous members
+// in enum initializers: enum E { A = 1, B = A << 1 }
+type tsEnumMemberRefs struct {
+	ast.Walker
+
+	enum    string
+	members map[string]bool
+	strings map[string]bool // members of string values
+}
+
+func (r *tsEnumMemberRefs) Expression(exp ast.IExpr) ast.IExpr {
+	if id, ok := exp.(*ast.Identifier); ok && r.members[id.Name] {
+		return tsEnumMember(r.enum, id.Name)
+	}
+
+	return r.Walker.Expression(exp)
+}
+
+// isString tells whether the rewritten initializer is a string, those of string members included
+func (r *tsEnumMemberRefs) isString(exp ast.IExpr) bool {
+	switch e := exp.(type) {
+	case *ast.StringLiteral, *ast.TemplateExpression:
+		return true
+	case *ast.BinaryExpression:
+		return e.Operator == token.PLUS && (r.isString(e.Left) || r.isString(e.Right))
+	case *ast.MemberExpression:
+		if enum, ok := e.Left.(*ast.Identifier); ok && enum.Name == r.enum {
+			return r.strings[tsMemberName(e)]
+		}
+	}
+
+	return false
+}
+
+// tsMemberName is the name of E.A and E['A'] members, empty for computed ones
+func tsMemberName(me *ast.MemberExpression) string {
+	switch right := me.Right.(type) {
+	case *ast.Identifier:
+		if me.Kind == ast.MKObject {
+			return right.Name
+		}
+	case *ast.StringLiteral:
+		if right.Raw {
+			return right.Literal
+		}
+
+		return right.Literal[1 : len(right.Literal)-1]
+	}
+
+	return ""
+}
+
+// tsNamespaceRefs rewrites references to the variables exported by namespaces to N.x,
+// names declared by nested functions and blocks shadow them
+type tsNamespaceRefs struct {
+	ast.Walker
+
+	namespace string
+	exports   map[string]bool
+	shadowed  []map[string]bool
+}
+
+func newTSNamespaceRefs(namespace string, exports map[string]bool) *tsNamespaceRefs {
+	r := &tsNamespaceRefs{namespace: namespace, exports: exports}
+	r.Walker.Visitor = r
+
+	return r
+}
+
+func (r *tsNamespaceRefs) exported(name string) bool {
+	if !r.exports[name] {
+		return false
+	}
+
+	for _, names := range r.shadowed {
+		if names[name] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *tsNamespaceRefs) shadow(names map[string]bool) func() {
+	r.shadowed = append(r.shadowed, names)
+
+	return func() {
+		r.shadowed = r.shadowed[:len(r.shadowed)-1]
+	}
+}
+
+func (r *tsNamespaceRefs) Expression(exp ast.IExpr) ast.IExpr {
+	if id, ok := exp.(*ast.Identifier); ok && r.exported(id.Name) {
+		return createMember(createId(r.namespace), id.Name)
+	}
+
+	return r.Walker.Expression(exp)
+}
+
+// PatternBinder rewrites the ids assigned by destructuring, declared ones shadow the members
+func (r *tsNamespaceRefs) PatternBinder(binder ast.PatternBinder) ast.PatternBinder {
+	if b, ok := binder.(*ast.IdentifierBinder); ok && r.exported(b.Id.Name) {
+		return &ast.ExpressionBinder{Expression: createMember(createId(r.namespace), b.Id.Name)}
+	}
+
+	return r.Walker.PatternBinder(binder)
+}
+
+func (r *tsNamespaceRefs) FunctionLiteral(fl *ast.FunctionLiteral) *ast.FunctionLiteral {
+	names := functionNames(fl.Parameters.List, fl.Body)
+
+	if fl.Id != nil {
+		names[fl.Id.Name] = true
+	}
+
+	defer r.shadow(names)()
+
+	return r.Walker.FunctionLiteral(fl)
+}
+
+func (r *tsNamespaceRefs) ArrowFunctionExpression(af *ast.ArrowFunctionExpression) *ast.ArrowFunctionExpression {
+	defer r.shadow(functionNames(af.Parameters, af.Body))()
+
+	return r.Walker.ArrowFunctionExpression(af)
+}
+
+func (r *tsNamespaceRefs) CatchStatement(stmt *ast.CatchStatement) ast.IStmt {
+	names := make(map[string]bool)
+
+	if stmt != nil && stmt.Parameter != nil {
+		for _, name := range binderNames(stmt.Parameter) {
+			names[name] = true
+		}
+	}
+
+	defer r.shadow(names)()
+
+	return r.Walker.CatchStatement(stmt)
+}
+
+func (r *tsNamespaceRefs) BlockStatement(stmt *ast.BlockStatement) ast.IStmt {
+	defer r.shadow(blockNames(stmt))()
+
+	return r.Walker.BlockStatement(stmt)
+}
+
+func (r *tsNamespaceRefs) ForStatement(stmt *ast.ForStatement) ast.IStmt {
+	defer r.shadow(blockNames(stmt))()
+
+	return r.Walker.ForStatement(stmt)
+}
+
+func (r *tsNamespaceRefs) ForInStatement(stmt *ast.ForInStatement) ast.IStmt {
+	defer r.shadow(blockNames(stmt))()
+
+	return r.Walker.ForInStatement(stmt)
+}
+
+func (r *tsNamespaceRefs) ForOfStatement(stmt *ast.ForOfStatement) ast.IStmt {
+	defer r.shadow(blockNames(stmt))()
+
+	return r.Walker.ForOfStatement(stmt)
+}
+
+func (r *tsNamespaceRefs) SwitchStatement(stmt *ast.SwitchStatement) ast.IStmt {
+	defer r.shadow(blockNames(stmt))()
+
+	return r.Walker.SwitchStatement(stmt)
+}
+
+// TSEnumStatement keeps the references to the members of the enum
+func (r *tsNamespaceRefs) TSEnumStatement(stmt *ast.TSEnumStatement) *ast.TSEnumStatement {
+	names := make(map[string]bool, len(stmt.Members))
+
+	for _, member := range stmt.Members {
+		names[member.Name] = true
+	}
+
+	defer r.shadow(names)()
+
+	return r.Walker.TSEnumStatement(stmt)
+}
+
+// TSNamespaceStatement keeps the references to the members of the nested namespace
+func (r *tsNamespaceRefs) TSNamespaceStatement(stmt *ast.TSNamespaceStatement) *ast.TSNamespaceStatement {
+	defer r.shadow(tsDeclaredNames(stmt.Body))()
+
+	return r.Walker.TSNamespaceStatement(stmt)
+}
+
+// functionNames returns the names of the parameters and the variables of the function
+func functionNames(parameters []ast.FunctionParameter, body *ast.FunctionBody) map[string]bool {
+	names := make(map[string]bool)
+
+	for _, parameter := range parameters {
+		for _, name := range parameterNames(parameter) {
+			names[name] = true
+		}
+	}
+
+	if body != nil {
+		for name := range tsDeclaredNames(body.List) {
+			names[name] = true
+		}
+	}
+
+	return names
+}
+
+// blockNames returns the names declared by let, const and class of the block
+func blockNames(stmt ast.IStmt) map[string]bool {
+	names := make(map[string]bool)
+	declarations := newDeclarationFinder()
+	declarations.Statement(stmt)
+
+	for _, declaration := range declarations.found {
+		if declaration.scope == stmt {
+			names[declaration.name] = true
+		}
+	}
+
+	return names
+}
+
+// tsDeclaredNames returns the names declared by the statements of the function or namespace body,
+// exported ones too
+func tsDeclaredNames(body []ast.IStmt) map[string]bool {
+	names := make(map[string]bool)
+	declarations := newDeclarationFinder()
+
+	for _, stmt := range body {
+		if export, ok := stmt.(*ast.ExportStatement); ok {
+			switch c := export.Clause.(type) {
+			case *ast.ExportVarClause:
+				stmt = c.Declaration
+			case *ast.ExportFunctionClause:
+				stmt = c.FunctionLiteral
+			case *ast.ExportClassClause:
+				stmt = &ast.ClassStatement{Expression: c.ClassExpression}
+			case ast.IStmt:
+				stmt = c
+			}
+		}
+
+		switch s := stmt.(type) {
+		case *ast.FunctionLiteral:
+			if s.Id != nil {
+				names[s.Id.Name] = true
+			}
+		case *ast.TSEnumStatement:
+			names[s.Name.Name] = true
+		case *ast.TSNamespaceStatement:
+			names[s.Name.Name] = true
+		case *ast.TSImportEqualsStatement:
+			names[s.Name.Name] = true
+		default:
+			declarations.Statement(stmt)
+		}
+	}
+
+	for _, declaration := range declarations.found {
+		if declaration.scope == nil {
+			names[declaration.name] = true
+		}
+	}
+
+	return names
+}
+
+func tsEnumMember(enum string, member string) ast.IExpr {
+	if matchIdentifier.MatchString(member) {
+		return createMember(createId(enum), member)
+	}
+
+	return createIndex(createId(enum), member)
+}
+
+func tsNumber(exp ast.IExpr) (float64, bool) {
+	switch e := exp.(type) {
+	case *ast.NumberLiteral:
+		if value, err := strconv.ParseFloat(e.Literal, 64); err == nil {
+			return value, true
+		}
+
+		if value, err := strconv.ParseInt(e.Literal, 0, 64); err == nil {
+			return float64(value), true
+		}
+	case *ast.UnaryExpression:
+		if value, ok := tsNumber(e.Operand); ok && e.Operator == token.MINUS {
+			return -value, true
+		}
+	}
+
+	return 0, false
+}